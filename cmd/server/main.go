@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/deeploy-sh/deeploy/internal/server/app"
 	"github.com/deeploy-sh/deeploy/internal/server/config"
 	"github.com/deeploy-sh/deeploy/internal/server/logger"
 	"github.com/deeploy-sh/deeploy/internal/server/routes"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
 func main() {
@@ -22,6 +29,35 @@ func main() {
 	}
 	defer application.Close()
 
+	// Subcommands, e.g. "docker exec deeploy-app ./main backup"
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backup":
+			err = backup(application, os.Args[2:])
+		case "restore":
+			err = restore(application, os.Args[2:])
+		default:
+			err = fmt.Errorf("unknown command %q, expected backup or restore", os.Args[1])
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			application.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	go application.BackupService.RunScheduler(context.Background())
+	application.DatabaseService.ProvisionPending()
+	err = application.CertificateService.WriteConfig()
+	if err != nil {
+		slog.Warn("failed to write traefik certificates", "error", err)
+	}
+	err = application.TraefikService.WriteDNSCredentials()
+	if err != nil {
+		slog.Warn("failed to write dns credentials", "error", err)
+	}
+
 	handler := routes.Setup(application)
 	slog.Info("server starting", "port", cfg.Port)
 
@@ -30,3 +66,76 @@ func main() {
 		slog.Error("server failed", "error", err)
 	}
 }
+
+// backup writes an archive of the server configuration to a file.
+func backup(application *app.App, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := flags.String("o", "deeploy-backup-"+time.Now().Format("2006-01-02")+".tar.gz", "archive to write")
+	flags.Parse(args)
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := application.ServerBackupService.Backup(f)
+	if err != nil {
+		os.Remove(*output)
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup written to %s\n", *output)
+	printTables(manifest)
+	return nil
+}
+
+// restore replaces the server configuration with an archive written by backup.
+func restore(application *app.App, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	key := flags.String("key", "", "ENCRYPTION_KEY of the server the backup was created on, if it differs")
+	force := flags.Bool("force", false, "replace the data of a server that is already in use")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: restore [-key OLD_KEY] [-force] FILE")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("missing backup file")
+	}
+
+	hasUser, err := application.UserService.HasUser()
+	if err != nil {
+		return err
+	}
+	if hasUser && !*force {
+		return fmt.Errorf("this server already has users, restoring replaces all its data (use -force)")
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := application.ServerBackupService.Restore(f, *key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored backup of deeploy %s from %s\n", manifest.DeeployVersion, manifest.CreatedAt.Format(time.DateTime))
+	printTables(manifest)
+	fmt.Println("Restart deeploy to provision databases and certificates, then deploy your pods.")
+	return nil
+}
+
+func printTables(manifest *model.ServerBackupManifest) {
+	for _, table := range slices.Sorted(maps.Keys(manifest.Tables)) {
+		fmt.Printf("  %-22s %d\n", table, manifest.Tables[table])
+	}
+}
@@ -1,27 +1,42 @@
 package app
 
 import (
+	"github.com/deeploy-sh/deeploy/internal/server/backup"
+	"github.com/deeploy-sh/deeploy/internal/server/buildlog"
 	"github.com/deeploy-sh/deeploy/internal/server/config"
 	"github.com/deeploy-sh/deeploy/internal/server/crypto"
 	"github.com/deeploy-sh/deeploy/internal/server/db"
 	"github.com/deeploy-sh/deeploy/internal/server/docker"
 	"github.com/deeploy-sh/deeploy/internal/server/repo"
 	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 	"github.com/jmoiron/sqlx"
 )
 
 type App struct {
-	Cfg              *config.Config
-	DB               *sqlx.DB
-	Docker           *docker.DockerService
-	UserService      *service.UserService
-	ProjectService   *service.ProjectService
-	PodService       *service.PodService
-	PodEnvVarService *service.PodEnvVarService
-	PodDomainService *service.PodDomainService
-	GitTokenService  *service.GitTokenService
-	DeployService    *service.DeployService
-	TraefikService   *service.TraefikService
+	Cfg                       *config.Config
+	DB                        *sqlx.DB
+	Docker                    *docker.DockerService
+	UserService               *service.UserService
+	ProjectService            *service.ProjectService
+	MemberService             *service.MemberService
+	PodService                *service.PodService
+	PodEnvVarService          *service.PodEnvVarService
+	PodDomainService          *service.PodDomainService
+	PodVolumeService          *service.PodVolumeService
+	DatabaseService           *service.DatabaseService
+	BackupService             *service.BackupService
+	ServerBackupService       *service.ServerBackupService
+	GitTokenService           *service.GitTokenService
+	APITokenService           *service.APITokenService
+	SessionService            *service.SessionService
+	TwoFactorService          *service.TwoFactorService
+	RegistryCredentialService *service.RegistryCredentialService
+	DeployService             *service.DeployService
+	PodWebhookService         *service.PodWebhookService
+	TraefikService            *service.TraefikService
+	CertificateService        *service.CertificateService
+	AuditService              *service.AuditService
 }
 
 func New(cfg *config.Config) (*App, error) {
@@ -46,37 +61,101 @@ func New(cfg *config.Config) (*App, error) {
 		return nil, err
 	}
 
+	// Build logs (persisted per deployment)
+	buildLogs, err := buildlog.NewStore(cfg.BuildLogDir, cfg.BuildLogRetention)
+	if err != nil {
+		return nil, err
+	}
+
+	// Backup storages, S3 only if an endpoint is configured
+	localBackups, err := backup.NewLocalStorage(cfg.BackupDir)
+	if err != nil {
+		return nil, err
+	}
+	backupStorages := map[string]backup.Storage{model.BackupDestinationLocal: localBackups}
+	if cfg.BackupS3Endpoint != "" {
+		s3Backups, err := backup.NewS3Storage(backup.S3Config{
+			Endpoint:  cfg.BackupS3Endpoint,
+			Bucket:    cfg.BackupS3Bucket,
+			Region:    cfg.BackupS3Region,
+			AccessKey: cfg.BackupS3AccessKey,
+			SecretKey: cfg.BackupS3SecretKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		backupStorages[model.BackupDestinationS3] = s3Backups
+	}
+
 	// Repositories
 	userRepo := repo.NewUserRepo(database)
 	projectRepo := repo.NewProjectRepo(database)
+	memberRepo := repo.NewMemberRepo(database)
 	podRepo := repo.NewPodRepo(database)
+	deploymentRepo := repo.NewDeploymentRepo(database)
 	podEnvVarRepo := repo.NewPodEnvVarRepo(database)
 	podDomainRepo := repo.NewPodDomainRepo(database)
+	certificateRepo := repo.NewCertificateRepo(database)
+	podVolumeRepo := repo.NewPodVolumeRepo(database)
+	databaseRepo := repo.NewDatabaseRepo(database)
+	backupRepo := repo.NewBackupRepo(database)
 	gitTokenRepo := repo.NewGitTokenRepo(database)
+	apiTokenRepo := repo.NewAPITokenRepo(database)
+	sessionRepo := repo.NewSessionRepo(database)
+	recoveryCodeRepo := repo.NewRecoveryCodeRepo(database)
+	registryCredentialRepo := repo.NewRegistryCredentialRepo(database)
+	podWebhookRepo := repo.NewPodWebhookRepo(database)
 	serverSettingsRepo := repo.NewServerSettingsRepo(database)
+	dnsSettingsRepo := repo.NewDNSSettingsRepo(database)
+	serverBackupRepo := repo.NewServerBackupRepo(database)
+	auditRepo := repo.NewAuditRepo(database)
 
 	// Services
 	userService := service.NewUserService(userRepo)
 	projectService := service.NewProjectService(projectRepo)
-	podService := service.NewPodService(podRepo, dockerService)
+	memberService := service.NewMemberService(memberRepo, userRepo, podRepo, podVolumeRepo, databaseRepo, backupRepo)
+	podService := service.NewPodService(podRepo, podVolumeRepo, dockerService, buildLogs)
 	podEnvVarService := service.NewPodEnvVarService(podEnvVarRepo, encryptor)
-	podDomainService := service.NewPodDomainService(podDomainRepo)
+	podDomainService := service.NewPodDomainService(podDomainRepo, podRepo, cfg.PublicIP, cfg.IsDevelopment())
+	podVolumeService := service.NewPodVolumeService(podVolumeRepo)
+	databaseService := service.NewDatabaseService(databaseRepo, encryptor, dockerService)
+	backupService := service.NewBackupService(backupRepo, databaseService, podRepo, podVolumeRepo, dockerService, backupStorages)
+	serverBackupService := service.NewServerBackupService(serverBackupRepo, encryptor, cfg.DBDriver, cfg.TraefikConfigDir)
 	gitTokenService := service.NewGitTokenService(gitTokenRepo, encryptor)
-	deployService := service.NewDeployService(podRepo, podDomainRepo, podEnvVarService, gitTokenService, dockerService)
-	traefikService := service.NewTraefikService(serverSettingsRepo, cfg.TraefikConfigDir, cfg.IsDevelopment())
+	apiTokenService := service.NewAPITokenService(apiTokenRepo)
+	sessionService := service.NewSessionService(sessionRepo)
+	twoFactorService := service.NewTwoFactorService(userRepo, recoveryCodeRepo, encryptor)
+	registryCredentialService := service.NewRegistryCredentialService(registryCredentialRepo, encryptor)
+	traefikService := service.NewTraefikService(serverSettingsRepo, dnsSettingsRepo, encryptor, dockerService, cfg.TraefikConfigDir, cfg.IsDevelopment())
+	deployService := service.NewDeployService(podRepo, deploymentRepo, podDomainRepo, certificateRepo, podVolumeRepo, podEnvVarService, databaseService, gitTokenService, registryCredentialService, traefikService, dockerService, buildLogs)
+	podWebhookService := service.NewPodWebhookService(podWebhookRepo, encryptor)
+	certificateService := service.NewCertificateService(certificateRepo, encryptor, traefikService)
+	auditService := service.NewAuditService(auditRepo, podRepo)
 
 	return &App{
-		Cfg:              cfg,
-		DB:               database,
-		Docker:           dockerService,
-		UserService:      userService,
-		ProjectService:   projectService,
-		PodService:       podService,
-		PodEnvVarService: podEnvVarService,
-		PodDomainService: podDomainService,
-		GitTokenService:  gitTokenService,
-		DeployService:    deployService,
-		TraefikService:   traefikService,
+		Cfg:                       cfg,
+		DB:                        database,
+		Docker:                    dockerService,
+		UserService:               userService,
+		ProjectService:            projectService,
+		MemberService:             memberService,
+		PodService:                podService,
+		PodEnvVarService:          podEnvVarService,
+		PodDomainService:          podDomainService,
+		PodVolumeService:          podVolumeService,
+		DatabaseService:           databaseService,
+		BackupService:             backupService,
+		ServerBackupService:       serverBackupService,
+		GitTokenService:           gitTokenService,
+		APITokenService:           apiTokenService,
+		SessionService:            sessionService,
+		TwoFactorService:          twoFactorService,
+		RegistryCredentialService: registryCredentialService,
+		DeployService:             deployService,
+		PodWebhookService:         podWebhookService,
+		TraefikService:            traefikService,
+		CertificateService:        certificateService,
+		AuditService:              auditService,
 	}, nil
 }
 
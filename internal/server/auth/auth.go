@@ -25,6 +25,26 @@ func GetUser(ctx context.Context) *model.User {
 	return nil
 }
 
+// GetAPIToken returns the API token a request was authenticated with,
+// nil for session tokens.
+func GetAPIToken(ctx context.Context) *model.APIToken {
+	token, ok := ctx.Value("api_token").(*model.APIToken)
+	if ok {
+		return token
+	}
+	return nil
+}
+
+// GetSession returns the session a request was authenticated with,
+// nil for API tokens.
+func GetSession(ctx context.Context) *model.Session {
+	session, ok := ctx.Value("session").(*model.Session)
+	if ok {
+		return session
+	}
+	return nil
+}
+
 func IsAuthenticated(ctx context.Context) bool {
 	return GetUser(ctx) != nil
 }
@@ -6,11 +6,13 @@ import (
 	"log"
 	"sync"
 	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
 // CLISession represents a pending CLI authentication session
 type CLISession struct {
-	Token     string
+	Tokens    *model.AuthTokens
 	ExpiresAt time.Time
 }
 
@@ -35,12 +37,12 @@ func CreateSession() string {
 	return sessionID
 }
 
-// SetSessionToken stores the auth token for a session (creates if not exists)
-func SetSessionToken(sessionID, token string) {
+// SetSessionTokens stores the auth tokens for a session (creates if not exists)
+func SetSessionTokens(sessionID string, tokens *model.AuthTokens) {
 	sessionsMu.Lock()
 	defer sessionsMu.Unlock()
 
-	log.Printf("[CLI Auth] SetSessionToken: session=%s token=%s...", sessionID, token[:min(10, len(token))])
+	log.Printf("[CLI Auth] SetSessionTokens: session=%s", sessionID)
 
 	session, exists := sessions[sessionID]
 	if !exists {
@@ -49,12 +51,12 @@ func SetSessionToken(sessionID, token string) {
 		log.Printf("[CLI Auth] Created new session: %s", sessionID)
 	}
 
-	session.Token = token
+	session.Tokens = tokens
 }
 
-// GetSessionToken retrieves the token for a session
+// GetSessionTokens retrieves the auth tokens for a session
 // Creates the session if it doesn't exist (TUI polls before login completes)
-func GetSessionToken(sessionID string) (token string, ready bool) {
+func GetSessionTokens(sessionID string) (tokens *model.AuthTokens, ready bool) {
 	sessionsMu.Lock()
 	defer sessionsMu.Unlock()
 
@@ -63,25 +65,25 @@ func GetSessionToken(sessionID string) (token string, ready bool) {
 	// Create session if it doesn't exist (TUI started polling)
 	if !exists {
 		sessions[sessionID] = &CLISession{ExpiresAt: time.Now().Add(sessionTTL)}
-		log.Printf("[CLI Auth] GetSessionToken: session=%s CREATED (pending)", sessionID)
-		return "", false
+		log.Printf("[CLI Auth] GetSessionTokens: session=%s CREATED (pending)", sessionID)
+		return nil, false
 	}
 
 	// Session expired
 	if time.Now().After(session.ExpiresAt) {
-		log.Printf("[CLI Auth] GetSessionToken: session=%s EXPIRED", sessionID)
+		log.Printf("[CLI Auth] GetSessionTokens: session=%s EXPIRED", sessionID)
 		delete(sessions, sessionID)
-		return "", false
+		return nil, false
 	}
 
 	// Token not set yet
-	if session.Token == "" {
-		log.Printf("[CLI Auth] GetSessionToken: session=%s PENDING", sessionID)
-		return "", false
+	if session.Tokens == nil {
+		log.Printf("[CLI Auth] GetSessionTokens: session=%s PENDING", sessionID)
+		return nil, false
 	}
 
-	log.Printf("[CLI Auth] GetSessionToken: session=%s READY", sessionID)
-	return session.Token, true
+	log.Printf("[CLI Auth] GetSessionTokens: session=%s READY", sessionID)
+	return session.Tokens, true
 }
 
 // DeleteSession removes a session (call after successful poll)
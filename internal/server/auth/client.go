@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// browsers maps User-Agent markers to names, Edge and Chrome mention the
+// browsers they are based on, so they are checked first.
+var browsers = []struct{ marker, name string }{
+	{"Edg/", "Edge"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+// ClientName describes where a user signs in, shown in the sessions list.
+func ClientName(r *http.Request, isCLI bool) string {
+	if isCLI {
+		return "TUI"
+	}
+	userAgent := r.UserAgent()
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.marker) {
+			return "Browser (" + b.name + ")"
+		}
+	}
+	return "Browser"
+}
+
+// ClientIP returns the address of the client. Behind Traefik it is the
+// last address of X-Forwarded-For, the one Traefik appended. Earlier entries
+// come from the client, so the header is only read when the request comes
+// from a proxy on the local or Docker network.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" && isTrustedProxy(host) {
+		hops := strings.Split(forwarded, ",")
+		if ip := strings.TrimSpace(hops[len(hops)-1]); net.ParseIP(ip) != nil {
+			return ip
+		}
+	}
+	return host
+}
+
+// isTrustedProxy reports whether addr is a loopback or private address,
+// which is where Traefik connects from.
+func isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+}
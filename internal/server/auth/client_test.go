@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"203.0.113.7:5000", "", "203.0.113.7"},
+		{"203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+		{"172.18.0.2:5000", "", "172.18.0.2"},
+		{"172.18.0.2:5000", "198.51.100.1", "198.51.100.1"},
+		{"172.18.0.2:5000", "10.0.0.1, 198.51.100.1", "198.51.100.1"},
+		{"127.0.0.1:5000", "1.2.3.4,198.51.100.1", "198.51.100.1"},
+		{"[::1]:5000", "2001:db8::1", "2001:db8::1"},
+		{"172.18.0.2:5000", "not-an-ip", "172.18.0.2"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = tt.remoteAddr
+		if tt.forwarded != "" {
+			r.Header.Set("X-Forwarded-For", tt.forwarded)
+		}
+		if got := ClientIP(r); got != tt.want {
+			t.Errorf("ClientIP(%q, X-Forwarded-For %q) = %q, want %q", tt.remoteAddr, tt.forwarded, got, tt.want)
+		}
+	}
+}
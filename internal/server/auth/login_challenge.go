@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// LoginChallenge is a login that passed the password and waits for the second factor
+type LoginChallenge struct {
+	UserID    string
+	Attempts  int
+	ExpiresAt time.Time
+}
+
+var (
+	challenges        = make(map[string]*LoginChallenge)
+	challengesMu      sync.Mutex
+	challengeTTL      = 5 * time.Minute
+	challengeAttempts = 5
+)
+
+// CreateLoginChallenge starts the second step of a login and returns its ID
+func CreateLoginChallenge(userID string) string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	challengeID := hex.EncodeToString(b)
+
+	challengesMu.Lock()
+	defer challengesMu.Unlock()
+
+	// Drop expired challenges, they are never completed
+	now := time.Now()
+	for id, c := range challenges {
+		if now.After(c.ExpiresAt) {
+			delete(challenges, id)
+		}
+	}
+
+	challenges[challengeID] = &LoginChallenge{
+		UserID:    userID,
+		ExpiresAt: now.Add(challengeTTL),
+	}
+	return challengeID
+}
+
+// LoginChallengeUser returns the user of a challenge and counts an attempt.
+// A challenge is gone after it expired or ran out of attempts.
+func LoginChallengeUser(challengeID string) (userID string, ok bool) {
+	challengesMu.Lock()
+	defer challengesMu.Unlock()
+
+	challenge, exists := challenges[challengeID]
+	if !exists {
+		return "", false
+	}
+	if time.Now().After(challenge.ExpiresAt) || challenge.Attempts >= challengeAttempts {
+		delete(challenges, challengeID)
+		return "", false
+	}
+
+	challenge.Attempts++
+	return challenge.UserID, true
+}
+
+// DeleteLoginChallenge removes a challenge (call after the second factor succeeded)
+func DeleteLoginChallenge(challengeID string) {
+	challengesMu.Lock()
+	delete(challenges, challengeID)
+	challengesMu.Unlock()
+}
@@ -0,0 +1,268 @@
+// Package autobuild generates Dockerfiles for repos without one.
+// It looks for well-known project files in the repo root and picks the first
+// matching app type: Go, Node, Python or a static site.
+package autobuild
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DockerfileName is the file the generated Dockerfile is written to in the build context.
+const DockerfileName = "Dockerfile.deeploy"
+
+// ErrNotDetected is returned when no supported app type is found.
+var ErrNotDetected = errors.New("could not detect app type - add a Dockerfile or set the build strategy to dockerfile")
+
+// Plan is a detected app type with its generated Dockerfile.
+type Plan struct {
+	Name       string // e.g. "go", "node", "python", "static"
+	Dockerfile string
+}
+
+type detector func(dir string) (*Plan, error)
+
+// Order matters: a Node app often ships an index.html, so static comes last.
+var detectors = []detector{detectGo, detectNode, detectPython, detectStatic}
+
+// Detect inspects the repo in dir and returns a build plan.
+func Detect(dir string) (*Plan, error) {
+	for _, detect := range detectors {
+		plan, err := detect(dir)
+		if err != nil {
+			return nil, err
+		}
+		if plan != nil {
+			return plan, nil
+		}
+	}
+	return nil, ErrNotDetected
+}
+
+// Write detects the app type and writes the generated Dockerfile to dir.
+// Returns the plan, the Dockerfile path is DockerfileName relative to dir.
+func Write(dir string) (*Plan, error) {
+	plan, err := Detect(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, DockerfileName), []byte(plan.Dockerfile), 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write Dockerfile: %w", err)
+	}
+
+	return plan, nil
+}
+
+func exists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// --- Go ---
+
+func detectGo(dir string) (*Plan, error) {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Use the Go version from the go directive (major.minor) for the builder image
+	version := "1"
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		v, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "go ")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(strings.TrimSpace(v), ".")
+		if len(parts) >= 2 {
+			version = parts[0] + "." + parts[1]
+		}
+		break
+	}
+
+	pkg, err := goMainPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plan{
+		Name: "go",
+		Dockerfile: fmt.Sprintf(`FROM golang:%s-alpine AS build
+WORKDIR /src
+COPY go.mod go.sum* ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/app %s
+
+FROM alpine:3
+RUN apk add --no-cache ca-certificates tzdata
+WORKDIR /app
+COPY --from=build /out/app /app/app
+CMD ["/app/app"]
+`, version, pkg),
+	}, nil
+}
+
+// goMainPackage finds the package to build: the repo root if it has Go files,
+// otherwise the only directory below cmd/.
+func goMainPackage(dir string) (string, error) {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	if len(matches) > 0 {
+		return ".", nil
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "cmd"))
+	if err != nil {
+		return "", fmt.Errorf("no Go files in repo root and no cmd/ directory: %w", ErrNotDetected)
+	}
+	var cmds []string
+	for _, e := range entries {
+		if e.IsDir() {
+			cmds = append(cmds, e.Name())
+		}
+	}
+	if len(cmds) != 1 {
+		return "", fmt.Errorf("found %d commands in cmd/, expected exactly one: %w", len(cmds), ErrNotDetected)
+	}
+	return "./cmd/" + cmds[0], nil
+}
+
+// --- Node ---
+
+type packageJSON struct {
+	Main    string            `json:"main"`
+	Scripts map[string]string `json:"scripts"`
+}
+
+func detectNode(dir string) (*Plan, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pkg packageJSON
+	err = json.Unmarshal(data, &pkg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package.json: %w", err)
+	}
+
+	// Package manager from the lockfile
+	install := "npm install"
+	run := "npm run"
+	setup := ""
+	switch {
+	case exists(dir, "pnpm-lock.yaml"):
+		setup = "RUN corepack enable\n"
+		install = "pnpm install --frozen-lockfile"
+		run = "pnpm run"
+	case exists(dir, "yarn.lock"):
+		setup = "RUN corepack enable\n"
+		install = "yarn install --frozen-lockfile"
+		run = "yarn run"
+	case exists(dir, "package-lock.json"):
+		install = "npm ci"
+	}
+
+	var start string
+	switch {
+	case pkg.Scripts["start"] != "":
+		start = fmt.Sprintf(`CMD ["sh", "-c", "%s start"]`, run)
+	case pkg.Main != "":
+		start = fmt.Sprintf(`CMD ["node", %q]`, pkg.Main)
+	case exists(dir, "index.js"):
+		start = `CMD ["node", "index.js"]`
+	default:
+		return nil, fmt.Errorf("package.json has no start script or main: %w", ErrNotDetected)
+	}
+
+	build := ""
+	if pkg.Scripts["build"] != "" {
+		build = fmt.Sprintf("RUN %s build\n", run)
+	}
+
+	return &Plan{
+		Name: "node",
+		Dockerfile: fmt.Sprintf(`FROM node:22-alpine
+WORKDIR /app
+%sCOPY package*.json yarn.lock* pnpm-lock.yaml* ./
+RUN %s
+COPY . .
+%sENV NODE_ENV=production
+%s
+`, setup, install, build, start),
+	}, nil
+}
+
+// --- Python ---
+
+func detectPython(dir string) (*Plan, error) {
+	if !exists(dir, "requirements.txt") {
+		return nil, nil
+	}
+
+	// A Procfile web process wins, then common entrypoints
+	var start string
+	procfile, err := os.ReadFile(filepath.Join(dir, "Procfile"))
+	if err == nil {
+		for _, line := range strings.Split(string(procfile), "\n") {
+			cmd, ok := strings.CutPrefix(strings.TrimSpace(line), "web:")
+			if ok {
+				start = fmt.Sprintf(`CMD ["sh", "-c", %q]`, strings.TrimSpace(cmd))
+				break
+			}
+		}
+	}
+	if start == "" {
+		for _, entry := range []string{"main.py", "app.py"} {
+			if exists(dir, entry) {
+				start = fmt.Sprintf(`CMD ["python", %q]`, entry)
+				break
+			}
+		}
+	}
+	if start == "" {
+		return nil, fmt.Errorf("no Procfile web process, main.py or app.py found: %w", ErrNotDetected)
+	}
+
+	return &Plan{
+		Name: "python",
+		Dockerfile: fmt.Sprintf(`FROM python:3.12-slim
+ENV PYTHONDONTWRITEBYTECODE=1 PYTHONUNBUFFERED=1
+WORKDIR /app
+COPY requirements.txt ./
+RUN pip install --no-cache-dir -r requirements.txt
+COPY . .
+%s
+`, start),
+	}, nil
+}
+
+// --- Static ---
+
+func detectStatic(dir string) (*Plan, error) {
+	if !exists(dir, "index.html") {
+		return nil, nil
+	}
+
+	return &Plan{
+		Name: "static",
+		Dockerfile: `FROM nginx:alpine
+COPY . /usr/share/nginx/html
+`,
+	}, nil
+}
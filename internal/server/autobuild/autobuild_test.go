@@ -0,0 +1,104 @@
+package autobuild
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		plan  string   // detected app type, empty = ErrNotDetected
+		want  []string // lines of the generated Dockerfile
+	}{
+		{
+			name:  "go in root",
+			files: map[string]string{"go.mod": "module app\n\ngo 1.24.2\n", "main.go": "package main"},
+			plan:  "go",
+			want:  []string{"FROM golang:1.24-alpine AS build", "go build -o /out/app ."},
+		},
+		{
+			name:  "go with one command",
+			files: map[string]string{"go.mod": "module app\n", "cmd/server/main.go": "package main"},
+			plan:  "go",
+			want:  []string{"FROM golang:1-alpine AS build", "go build -o /out/app ./cmd/server"},
+		},
+		{
+			name:  "go with two commands",
+			files: map[string]string{"go.mod": "module app\n", "cmd/a/main.go": "package main", "cmd/b/main.go": "package main"},
+		},
+		{
+			name:  "node with pnpm and build",
+			files: map[string]string{"package.json": `{"scripts":{"start":"node dist","build":"tsc"}}`, "pnpm-lock.yaml": "", "index.html": ""},
+			plan:  "node",
+			want:  []string{"RUN pnpm install --frozen-lockfile", "RUN pnpm run build", `CMD ["sh", "-c", "pnpm run start"]`},
+		},
+		{
+			name:  "node with main",
+			files: map[string]string{"package.json": `{"main":"server.js"}`, "package-lock.json": ""},
+			plan:  "node",
+			want:  []string{"RUN npm ci", `CMD ["node", "server.js"]`},
+		},
+		{
+			name:  "node without entrypoint",
+			files: map[string]string{"package.json": `{"scripts":{"test":"jest"}}`},
+		},
+		{
+			name:  "python with procfile",
+			files: map[string]string{"requirements.txt": "flask", "Procfile": "worker: celery\nweb: gunicorn app:app\n", "main.py": ""},
+			plan:  "python",
+			want:  []string{`CMD ["sh", "-c", "gunicorn app:app"]`},
+		},
+		{
+			name:  "python with app.py",
+			files: map[string]string{"requirements.txt": "flask", "app.py": ""},
+			plan:  "python",
+			want:  []string{`CMD ["python", "app.py"]`},
+		},
+		{
+			name:  "static",
+			files: map[string]string{"index.html": "<h1>hi</h1>"},
+			plan:  "static",
+			want:  []string{"FROM nginx:alpine"},
+		},
+		{
+			name:  "empty repo",
+			files: map[string]string{"README.md": "# app"},
+		},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		for name, content := range tt.files {
+			path := filepath.Join(dir, name)
+			os.MkdirAll(filepath.Dir(path), 0755)
+			err := os.WriteFile(path, []byte(content), 0644)
+			if err != nil {
+				t.Fatalf("%s: write %s: %v", tt.name, name, err)
+			}
+		}
+
+		plan, err := Detect(dir)
+		if tt.plan == "" {
+			if !errors.Is(err, ErrNotDetected) {
+				t.Errorf("%s: got %v, %v, want ErrNotDetected", tt.name, plan, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if plan.Name != tt.plan {
+			t.Errorf("%s: detected %s, want %s", tt.name, plan.Name, tt.plan)
+		}
+		for _, line := range tt.want {
+			if !strings.Contains(plan.Dockerfile, line) {
+				t.Errorf("%s: Dockerfile misses %q:\n%s", tt.name, line, plan.Dockerfile)
+			}
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package buildlog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store persists build logs on disk, one file per deployment:
+//
+//	<dir>/<podID>/<deploymentID>.log
+//
+// Files survive server restarts. Only the newest `retention` logs per pod are kept.
+type Store struct {
+	dir       string
+	retention int
+
+	mu    sync.Mutex
+	files map[string]*os.File                   // deploymentID -> open file while a build is running
+	subs  map[string]map[chan struct{}]struct{} // deploymentID -> subscribers waiting for new lines
+}
+
+func NewStore(dir string, retention int) (*Store, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build log directory: %w", err)
+	}
+
+	return &Store{
+		dir:       dir,
+		retention: retention,
+		files:     make(map[string]*os.File),
+		subs:      make(map[string]map[chan struct{}]struct{}),
+	}, nil
+}
+
+func (s *Store) path(podID, deploymentID string) string {
+	return filepath.Join(s.dir, podID, deploymentID+".log")
+}
+
+// Open creates the log file of a deployment and marks its build as running,
+// so subscribers can follow it before the first line is written.
+func (s *Store) Open(podID, deploymentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.open(podID, deploymentID)
+	return err
+}
+
+func (s *Store) open(podID, deploymentID string) (*os.File, error) {
+	f, ok := s.files[deploymentID]
+	if ok {
+		return f, nil
+	}
+
+	err := os.MkdirAll(filepath.Join(s.dir, podID), 0755)
+	if err != nil {
+		return nil, err
+	}
+	f, err = os.OpenFile(s.path(podID, deploymentID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s.files[deploymentID] = f
+	return f, nil
+}
+
+// Append writes a line to the build log of a deployment.
+// The file is opened on first write and kept open until Close.
+func (s *Store) Append(podID, deploymentID, line string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := s.open(podID, deploymentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.WriteString(line + "\n")
+	if err != nil {
+		return err
+	}
+
+	// Notify subscribers without blocking - a pending signal is enough,
+	// subscribers re-read the file from their own offset.
+	for ch := range s.subs[deploymentID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel that is signalled whenever lines are appended to
+// the log of a deployment and closed when the log is closed. If the build is
+// not running (anymore), the returned channel is already closed.
+// Call the returned cancel func when done.
+func (s *Store) Subscribe(deploymentID string) (<-chan struct{}, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if _, open := s.files[deploymentID]; !open {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if s.subs[deploymentID] == nil {
+		s.subs[deploymentID] = make(map[chan struct{}]struct{})
+	}
+	s.subs[deploymentID][ch] = struct{}{}
+
+	cancel := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subs[deploymentID][ch]; ok {
+			delete(s.subs[deploymentID], ch)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+// Close closes the log file of a finished deployment and applies retention.
+func (s *Store) Close(podID, deploymentID string) {
+	s.mu.Lock()
+	f, ok := s.files[deploymentID]
+	delete(s.files, deploymentID)
+	for ch := range s.subs[deploymentID] {
+		close(ch)
+	}
+	delete(s.subs, deploymentID)
+	s.mu.Unlock()
+
+	if ok {
+		f.Close()
+	}
+	s.prune(podID)
+}
+
+// Read returns up to limit lines starting at offset and the total number of lines.
+// A missing log file is not an error - it simply has no lines.
+func (s *Store) Read(podID, deploymentID string, offset, limit int) ([]string, int, error) {
+	f, err := os.Open(s.path(podID, deploymentID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	lines := []string{}
+	total := 0
+	_, err = readLines(f, func(line string) bool {
+		if total >= offset && (limit <= 0 || len(lines) < limit) {
+			lines = append(lines, line)
+		}
+		total++
+		return true
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return lines, total, nil
+}
+
+// Seek returns the byte position after the first offset lines of a log and
+// the number of lines skipped, fewer if the log is shorter.
+func (s *Store) Seek(podID, deploymentID string, offset int) (int64, int, error) {
+	if offset <= 0 {
+		return 0, 0, nil
+	}
+	f, err := os.Open(s.path(podID, deploymentID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	skipped := 0
+	pos, err := readLines(f, func(string) bool {
+		skipped++
+		return skipped < offset
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return pos, skipped, nil
+}
+
+// ReadFrom returns the lines after byte position pos and the position after
+// the last of them, so followers of a running build only read new lines.
+func (s *Store) ReadFrom(podID, deploymentID string, pos int64) ([]string, int64, error) {
+	f, err := os.Open(s.path(podID, deploymentID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, pos, nil
+	}
+	if err != nil {
+		return nil, pos, err
+	}
+	defer f.Close()
+
+	_, err = f.Seek(pos, io.SeekStart)
+	if err != nil {
+		return nil, pos, err
+	}
+
+	lines := []string{}
+	n, err := readLines(f, func(line string) bool {
+		lines = append(lines, line)
+		return true
+	})
+	if err != nil {
+		return nil, pos, err
+	}
+	return lines, pos + n, nil
+}
+
+// readLines calls fn for every line of r until it returns false and returns
+// the number of bytes read. A last line without newline is still being
+// written by Append and left for the next read.
+func readLines(r io.Reader, fn func(line string) bool) (int64, error) {
+	reader := bufio.NewReader(r)
+	var n int64
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		n += int64(len(line))
+		if !fn(strings.TrimSuffix(line, "\n")) {
+			return n, nil
+		}
+	}
+}
+
+// RemovePod deletes all build logs of a pod.
+func (s *Store) RemovePod(podID string) error {
+	return os.RemoveAll(filepath.Join(s.dir, podID))
+}
+
+// prune removes the oldest log files of a pod beyond the retention limit.
+func (s *Store) prune(podID string) {
+	if s.retention <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, podID))
+	if err != nil || len(entries) <= s.retention {
+		return
+	}
+
+	type logFile struct {
+		name    string
+		modTime int64
+	}
+	var files []logFile
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || e.IsDir() {
+			continue
+		}
+		files = append(files, logFile{name: e.Name(), modTime: info.ModTime().UnixNano()})
+	}
+
+	// Newest first
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime > files[j].modTime })
+
+	for _, f := range files[min(s.retention, len(files)):] {
+		os.Remove(filepath.Join(s.dir, podID, f.name))
+	}
+}
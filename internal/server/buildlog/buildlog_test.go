@@ -0,0 +1,139 @@
+package buildlog
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func newStore(t *testing.T, retention int) *Store {
+	t.Helper()
+	s, err := NewStore(t.TempDir(), retention)
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	return s
+}
+
+func TestRead(t *testing.T) {
+	s := newStore(t, 0)
+	for _, line := range []string{"a", "b", "c", "d"} {
+		err := s.Append("pod", "dep", line)
+		if err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	s.Close("pod", "dep")
+
+	tests := []struct {
+		offset, limit int
+		want          []string
+	}{
+		{0, 0, []string{"a", "b", "c", "d"}},
+		{1, 2, []string{"b", "c"}},
+		{3, 0, []string{"d"}},
+		{4, 0, []string{}},
+		{9, 2, []string{}},
+	}
+	for _, tt := range tests {
+		lines, total, err := s.Read("pod", "dep", tt.offset, tt.limit)
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if !slices.Equal(lines, tt.want) || total != 4 {
+			t.Errorf("Read(%d, %d) = %v, %d, want %v, 4", tt.offset, tt.limit, lines, total, tt.want)
+		}
+	}
+
+	lines, total, err := s.Read("pod", "missing", 0, 0)
+	if err != nil || len(lines) != 0 || total != 0 {
+		t.Errorf("missing log: got %v, %d, %v", lines, total, err)
+	}
+}
+
+func TestReadSkipsPartialLine(t *testing.T) {
+	s := newStore(t, 0)
+	err := s.Append("pod", "dep", "done")
+	if err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	// A line Append is still writing
+	f, err := os.OpenFile(s.path("pod", "dep"), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	f.WriteString("half")
+	f.Close()
+
+	lines, total, err := s.Read("pod", "dep", 0, 0)
+	if err != nil || !slices.Equal(lines, []string{"done"}) || total != 1 {
+		t.Errorf("Read = %v, %d, %v, want only the complete line", lines, total, err)
+	}
+
+	lines, pos, err := s.ReadFrom("pod", "dep", 0)
+	if err != nil || !slices.Equal(lines, []string{"done"}) || pos != int64(len("done\n")) {
+		t.Errorf("ReadFrom = %v, %d, %v, want the complete line", lines, pos, err)
+	}
+}
+
+func TestSeekAndReadFrom(t *testing.T) {
+	s := newStore(t, 0)
+	for _, line := range []string{"one", "two", "three"} {
+		s.Append("pod", "dep", line)
+	}
+
+	tests := []struct {
+		offset, skipped int
+		want            []string
+	}{
+		{0, 0, []string{"one", "two", "three"}},
+		{2, 2, []string{"three"}},
+		{5, 3, []string{}},
+	}
+	for _, tt := range tests {
+		pos, skipped, err := s.Seek("pod", "dep", tt.offset)
+		if err != nil || skipped != tt.skipped {
+			t.Fatalf("Seek(%d) = %d, %d, %v, want %d lines skipped", tt.offset, pos, skipped, err, tt.skipped)
+		}
+		lines, _, err := s.ReadFrom("pod", "dep", pos)
+		if err != nil || !slices.Equal(lines, tt.want) {
+			t.Errorf("ReadFrom after Seek(%d) = %v, %v, want %v", tt.offset, lines, err, tt.want)
+		}
+	}
+
+	// Following a build only returns new lines
+	_, pos, _ := s.ReadFrom("pod", "dep", 0)
+	s.Append("pod", "dep", "four")
+	lines, _, err := s.ReadFrom("pod", "dep", pos)
+	if err != nil || !slices.Equal(lines, []string{"four"}) {
+		t.Errorf("ReadFrom = %v, %v, want the new line", lines, err)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	s := newStore(t, 2)
+	now := time.Now()
+	for i, dep := range []string{"old", "mid", "new"} {
+		s.Append("pod", dep, "line")
+		s.Close("pod", dep)
+		modTime := now.Add(time.Duration(i-3) * time.Minute)
+		os.Chtimes(s.path("pod", dep), modTime, modTime)
+	}
+	s.Append("pod", "newest", "line")
+	s.Close("pod", "newest")
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, "pod"))
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if !slices.Equal(names, []string{"new.log", "newest.log"}) {
+		t.Errorf("got logs %v, want the newest two", names)
+	}
+}
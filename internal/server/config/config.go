@@ -3,20 +3,30 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	AppEnv           string
-	Port             string
-	DBDriver         string // "sqlite" or "pgx"
-	DBConnection     string // connection string
-	JWTSecret        string
-	EncryptionKey    string
-	CookieSecure     bool
-	BuildDir         string
-	TraefikConfigDir string // Directory for Traefik dynamic config files
+	AppEnv            string
+	Port              string
+	DBDriver          string // "sqlite" or "pgx"
+	DBConnection      string // connection string
+	JWTSecret         string
+	EncryptionKey     string
+	CookieSecure      bool
+	BuildDir          string
+	BuildLogDir       string // Directory for persisted build logs (one file per deployment)
+	BuildLogRetention int    // Number of build logs kept per pod
+	TraefikConfigDir  string // Directory for Traefik dynamic config files
+	PublicIP          string // IP pod domains must resolve to, empty = detected via ipify.org
+	BackupDir         string // Directory for local backups
+	BackupS3Endpoint  string // S3-compatible endpoint for backups, empty = local only
+	BackupS3Bucket    string
+	BackupS3Region    string
+	BackupS3AccessKey string
+	BackupS3SecretKey string
 }
 
 func Load() *Config {
@@ -33,15 +43,24 @@ func Load() *Config {
 	encryptionKey := requireEnv("ENCRYPTION_KEY", "exactly 32 characters")
 
 	return &Config{
-		AppEnv:           appEnv,
-		Port:             getEnv("PORT", "8090"),
-		DBDriver:         dbDriver,
-		DBConnection:     dbConnection,
-		JWTSecret:        jwtSecret,
-		EncryptionKey:    encryptionKey,
-		CookieSecure:     false, // HTTP allowed, Traefik enforces HTTPS when domain configured
-		BuildDir:         getEnv("BUILD_DIR", "/tmp/deeploy-builds"),
-		TraefikConfigDir: getEnv("TRAEFIK_CONFIG_DIR", "/traefik/dynamic"),
+		AppEnv:            appEnv,
+		Port:              getEnv("PORT", "8090"),
+		DBDriver:          dbDriver,
+		DBConnection:      dbConnection,
+		JWTSecret:         jwtSecret,
+		EncryptionKey:     encryptionKey,
+		CookieSecure:      false, // HTTP allowed, Traefik enforces HTTPS when domain configured
+		BuildDir:          getEnv("BUILD_DIR", "/tmp/deeploy-builds"),
+		BuildLogDir:       getEnv("BUILD_LOG_DIR", getBuildLogDirDefault()),
+		BuildLogRetention: getEnvInt("BUILD_LOG_RETENTION", 20),
+		TraefikConfigDir:  getEnv("TRAEFIK_CONFIG_DIR", "/traefik/dynamic"),
+		PublicIP:          getEnv("PUBLIC_IP", ""),
+		BackupDir:         getEnv("BACKUP_DIR", getBackupDirDefault()),
+		BackupS3Endpoint:  getEnv("BACKUP_S3_ENDPOINT", ""),
+		BackupS3Bucket:    getEnv("BACKUP_S3_BUCKET", ""),
+		BackupS3Region:    getEnv("BACKUP_S3_REGION", "us-east-1"),
+		BackupS3AccessKey: getEnv("BACKUP_S3_ACCESS_KEY", ""),
+		BackupS3SecretKey: getEnv("BACKUP_S3_SECRET_KEY", ""),
 	}
 }
 
@@ -61,6 +80,14 @@ func getEnv(key, fallback string) string {
 	return value
 }
 
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 func requireEnv(key, hint string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -80,3 +107,21 @@ func getDBConnectionDefault() string {
 	}
 	return "./data/deeploy.db?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)"
 }
+
+// getBuildLogDirDefault returns the build log directory next to the SQLite database,
+// so logs are persisted by the same host mount (/opt/deeploy/data:/data).
+func getBuildLogDirDefault() string {
+	if _, err := os.Stat("/.dockerenv"); err == nil {
+		return "/data/build-logs"
+	}
+	return "./data/build-logs"
+}
+
+// getBackupDirDefault returns the local backup directory next to the SQLite database,
+// so backups are persisted by the same host mount (/opt/deeploy/data:/data).
+func getBackupDirDefault() string {
+	if _, err := os.Stat("/.dockerenv"); err == nil {
+		return "/data/backups"
+	}
+	return "./data/backups"
+}
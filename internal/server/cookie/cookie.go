@@ -2,26 +2,33 @@ package cookie
 
 import (
 	"net/http"
+	"strings"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
-func SetCookie(w http.ResponseWriter, token string) {
+// maxAge matches how long a session lasts without being refreshed.
+const maxAge = 3600 * 24 * 30
+
+func SetCookie(w http.ResponseWriter, tokens *model.AuthTokens) {
+	setCookie(w, "token", tokens.Token, maxAge)
+	setCookie(w, "refresh_token", tokens.RefreshToken, maxAge)
+}
+
+func ClearCookie(w http.ResponseWriter) {
+	setCookie(w, "token", "", -1)
+	setCookie(w, "refresh_token", "", -1)
+}
+
+func setCookie(w http.ResponseWriter, name, value string, maxAge int) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    token,
+		Name:     name,
+		Value:    value,
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   false, // HTTP allowed, Traefik enforces HTTPS when domain configured
 		SameSite: http.SameSiteStrictMode,
-		MaxAge:   3600 * 24,
-	})
-}
-
-func ClearCookie(w http.ResponseWriter) {
-	http.SetCookie(w, &http.Cookie{
-		Name:   "token",
-		Value:  "",
-		Path:   "/",
-		MaxAge: -1,
+		MaxAge:   maxAge,
 	})
 }
 
@@ -32,3 +39,30 @@ func GetTokenFromCookie(r *http.Request) string {
 	}
 	return cookie.Value
 }
+
+func GetRefreshTokenFromCookie(r *http.Request) string {
+	cookie, err := r.Cookie("refresh_token")
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// SetRedirectCookie remembers the page a browser wanted before signing in.
+func SetRedirectCookie(w http.ResponseWriter, path string) {
+	setCookie(w, "redirect", path, 600)
+}
+
+// PopRedirect returns the remembered page and forgets it, "" if there is none.
+// Only paths on this server are returned.
+func PopRedirect(w http.ResponseWriter, r *http.Request) string {
+	cookie, err := r.Cookie("redirect")
+	if err != nil {
+		return ""
+	}
+	setCookie(w, "redirect", "", -1)
+	if !strings.HasPrefix(cookie.Value, "/") || strings.HasPrefix(cookie.Value, "//") {
+		return ""
+	}
+	return cookie.Value
+}
@@ -93,3 +93,8 @@ func runMigrations(db *sqlx.DB, driver string) error {
 	slog.Info("migrations completed")
 	return nil
 }
+
+// Version returns the version of the newest applied migration.
+func Version(db *sqlx.DB) (int64, error) {
+	return goose.GetDBVersion(db.DB)
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Deployment history: one row per build/rollback of a pod.
+-- Each build gets its own immutable image tag so older builds can be rolled back to.
+CREATE TABLE deployments (
+    id TEXT PRIMARY KEY,
+    pod_id TEXT NOT NULL REFERENCES pods(id) ON DELETE CASCADE,
+    commit_sha TEXT,
+    branch TEXT NOT NULL,
+    image_tag TEXT NOT NULL,
+    status TEXT NOT NULL,
+    triggered_by TEXT NOT NULL,
+    rollback_of TEXT,
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_deployments_pod_id ON deployments(pod_id);
+
+-- +goose Down
+DROP TABLE deployments;
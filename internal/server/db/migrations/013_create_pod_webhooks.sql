@@ -0,0 +1,12 @@
+-- +goose Up
+-- One push webhook per pod. The secret is encrypted like git tokens.
+CREATE TABLE pod_webhooks (
+    id TEXT PRIMARY KEY,
+    pod_id TEXT NOT NULL UNIQUE REFERENCES pods(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE pod_webhooks;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Requested ref (commit SHA or tag) and resolved commit message of a deployment
+ALTER TABLE deployments ADD COLUMN ref TEXT;
+ALTER TABLE deployments ADD COLUMN commit_message TEXT;
+
+-- Live commit of a pod, set by successful deploys and rollbacks
+ALTER TABLE pods ADD COLUMN commit_sha TEXT;
+ALTER TABLE pods ADD COLUMN commit_message TEXT;
+
+-- +goose Down
+ALTER TABLE deployments DROP COLUMN ref;
+ALTER TABLE deployments DROP COLUMN commit_message;
+ALTER TABLE pods DROP COLUMN commit_sha;
+ALTER TABLE pods DROP COLUMN commit_message;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Scope of an env var: runtime (container env), build (docker build arg) or both
+ALTER TABLE pod_env_vars ADD COLUMN scope TEXT NOT NULL DEFAULT 'runtime';
+
+-- +goose Down
+ALTER TABLE pod_env_vars DROP COLUMN scope;
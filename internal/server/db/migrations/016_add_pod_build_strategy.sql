@@ -0,0 +1,6 @@
+-- +goose Up
+-- How the image of a pod is built: dockerfile (from the repo) or auto (generated)
+ALTER TABLE pods ADD COLUMN build_strategy TEXT NOT NULL DEFAULT 'dockerfile';
+
+-- +goose Down
+ALTER TABLE pods DROP COLUMN build_strategy;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Credentials for pulling images from private registries
+CREATE TABLE registry_credentials (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    registry TEXT NOT NULL,
+    username TEXT NOT NULL,
+    password TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Pods deploy from a git repo (built) or a prebuilt image (pulled)
+ALTER TABLE pods ADD COLUMN source_type TEXT NOT NULL DEFAULT 'git';
+ALTER TABLE pods ADD COLUMN image TEXT;
+ALTER TABLE pods ADD COLUMN registry_credential_id TEXT REFERENCES registry_credentials(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE pods DROP COLUMN registry_credential_id;
+ALTER TABLE pods DROP COLUMN image;
+ALTER TABLE pods DROP COLUMN source_type;
+DROP TABLE registry_credentials;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Deployments built without the layer cache and with a fresh clone
+ALTER TABLE deployments ADD COLUMN no_cache BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE deployments DROP COLUMN no_cache;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Health check a new container must pass before it replaces the old one.
+-- status 0 accepts any 2xx/3xx, durations are in seconds.
+ALTER TABLE pods ADD COLUMN health_check_path TEXT NOT NULL DEFAULT '/';
+ALTER TABLE pods ADD COLUMN health_check_status INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pods ADD COLUMN health_check_interval INTEGER NOT NULL DEFAULT 2;
+ALTER TABLE pods ADD COLUMN health_check_timeout INTEGER NOT NULL DEFAULT 5;
+ALTER TABLE pods ADD COLUMN health_check_grace_period INTEGER NOT NULL DEFAULT 60;
+
+-- +goose Down
+ALTER TABLE pods DROP COLUMN health_check_path;
+ALTER TABLE pods DROP COLUMN health_check_status;
+ALTER TABLE pods DROP COLUMN health_check_interval;
+ALTER TABLE pods DROP COLUMN health_check_timeout;
+ALTER TABLE pods DROP COLUMN health_check_grace_period;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Container resource limits, 0 = unlimited. Memory in MB, CPU in cores.
+ALTER TABLE pods ADD COLUMN memory_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pods ADD COLUMN memory_reservation INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pods ADD COLUMN cpu_limit REAL NOT NULL DEFAULT 0;
+ALTER TABLE pods ADD COLUMN pids_limit INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE pods DROP COLUMN memory_limit;
+ALTER TABLE pods DROP COLUMN memory_reservation;
+ALTER TABLE pods DROP COLUMN cpu_limit;
+ALTER TABLE pods DROP COLUMN pids_limit;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Persistent storage of pods: named Docker volumes or host paths (bind mounts)
+CREATE TABLE pod_volumes (
+    id TEXT PRIMARY KEY,
+    pod_id TEXT NOT NULL REFERENCES pods(id) ON DELETE CASCADE,
+    type TEXT NOT NULL DEFAULT 'volume',
+    source TEXT NOT NULL,
+    mount_path TEXT NOT NULL,
+    read_only BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (pod_id, mount_path)
+);
+
+-- +goose Down
+DROP TABLE pod_volumes;
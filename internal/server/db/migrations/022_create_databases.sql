@@ -0,0 +1,31 @@
+-- +goose Up
+-- Managed databases: containers from official images, provisioned per project
+CREATE TABLE databases (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE RESTRICT,
+    title TEXT NOT NULL,
+    engine TEXT NOT NULL,
+    version TEXT NOT NULL,
+    username TEXT NOT NULL,
+    password TEXT NOT NULL,
+    database_name TEXT NOT NULL,
+    container_id TEXT,
+    status TEXT NOT NULL DEFAULT 'provisioning',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Links inject the connection URL of a database into a pod as env_var
+CREATE TABLE pod_databases (
+    pod_id TEXT NOT NULL REFERENCES pods(id) ON DELETE CASCADE,
+    database_id TEXT NOT NULL REFERENCES databases(id) ON DELETE CASCADE,
+    env_var TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (pod_id, database_id),
+    UNIQUE (pod_id, env_var)
+);
+
+-- +goose Down
+DROP TABLE pod_databases;
+DROP TABLE databases;
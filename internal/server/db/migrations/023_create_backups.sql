@@ -0,0 +1,37 @@
+-- +goose Up
+-- Backup schedules of databases and named pod volumes (one per target)
+CREATE TABLE backup_schedules (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    interval_hours INTEGER NOT NULL DEFAULT 24,
+    retention INTEGER NOT NULL DEFAULT 7,
+    destination TEXT NOT NULL DEFAULT 'local',
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_run_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (target_type, target_id)
+);
+
+-- Compressed archives in the local backup directory or an S3 bucket
+CREATE TABLE backups (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    destination TEXT NOT NULL,
+    storage_key TEXT NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'running',
+    error TEXT,
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_backups_target ON backups(target_type, target_id);
+
+-- +goose Down
+DROP TABLE backups;
+DROP TABLE backup_schedules;
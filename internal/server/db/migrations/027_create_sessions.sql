@@ -0,0 +1,20 @@
+-- +goose Up
+-- Sign-ins of users, each with a refresh token that is replaced on every use.
+-- Only hashes are stored, the previous hash detects a stolen refresh token.
+CREATE TABLE sessions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    refresh_token_hash TEXT NOT NULL UNIQUE,
+    previous_token_hash TEXT,
+    client TEXT NOT NULL,
+    ip TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    last_seen_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sessions_user ON sessions(user_id);
+CREATE INDEX idx_sessions_previous_token ON sessions(previous_token_hash);
+
+-- +goose Down
+DROP TABLE sessions;
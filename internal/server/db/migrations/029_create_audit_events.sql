@@ -0,0 +1,23 @@
+-- +goose Up
+-- Who changed what. Events keep the actor's email, they outlive deleted
+-- users, projects and targets.
+CREATE TABLE audit_events (
+    id TEXT PRIMARY KEY,
+    actor_id TEXT NOT NULL,
+    actor_email TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    target_name TEXT NOT NULL,
+    project_id TEXT NOT NULL DEFAULT '',
+    changes TEXT NOT NULL,
+    ip TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_audit_events_created ON audit_events(created_at);
+CREATE INDEX idx_audit_events_project ON audit_events(project_id);
+CREATE INDEX idx_audit_events_actor ON audit_events(actor_id);
+
+-- +goose Down
+DROP TABLE audit_events;
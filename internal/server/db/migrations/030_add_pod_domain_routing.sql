@@ -0,0 +1,38 @@
+-- +goose Up
+-- A domain may now route several paths to different pods, so the
+-- table is rebuilt with a unique (domain, path) instead of a unique domain
+CREATE TABLE pod_domains_new (
+    id TEXT PRIMARY KEY,
+    pod_id TEXT NOT NULL REFERENCES pods(id) ON DELETE CASCADE,
+    domain TEXT NOT NULL,
+    path TEXT NOT NULL DEFAULT '',
+    redirect_to TEXT NOT NULL DEFAULT '',
+    is_primary BOOLEAN DEFAULT false,
+    ssl_enabled BOOLEAN DEFAULT true,
+    type TEXT DEFAULT 'custom',
+    port INTEGER DEFAULT 80,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (domain, path)
+);
+INSERT INTO pod_domains_new (id, pod_id, domain, is_primary, ssl_enabled, type, port, created_at, updated_at)
+SELECT id, pod_id, domain, is_primary, ssl_enabled, type, port, created_at, updated_at FROM pod_domains;
+DROP TABLE pod_domains;
+ALTER TABLE pod_domains_new RENAME TO pod_domains;
+
+-- +goose Down
+CREATE TABLE pod_domains_old (
+    id TEXT PRIMARY KEY,
+    pod_id TEXT NOT NULL REFERENCES pods(id) ON DELETE CASCADE,
+    domain TEXT NOT NULL UNIQUE,
+    is_primary BOOLEAN DEFAULT false,
+    ssl_enabled BOOLEAN DEFAULT true,
+    type TEXT DEFAULT 'custom',
+    port INTEGER DEFAULT 80,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO pod_domains_old (id, pod_id, domain, is_primary, ssl_enabled, type, port, created_at, updated_at)
+SELECT id, pod_id, domain, is_primary, ssl_enabled, type, port, created_at, updated_at FROM pod_domains WHERE path = '';
+DROP TABLE pod_domains;
+ALTER TABLE pod_domains_old RENAME TO pod_domains;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE certificates (
+    id TEXT PRIMARY KEY,
+    pod_domain_id TEXT NOT NULL UNIQUE REFERENCES pod_domains(id) ON DELETE CASCADE,
+    certificate TEXT NOT NULL,
+    private_key TEXT NOT NULL,
+    issuer TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE certificates;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- A single row, the DNS provider of the ACME DNS challenge
+CREATE TABLE dns_settings (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    provider TEXT NOT NULL,
+    credentials TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE dns_settings;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Domains are only routed once their DNS points at the server. Existing
+-- domains are already live and count as verified.
+ALTER TABLE pod_domains ADD COLUMN status TEXT NOT NULL DEFAULT 'pending';
+ALTER TABLE pod_domains ADD COLUMN verified_at TIMESTAMP;
+UPDATE pod_domains SET status = 'verified', verified_at = CURRENT_TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE pod_domains DROP COLUMN verified_at;
+ALTER TABLE pod_domains DROP COLUMN status;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- The last time step a TOTP code was accepted for, codes of that step or
+-- earlier ones are rejected so an observed code can't be replayed.
+ALTER TABLE users ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN totp_last_step;
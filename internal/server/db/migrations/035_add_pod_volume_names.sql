@@ -0,0 +1,11 @@
+-- +goose Up
+-- Named volumes live under a Docker name of their pod, deeploy-<pod id>-<name>,
+-- so pods can't mount volumes of other projects, databases or deeploy itself.
+-- Existing volumes keep their name and data, unless it is one of deeploy's.
+ALTER TABLE pod_volumes ADD COLUMN volume_name TEXT NOT NULL DEFAULT '';
+UPDATE pod_volumes SET volume_name = source WHERE type = 'volume';
+UPDATE pod_volumes SET volume_name = 'deeploy-' || pod_id || '-' || source
+WHERE type = 'volume' AND (source LIKE 'deeploy%' OR source LIKE '%postgres_data' OR source LIKE '%letsencrypt_certs');
+
+-- +goose Down
+ALTER TABLE pod_volumes DROP COLUMN volume_name;
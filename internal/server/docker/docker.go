@@ -2,27 +2,37 @@ package docker
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
-	"github.com/google/uuid"
+	"github.com/moby/patternmatcher/ignorefile"
 )
 
 // ansiRegex matches ANSI escape codes (colors, formatting)
@@ -33,6 +43,9 @@ var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 // Must match: docker-compose.yml -> networks -> deeploy -> name
 const NetworkName = "deeploy"
 
+// TraefikContainerName is the Traefik container of docker-compose.yml.
+const TraefikContainerName = "deeploy-traefik"
+
 type DockerService struct {
 	client        *client.Client
 	buildDir      string
@@ -58,8 +71,17 @@ func NewDockerService(buildDir string, isDevelopment bool) (*DockerService, erro
 	}, nil
 }
 
-// CloneRepo clones a git repository. Token is optional (for private repos).
-func (d *DockerService) CloneRepo(repoURL, branch, token string) (string, error) {
+// sourceDir returns the persistent checkout directory of a pod.
+func (d *DockerService) sourceDir(podID string) string {
+	return filepath.Join(d.buildDir, "src", podID)
+}
+
+// SyncRepo checks out a git repository in the persistent source directory of a pod
+// and returns its path. An existing checkout is updated with a shallow fetch, so
+// only new objects are downloaded; fresh discards it and clones from scratch.
+// Token is optional (for private repos).
+// ref is an optional commit SHA or tag to check out instead of the branch tip.
+func (d *DockerService) SyncRepo(podID, repoURL, branch, ref, token string, fresh bool) (string, error) {
 	// Parse URL and inject token if provided
 	cloneURL := repoURL
 	if token != "" {
@@ -71,49 +93,141 @@ func (d *DockerService) CloneRepo(repoURL, branch, token string) (string, error)
 		cloneURL = parsed.String()
 	}
 
-	// Create unique directory for this clone
-	repoName := filepath.Base(strings.TrimSuffix(repoURL, ".git"))
-	cloneDir := filepath.Join(d.buildDir, fmt.Sprintf("%s-%s", repoName, uuid.New().String()[:8]))
+	dir := d.sourceDir(podID)
+	target := ref
+	if target == "" {
+		target = branch
+	}
+
+	// The URL is passed on every fetch instead of being stored as remote,
+	// so tokens are never written to disk
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	if !fresh && err == nil {
+		err = fetchCheckout(dir, cloneURL, target)
+		if err == nil {
+			return dir, nil
+		}
+		slog.Warn("failed to update source checkout, cloning again", "podID", podID, "error", err)
+	}
+
+	os.RemoveAll(dir)
+	err = os.MkdirAll(filepath.Dir(dir), 0755)
+	if err != nil {
+		return "", err
+	}
+	if ref == "" {
+		err = git("", "clone", "--depth", "1", "--branch", branch, cloneURL, dir)
+	} else {
+		err = cloneRef(cloneURL, ref, dir)
+	}
+	if err != nil {
+		os.RemoveAll(dir) // Cleanup failed clone attempt
+		return "", err
+	}
+	// Clones store the URL (and token) as origin, drop it
+	git(dir, "remote", "remove", "origin")
+
+	return dir, nil
+}
+
+// fetchCheckout updates an existing checkout to target (branch, tag or commit)
+// and removes everything not tracked by git, like files generated by earlier builds.
+func fetchCheckout(dir, cloneURL, target string) error {
+	err := git(dir, "fetch", "--quiet", "--depth", "1", cloneURL, target)
+	if err != nil {
+		return err
+	}
+	err = git(dir, "checkout", "--quiet", "--force", "--detach", "FETCH_HEAD")
+	if err != nil {
+		return err
+	}
+	return git(dir, "clean", "--quiet", "-ffdx")
+}
+
+// cloneRef checks out a single commit or tag. A shallow fetch of the ref is
+// tried first; servers that refuse to serve arbitrary commits (and short SHAs)
+// fall back to a full clone.
+func cloneRef(cloneURL, ref, cloneDir string) error {
+	err := git("", "init", "--quiet", cloneDir)
+	if err != nil {
+		return err
+	}
+	err = git(cloneDir, "fetch", "--depth", "1", cloneURL, ref)
+	if err == nil {
+		return git(cloneDir, "checkout", "--quiet", "--detach", "FETCH_HEAD")
+	}
 
-	// Remove if exists
 	os.RemoveAll(cloneDir)
+	err = git("", "clone", "--no-checkout", cloneURL, cloneDir)
+	if err != nil {
+		return err
+	}
+	err = git(cloneDir, "checkout", "--quiet", "--detach", ref)
+	if err != nil {
+		return fmt.Errorf("ref %q not found: %w", ref, err)
+	}
+	return nil
+}
 
-	// Clone
-	cmd := exec.Command("git", "clone", "--depth", "1", "--branch", branch, cloneURL, cloneDir)
+// git runs a git command, in dir if set.
+func git(dir string, args ...string) error {
+	subcommand := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		os.RemoveAll(cloneDir) // Cleanup failed clone attempt
-		return "", fmt.Errorf("git clone failed: %s - %w", string(output), err)
+		return fmt.Errorf("git %s failed: %s - %w", subcommand, strings.TrimSpace(string(output)), err)
 	}
+	return nil
+}
 
-	return cloneDir, nil
+// HeadCommit returns the full SHA and the subject line of the checked out commit in a cloned repo.
+func (d *DockerService) HeadCommit(repoPath string) (string, string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "log", "-1", "--format=%H%n%s")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("git log failed: %w", err)
+	}
+	sha, message, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return sha, message, nil
 }
 
 // BuildImage builds a Docker image from a directory with a Dockerfile.
+// Layers are cached by the daemon across builds unless opts.NoCache is set.
 // logCallback is called for each line of build output (can be nil).
-func (d *DockerService) BuildImage(ctx context.Context, buildPath, dockerfilePath, imageName string, logCallback func(string)) (string, error) {
-	// Cleanup dangling images after build (success or fail)
-	defer d.PruneDanglingImages(ctx)
-	defer d.PruneBuildContainers(ctx)
+func (d *DockerService) BuildImage(ctx context.Context, opts BuildImageOptions, logCallback func(string)) (string, error) {
+	excludes, err := dockerignore(opts.BuildPath, opts.DockerfilePath)
+	if err != nil {
+		return "", err
+	}
 
 	// Create tar archive of build context
-	tar, err := archive.TarWithOptions(buildPath, &archive.TarOptions{})
+	tar, err := archive.TarWithOptions(opts.BuildPath, &archive.TarOptions{ExcludePatterns: excludes})
 	if err != nil {
 		return "", fmt.Errorf("failed to create build context: %w", err)
 	}
 	defer tar.Close()
 
-	// Build options
-	opts := types.ImageBuildOptions{
-		Tags:       []string{imageName},
-		Dockerfile: dockerfilePath,
-		Remove:     true,
+	// Build args are passed as pointers, nil would mean "take the value from the environment"
+	buildArgs := make(map[string]*string, len(opts.BuildArgs))
+	for k, v := range opts.BuildArgs {
+		buildArgs[k] = &v
 	}
 
 	// Build
-	resp, err := d.client.ImageBuild(ctx, tar, opts)
+	resp, err := d.client.ImageBuild(ctx, tar, types.ImageBuildOptions{
+		Tags:        []string{opts.ImageName},
+		Dockerfile:  opts.DockerfilePath,
+		BuildArgs:   buildArgs,
+		NoCache:     opts.NoCache,
+		CacheFrom:   opts.CacheFrom,
+		Remove:      true,
+		ForceRemove: true, // also remove intermediate containers of failed builds
+	})
 	if err != nil {
 		return "", fmt.Errorf("docker build failed: %w", err)
 	}
@@ -160,7 +274,95 @@ func (d *DockerService) BuildImage(ctx context.Context, buildPath, dockerfilePat
 		return "", fmt.Errorf("failed reading build output: %w", err)
 	}
 
-	return imageName, nil
+	return opts.ImageName, nil
+}
+
+// dockerignore returns the exclude patterns of the .dockerignore file in a build
+// context, so ignored files are not sent to the daemon at all. The Dockerfile and
+// .dockerignore itself are always sent, the daemon needs them.
+func dockerignore(buildPath, dockerfilePath string) ([]string, error) {
+	f, err := os.Open(filepath.Join(buildPath, ".dockerignore"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .dockerignore: %w", err)
+	}
+	defer f.Close()
+
+	excludes, err := ignorefile.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse .dockerignore: %w", err)
+	}
+	if dockerfilePath == "" {
+		dockerfilePath = "Dockerfile"
+	}
+	return append(excludes, "!"+filepath.ToSlash(filepath.Clean(dockerfilePath)), "!.dockerignore"), nil
+}
+
+// PullImage pulls an image from a registry and tags it as imageName, so pulled
+// images are handled like built ones. auth is optional (for private registries).
+// logCallback is called for each progress line (can be nil).
+func (d *DockerService) PullImage(ctx context.Context, ref, imageName string, auth *registry.AuthConfig, logCallback func(string)) error {
+	if logCallback == nil {
+		logCallback = func(string) {}
+	}
+
+	var opts image.PullOptions
+	if auth != nil {
+		encoded, err := registry.EncodeAuthConfig(*auth)
+		if err != nil {
+			return fmt.Errorf("invalid registry credentials: %w", err)
+		}
+		opts.RegistryAuth = encoded
+	}
+
+	resp, err := d.client.ImagePull(ctx, ref, opts)
+	if err != nil {
+		return fmt.Errorf("docker pull failed: %w", err)
+	}
+	defer resp.Close()
+
+	// Pull output is JSON progress - only log status changes, not progress bars
+	scanner := bufio.NewScanner(resp)
+	var lastError string
+	for scanner.Scan() {
+		var msg struct {
+			Status string `json:"status"`
+			ID     string `json:"id"`
+			Error  string `json:"error"`
+		}
+		err := json.Unmarshal(scanner.Bytes(), &msg)
+		if err != nil {
+			continue
+		}
+		switch {
+		case msg.Error != "":
+			lastError = msg.Error
+			logCallback("ERROR: " + msg.Error)
+		case msg.Status == "Downloading" || msg.Status == "Extracting" || msg.Status == "Waiting":
+		case msg.ID != "":
+			logCallback(msg.ID + ": " + msg.Status)
+		default:
+			logCallback(msg.Status)
+		}
+	}
+
+	if lastError != "" {
+		return fmt.Errorf("pull failed: %s", lastError)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return fmt.Errorf("failed reading pull output: %w", err)
+	}
+
+	err = d.client.ImageTag(ctx, ref, imageName)
+	if err != nil {
+		return fmt.Errorf("failed to tag image: %w", err)
+	}
+
+	return nil
 }
 
 // RunContainer starts a container with the given configuration.
@@ -179,51 +381,89 @@ func (d *DockerService) RunContainer(ctx context.Context, opts RunContainerOptio
 		"deeploy.pod.id": opts.PodID,
 	}
 
-	// Determine entrypoint based on environment
-	// - Development: "web" (HTTP on port 80) - Let's Encrypt won't work locally
-	// - Production: "websecure" (HTTPS on port 443) - with automatic SSL
-	entrypoint := "websecure"
-	if d.isDevelopment {
-		entrypoint = "web"
+	certResolver := opts.CertResolver
+	if certResolver == "" {
+		certResolver = HTTPCertResolver
 	}
 
 	// Create a router for each domain
 	// Each domain gets its own router but shares the same service (load balancer)
 	for i, domain := range opts.Domains {
 		routerName := fmt.Sprintf("%s-%d", opts.PodID, i)
+		router := "traefik.http.routers." + routerName
 
-		// Routing rule: Which domain goes to this container?
-		// Host(`example.com`) matches requests with that exact Host header
-		labels["traefik.http.routers."+routerName+".rule"] = fmt.Sprintf("Host(`%s`)", domain.Domain)
+		// Routing rule: Which requests go to this container?
+		// Host(`example.com`) matches requests with that exact Host header,
+		// PathPrefix(`/api`) additionally limits them to a path. Traefik prefers
+		// longer rules, so a path prefix wins over the same host without one.
+		labels[router+".rule"] = domainRule(domain)
 
 		// Service: Where to forward the traffic
 		// @docker suffix is required because Traefik auto-appends it to Docker services
-		labels["traefik.http.routers."+routerName+".service"] = opts.PodID + "@docker"
+		labels[router+".service"] = opts.PodID + "@docker"
 
 		// Entrypoint: Which port to listen on (web=80, websecure=443)
-		labels["traefik.http.routers."+routerName+".entrypoints"] = entrypoint
+		// - Development: "web" - Let's Encrypt won't work locally
+		// - Production: "websecure" with SSL, "web" for domains without it.
+		//   Plain HTTP routers outrank the global redirect to HTTPS,
+		//   see the redirection priority in docker-compose.yml.
+		entrypoint := "websecure"
+		if d.isDevelopment || !domain.SSL {
+			entrypoint = "web"
+		}
+		labels[router+".entrypoints"] = entrypoint
+
+		// Redirect domains answer every request with a permanent redirect
+		// to the same path on the target host, the service is never reached
+		if domain.RedirectTo != "" {
+			scheme := "https"
+			if d.isDevelopment {
+				scheme = "http"
+			}
+			middleware := "traefik.http.middlewares." + routerName + "-redirect.redirectregex."
+			labels[middleware+"regex"] = `^https?://[^/]+(.*)`
+			labels[middleware+"replacement"] = scheme + "://" + domain.RedirectTo + "${1}"
+			labels[middleware+"permanent"] = "true"
+			labels[router+".middlewares"] = routerName + "-redirect"
+		}
 
-		// SSL/TLS: Only in production (not development)
-		// certresolver=letsencrypt tells Traefik to automatically get a certificate
-		// from Let's Encrypt using the HTTP challenge
-		if !d.isDevelopment {
-			labels["traefik.http.routers."+routerName+".tls.certresolver"] = "letsencrypt"
+		// SSL/TLS: Only on the HTTPS entrypoint
+		// certresolver tells Traefik to automatically get a certificate from
+		// Let's Encrypt, using the HTTP challenge or the DNS challenge of the
+		// configured DNS provider. Wildcard certificates can only be issued
+		// with the DNS challenge, without it Traefik serves its default one.
+		// Uploaded certificates need no resolver, Traefik finds them in the
+		// TLS store of the file provider by SNI.
+		if entrypoint == "websecure" {
+			labels[router+".tls"] = "true"
+			switch {
+			case domain.CustomCertificate:
+			case strings.HasPrefix(domain.Domain, "*."):
+				if certResolver != HTTPCertResolver {
+					labels[router+".tls.certresolver"] = certResolver
+					labels[router+".tls.domains[0].main"] = domain.Domain
+				}
+			default:
+				labels[router+".tls.certresolver"] = certResolver
+			}
 		}
 	}
 
 	// Service configuration: One service for all routers
 	// All domains for this pod route to the same container/port
-	port := 8080
-	if len(opts.Domains) > 0 {
-		port = opts.Domains[0].Port
-	}
+	port := ServicePort(opts.Domains)
 	labels["traefik.http.services."+opts.PodID+".loadbalancer.server.port"] = fmt.Sprintf("%d", port)
 
-	// Health checks: Traefik pings each container every 2 seconds
-	// Only containers that respond get traffic. This ensures zero-downtime
-	// during redeploys - new container only gets traffic once it's ready.
-	labels["traefik.http.services."+opts.PodID+".loadbalancer.healthcheck.path"] = "/"
-	labels["traefik.http.services."+opts.PodID+".loadbalancer.healthcheck.interval"] = "2s"
+	// Health checks: Traefik pings each container with the pod's health check
+	// Only containers that respond get traffic. Deploys additionally wait for
+	// the new container to pass the check before the old one is stopped.
+	healthCheck := "traefik.http.services." + opts.PodID + ".loadbalancer.healthcheck."
+	labels[healthCheck+"path"] = opts.HealthCheck.Path
+	labels[healthCheck+"interval"] = opts.HealthCheck.Interval.String()
+	labels[healthCheck+"timeout"] = opts.HealthCheck.Timeout.String()
+	if opts.HealthCheck.Status != 0 {
+		labels[healthCheck+"status"] = strconv.Itoa(opts.HealthCheck.Status)
+	}
 
 	// Container config
 	config := &container.Config{
@@ -236,9 +476,12 @@ func (d *DockerService) RunContainer(ctx context.Context, opts RunContainerOptio
 	exposedPort := nat.Port(fmt.Sprintf("%d/tcp", port))
 	config.ExposedPorts = nat.PortSet{exposedPort: struct{}{}}
 
-	// Host config
+	// Host config - resource limits keep a runaway pod from starving the host,
+	// mounts keep data across redeploys
 	hostConfig := &container.HostConfig{
 		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+		Resources:     opts.Resources.dockerResources(),
+		Mounts:        volumeMounts(opts.Volumes),
 	}
 
 	// Network config - join the deeploy network so Traefik can reach this container
@@ -263,12 +506,60 @@ func (d *DockerService) RunContainer(ctx context.Context, opts RunContainerOptio
 	return resp.ID, nil
 }
 
+// RunDatabaseContainer starts a managed database container on the deeploy network.
+// Unlike pods it gets no Traefik labels - databases are only reachable by other
+// containers on the network, under their container name.
+func (d *DockerService) RunDatabaseContainer(ctx context.Context, opts DatabaseContainerOptions) (string, error) {
+	config := &container.Config{
+		Image: opts.ImageName,
+		Env:   mapToEnvSlice(opts.EnvVars),
+		Cmd:   opts.Cmd,
+		Labels: map[string]string{
+			"deeploy.database.id": opts.DatabaseID,
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+		Mounts:        volumeMounts([]VolumeMount{opts.Volume}),
+	}
+
+	networkConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			NetworkName: {},
+		},
+	}
+
+	resp, err := d.client.ContainerCreate(ctx, config, hostConfig, networkConfig, nil, opts.ContainerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to create container: %w", err)
+	}
+
+	err = d.client.ContainerStart(ctx, resp.ID, container.StartOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return resp.ID, nil
+}
+
+// StartContainer starts a stopped container.
+func (d *DockerService) StartContainer(ctx context.Context, containerID string) error {
+	return d.client.ContainerStart(ctx, containerID, container.StartOptions{})
+}
+
 // StopContainer stops a running container.
 func (d *DockerService) StopContainer(ctx context.Context, containerID string) error {
 	timeout := 30
 	return d.client.ContainerStop(ctx, containerID, container.StopOptions{Timeout: &timeout})
 }
 
+// RestartContainer restarts a running container.
+func (d *DockerService) RestartContainer(ctx context.Context, containerID string) error {
+	timeout := 30
+	return d.client.ContainerRestart(ctx, containerID, container.StopOptions{Timeout: &timeout})
+}
+
 // RemoveContainer removes a container.
 func (d *DockerService) RemoveContainer(ctx context.Context, containerID string) error {
 	return d.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
@@ -297,6 +588,42 @@ func (d *DockerService) GetContainerState(ctx context.Context, containerID strin
 	return info.State.Status, nil
 }
 
+// ProbeContainer sends a GET request for path to a container on the deeploy network
+// and returns the response status. Redirects are not followed.
+// The server must be able to reach the network, which it does when running in
+// docker compose (and on Linux hosts in development).
+func (d *DockerService) ProbeContainer(ctx context.Context, containerID string, port int, path string, timeout time.Duration) (int, error) {
+	info, err := d.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if !info.State.Running {
+		return 0, fmt.Errorf("container is %s (exit code %d)", info.State.Status, info.State.ExitCode)
+	}
+	endpoint, ok := info.NetworkSettings.Networks[NetworkName]
+	if !ok || endpoint.IPAddress == "" {
+		return 0, fmt.Errorf("container has no address on network %s", NetworkName)
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	probeURL := fmt.Sprintf("http://%s%s", net.JoinHostPort(endpoint.IPAddress, strconv.Itoa(port)), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
 // GetLogs returns a reader for container logs.
 func (d *DockerService) GetLogs(ctx context.Context, containerID string, follow bool) (io.ReadCloser, error) {
 	opts := container.LogsOptions{
@@ -336,9 +663,57 @@ func (d *DockerService) GetLogsLines(ctx context.Context, containerID string, li
 	return result, scanner.Err()
 }
 
-// Cleanup removes the cloned repo directory.
-func (d *DockerService) Cleanup(path string) error {
-	return os.RemoveAll(path)
+// FollowLogs streams container logs line by line until the container stops,
+// ctx is cancelled or onLine returns an error.
+// since (RFC3339 timestamp) resumes after a previous line, otherwise the last
+// tail lines are sent first. onLine receives the Docker timestamp of each line.
+func (d *DockerService) FollowLogs(ctx context.Context, containerID, since string, tail int, onLine func(ts time.Time, line string) error) error {
+	opts := container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+		Since:      since,
+	}
+	if since == "" {
+		opts.Tail = fmt.Sprintf("%d", tail)
+	}
+
+	reader, err := d.client.ContainerLogs(ctx, containerID, opts)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// Docker multiplexes stdout/stderr with 8-byte frame headers - demux into a pipe
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pw, pw, reader)
+		pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		// Each line is prefixed with its timestamp: "2006-01-02T15:04:05.999999999Z message"
+		raw, line, _ := strings.Cut(scanner.Text(), " ")
+		ts, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			line = scanner.Text()
+		}
+		err = onLine(ts, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// RemoveSource removes the persistent source checkout of a pod.
+func (d *DockerService) RemoveSource(podID string) error {
+	return os.RemoveAll(d.sourceDir(podID))
 }
 
 // Close closes the Docker client.
@@ -358,30 +733,229 @@ func (d *DockerService) PruneDanglingImages(ctx context.Context) {
 	}
 }
 
-// PruneBuildContainers removes stopped containers.
-func (d *DockerService) PruneBuildContainers(ctx context.Context) {
-	report, err := d.client.ContainersPrune(ctx, filters.NewArgs())
+// RemoveImage removes a specific image by name.
+func (d *DockerService) RemoveImage(ctx context.Context, imageName string) {
+	_, err := d.client.ImageRemove(ctx, imageName, image.RemoveOptions{Force: true, PruneChildren: true})
 	if err != nil {
-		slog.Warn("failed to prune containers", "error", err)
-		return
+		slog.Warn("failed to remove image", "image", imageName, "error", err)
 	}
-	if len(report.ContainersDeleted) > 0 {
-		slog.Info("pruned exited containers", "count", len(report.ContainersDeleted), "reclaimed", report.SpaceReclaimed)
+}
+
+// RemoveVolume removes a named volume. Volumes still used by a container are kept.
+func (d *DockerService) RemoveVolume(ctx context.Context, name string) {
+	err := d.client.VolumeRemove(ctx, name, false)
+	if err != nil {
+		slog.Warn("failed to remove volume", "volume", name, "error", err)
 	}
 }
 
-// RemoveImage removes a specific image by name.
-func (d *DockerService) RemoveImage(ctx context.Context, imageName string) {
-	_, err := d.client.ImageRemove(ctx, imageName, image.RemoveOptions{Force: true, PruneChildren: true})
+// Exec runs a command in a running container and waits for it to exit.
+// Output of stderr is returned in the error if the command fails.
+func (d *DockerService) Exec(ctx context.Context, containerID string, opts ExecOptions) error {
+	exec, err := d.client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          opts.Cmd,
+		Env:          mapToEnvSlice(opts.Env),
+		AttachStdin:  opts.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
 	if err != nil {
-		slog.Warn("failed to remove image", "image", imageName, "error", err)
+		return fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	resp, err := d.client.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to attach exec: %w", err)
+	}
+	defer resp.Close()
+
+	if opts.Stdin != nil {
+		go func() {
+			io.Copy(resp.Conn, opts.Stdin)
+			resp.CloseWrite()
+		}()
+	}
+
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	var stderr bytes.Buffer
+	_, err = stdcopy.StdCopy(stdout, &stderr, resp.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	info, err := d.client.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	if info.ExitCode != 0 {
+		return fmt.Errorf("%s exited with code %d: %s", opts.Cmd[0], info.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// volumeHelperImage runs the short-lived containers that read and write volumes.
+const volumeHelperImage = "busybox:1.37"
+
+// volumeHelperPath is where volumes are mounted in helper containers.
+const volumeHelperPath = "/volume"
+
+// createVolumeHelper creates (but does not start) a container with the volume
+// mounted at volumeHelperPath. The caller removes it.
+func (d *DockerService) createVolumeHelper(ctx context.Context, volume string, cmd []string) (string, error) {
+	if !d.ImageExists(ctx, volumeHelperImage) {
+		err := d.PullImage(ctx, volumeHelperImage, volumeHelperImage, nil, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	config := &container.Config{
+		Image: volumeHelperImage,
+		Cmd:   cmd,
+	}
+	hostConfig := &container.HostConfig{
+		NetworkMode: "none",
+		Mounts:      volumeMounts([]VolumeMount{{Source: volume, Target: volumeHelperPath}}),
+	}
+
+	resp, err := d.client.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create volume helper: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// ExportVolume writes a tar archive of a named volume to w.
+// Entries are prefixed with "volume/". The volume is read while in use,
+// stop writers first for a consistent archive.
+func (d *DockerService) ExportVolume(ctx context.Context, volume string, w io.Writer) error {
+	helperID, err := d.createVolumeHelper(ctx, volume, nil)
+	if err != nil {
+		return err
+	}
+	defer d.RemoveContainer(context.Background(), helperID)
+
+	rc, _, err := d.client.CopyFromContainer(ctx, helperID, volumeHelperPath)
+	if err != nil {
+		return fmt.Errorf("failed to read volume: %w", err)
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+// ImportVolume replaces the contents of a named volume with a tar archive
+// written by ExportVolume. Containers using the volume should be stopped.
+func (d *DockerService) ImportVolume(ctx context.Context, volume string, r io.Reader) error {
+	// Empty the volume first, so files missing in the archive don't survive
+	wipe := []string{"sh", "-c", "rm -rf " + volumeHelperPath + "/* " + volumeHelperPath + "/.[!.]* " + volumeHelperPath + "/..?*"}
+	helperID, err := d.createVolumeHelper(ctx, volume, wipe)
+	if err != nil {
+		return err
+	}
+	defer d.RemoveContainer(context.Background(), helperID)
+
+	err = d.client.ContainerStart(ctx, helperID, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to start volume helper: %w", err)
+	}
+	waitCh, errCh := d.client.ContainerWait(ctx, helperID, container.WaitConditionNotRunning)
+	select {
+	case result := <-waitCh:
+		if result.StatusCode != 0 {
+			return fmt.Errorf("failed to empty volume: exit code %d", result.StatusCode)
+		}
+	case err := <-errCh:
+		return fmt.Errorf("failed to empty volume: %w", err)
+	}
+
+	err = d.client.CopyToContainer(ctx, helperID, "/", r, container.CopyToContainerOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to write volume: %w", err)
+	}
+	return nil
+}
+
+// ImageExists reports whether an image (name:tag) is present locally.
+func (d *DockerService) ImageExists(ctx context.Context, imageName string) bool {
+	_, err := d.client.ImageInspect(ctx, imageName)
+	return err == nil
+}
+
+// RemovePodImages removes all images built for a pod (every deployment tag).
+func (d *DockerService) RemovePodImages(ctx context.Context, podID string) {
+	images, err := d.client.ImageList(ctx, image.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("reference", fmt.Sprintf("deeploy-%s", podID))),
+	})
+	if err != nil {
+		slog.Warn("failed to list pod images", "podID", podID, "error", err)
+		return
+	}
+	for _, img := range images {
+		for _, tag := range img.RepoTags {
+			d.RemoveImage(ctx, tag)
+		}
 	}
 }
 
 // DomainConfig holds domain configuration for Traefik routing.
 type DomainConfig struct {
-	Domain string
-	Port   int
+	Domain     string // exact host, or *.example.com for one subdomain level
+	Path       string // path prefix, empty = all paths
+	RedirectTo string // target host, set for domains that only redirect
+	Port       int
+	SSL        bool // serve over HTTPS, ignored in development
+	// CustomCertificate serves an uploaded certificate instead of Let's Encrypt
+	CustomCertificate bool
+}
+
+// ServicePort returns the container port traffic is routed to, the port of
+// the first domain that is not a redirect. Redirect domains never reach the
+// container and carry no real port.
+func ServicePort(domains []DomainConfig) int {
+	for _, domain := range domains {
+		if domain.RedirectTo == "" {
+			return domain.Port
+		}
+	}
+	return 8080
+}
+
+// HTTPCertResolver is the Traefik certificate resolver using the ACME HTTP challenge.
+const HTTPCertResolver = "letsencrypt"
+
+// DNSCertResolver returns the Traefik certificate resolver using the ACME DNS
+// challenge of a DNS provider, the only challenge that can issue wildcard
+// certificates. docker-compose.yml declares one for each supported provider.
+func DNSCertResolver(provider string) string {
+	return "letsencrypt-dns-" + provider
+}
+
+// domainRule returns the Traefik router rule of a domain.
+func domainRule(domain DomainConfig) string {
+	rule := fmt.Sprintf("Host(`%s`)", domain.Domain)
+	if suffix, ok := strings.CutPrefix(domain.Domain, "*."); ok {
+		// HostRegexp matches one subdomain level, like a wildcard certificate
+		rule = fmt.Sprintf("HostRegexp(`^[a-zA-Z0-9-]+\\.%s$`)", regexp.QuoteMeta(suffix))
+	}
+	if domain.Path != "" {
+		rule += fmt.Sprintf(" && PathPrefix(`%s`)", domain.Path)
+	}
+	return rule
+}
+
+// BuildImageOptions holds options for building an image.
+type BuildImageOptions struct {
+	BuildPath      string
+	DockerfilePath string
+	ImageName      string
+	BuildArgs      map[string]string
+	CacheFrom      []string // images to use as additional cache sources, e.g. the previous build
+	NoCache        bool
 }
 
 // RunContainerOptions holds options for running a container.
@@ -390,7 +964,104 @@ type RunContainerOptions struct {
 	ContainerName string
 	PodID         string
 	Domains       []DomainConfig
+	CertResolver  string // resolver of Let's Encrypt certificates, empty = HTTPCertResolver
+	EnvVars       map[string]string
+	Volumes       []VolumeMount
+	HealthCheck   HealthCheckConfig
+	Resources     ResourceLimits
+}
+
+// DatabaseContainerOptions configures a managed database container.
+type DatabaseContainerOptions struct {
+	ImageName     string
+	ContainerName string
+	DatabaseID    string
 	EnvVars       map[string]string
+	Cmd           []string // overrides the image command, nil keeps it
+	Volume        VolumeMount
+}
+
+// VolumeMount mounts a named volume (created on first use) or,
+// if Bind is set, a host path into a container.
+type VolumeMount struct {
+	Bind     bool
+	Source   string
+	Target   string
+	ReadOnly bool
+	Labels   map[string]string // set on named volumes Docker creates on first use
+}
+
+func volumeMounts(volumes []VolumeMount) []mount.Mount {
+	mounts := make([]mount.Mount, 0, len(volumes))
+	for _, v := range volumes {
+		m := mount.Mount{
+			Type:     mount.TypeVolume,
+			Source:   v.Source,
+			Target:   v.Target,
+			ReadOnly: v.ReadOnly,
+		}
+		if len(v.Labels) > 0 {
+			m.VolumeOptions = &mount.VolumeOptions{Labels: v.Labels}
+		}
+		if v.Bind {
+			m.Type = mount.TypeBind
+			// Create missing host directories like "docker run -v" does
+			m.BindOptions = &mount.BindOptions{CreateMountpoint: true}
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts
+}
+
+// ExecOptions configures a command run with Exec.
+type ExecOptions struct {
+	Cmd    []string
+	Env    map[string]string
+	Stdin  io.Reader // nil = no input
+	Stdout io.Writer // nil = discarded
+}
+
+// ResourceLimits are the resource limits of a container, 0 = unlimited.
+type ResourceLimits struct {
+	MemoryMB            int
+	MemoryReservationMB int
+	CPUs                float64
+	PIDs                int
+}
+
+func (l ResourceLimits) dockerResources() container.Resources {
+	resources := container.Resources{
+		Memory:            int64(l.MemoryMB) * 1024 * 1024,
+		MemoryReservation: int64(l.MemoryReservationMB) * 1024 * 1024,
+		NanoCPUs:          int64(l.CPUs * 1e9),
+	}
+	// Without swap, a memory limit is a hard limit
+	if resources.Memory > 0 {
+		resources.MemorySwap = resources.Memory
+	}
+	if l.PIDs > 0 {
+		pids := int64(l.PIDs)
+		resources.PidsLimit = &pids
+	}
+	return resources
+}
+
+// HostResources returns the number of CPUs and the total memory in MB of the Docker host.
+func (d *DockerService) HostResources(ctx context.Context) (int, int, error) {
+	info, err := d.client.Info(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get docker info: %w", err)
+	}
+	return info.NCPU, int(info.MemTotal / 1024 / 1024), nil
+}
+
+// HealthCheckConfig configures the Traefik health check of a container.
+// Status 0 accepts any 2xx/3xx response.
+type HealthCheckConfig struct {
+	Path     string
+	Status   int
+	Interval time.Duration
+	Timeout  time.Duration
 }
 
 func mapToEnvSlice(m map[string]string) []string {
@@ -0,0 +1,21 @@
+package docker
+
+import "testing"
+
+func TestServicePort(t *testing.T) {
+	tests := []struct {
+		name    string
+		domains []DomainConfig
+		want    int
+	}{
+		{"no domains", nil, 8080},
+		{"first domain", []DomainConfig{{Domain: "example.com", Port: 3000}, {Domain: "api.example.com", Port: 4000}}, 3000},
+		{"redirect first", []DomainConfig{{Domain: "www.example.com", RedirectTo: "example.com", Port: 80}, {Domain: "example.com", Port: 3000}}, 3000},
+		{"only redirects", []DomainConfig{{Domain: "www.example.com", RedirectTo: "example.com", Port: 80}}, 8080},
+	}
+	for _, tt := range tests {
+		if got := ServicePort(tt.domains); got != tt.want {
+			t.Errorf("%s: ServicePort = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+)
+
+// authorize checks that the user of a request has at least role in a project.
+// On failure the error response is written and false returned.
+func authorize(w http.ResponseWriter, r *http.Request, members service.MemberServiceInterface, projectID, role string) bool {
+	err := members.Authorize(projectID, auth.GetUser(r.Context()).ID, role)
+	if err != nil {
+		writeError(w, err)
+		return false
+	}
+	return true
+}
+
+// authorizePod checks that the user of a request has at least role in the project of a pod.
+// On failure the error response is written and false returned.
+func authorizePod(w http.ResponseWriter, r *http.Request, members service.MemberServiceInterface, podID, role string) bool {
+	err := members.AuthorizePod(podID, auth.GetUser(r.Context()).ID, role)
+	if err != nil {
+		writeError(w, err)
+		return false
+	}
+	return true
+}
+
+// authorizeDatabase checks that the user of a request has at least role in the project of a database.
+// On failure the error response is written and false returned.
+func authorizeDatabase(w http.ResponseWriter, r *http.Request, members service.MemberServiceInterface, databaseID, role string) bool {
+	err := members.AuthorizeDatabase(databaseID, auth.GetUser(r.Context()).ID, role)
+	if err != nil {
+		writeError(w, err)
+		return false
+	}
+	return true
+}
+
+// authorizeBackup checks that the user of a request has at least role in the project a backup belongs to.
+// On failure the error response is written and false returned.
+func authorizeBackup(w http.ResponseWriter, r *http.Request, members service.MemberServiceInterface, backupID, role string) bool {
+	err := members.AuthorizeBackup(backupID, auth.GetUser(r.Context()).ID, role)
+	if err != nil {
+		writeError(w, err)
+		return false
+	}
+	return true
+}
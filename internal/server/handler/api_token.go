@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+type APITokenHandler struct {
+	service      service.APITokenServiceInterface
+	auditService service.AuditServiceInterface
+}
+
+func NewAPITokenHandler(service *service.APITokenService, auditService *service.AuditService) *APITokenHandler {
+	return &APITokenHandler{service: service, auditService: auditService}
+}
+
+func apiTokenEvent(action string, token *model.APIToken) model.AuditEvent {
+	return model.AuditEvent{Action: action, TargetType: "api_token", TargetID: token.ID, TargetName: token.Name}
+}
+
+// Create creates a token and returns it once. API tokens can't create tokens,
+// so a leaked token can't outlive its own expiry.
+func (h *APITokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if auth.GetAPIToken(r.Context()) != nil {
+		writeError(w, fmt.Errorf("api tokens can only be created by signing in: %w", errs.ErrForbidden))
+		return
+	}
+
+	var req model.APITokenCreate
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.service.Create(auth.GetUser(r.Context()).ID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, apiTokenEvent("api_token.create", token), nil, token)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(token)
+}
+
+func (h *APITokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUser(r.Context()).ID
+
+	tokens, err := h.service.APITokensByUser(userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+func (h *APITokenHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	existing, err := h.service.APIToken(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !auth.IsOwner(existing.UserID, r.Context()) {
+		writeError(w, fmt.Errorf("api token %s: %w", id, errs.ErrNotFound))
+		return
+	}
+
+	err = h.service.Delete(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, apiTokenEvent("api_token.delete", existing), existing, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
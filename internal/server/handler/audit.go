@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+// audit records a change by the user of the request. before and after are
+// the target before and after the change, nil when it was created or
+// deleted. A failed record is only logged, the change already happened.
+func audit(r *http.Request, audits service.AuditServiceInterface, event model.AuditEvent, before, after any) {
+	if user := auth.GetUser(r.Context()); user != nil {
+		event.ActorID = user.ID
+		event.ActorEmail = user.Email
+	}
+	event.IP = auth.ClientIP(r)
+
+	err := audits.Record(&event, before, after)
+	if err != nil {
+		slog.Error("failed to record audit event", "action", event.Action, "targetID", event.TargetID, "error", err)
+	}
+}
+
+type AuditHandler struct {
+	service service.AuditServiceInterface
+}
+
+func NewAuditHandler(service *service.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// List returns audit events, filtered by the query parameters action,
+// actor, target_type, target_id, project_id, since, until (RFC 3339) and limit.
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := model.AuditFilter{
+		Action:     query.Get("action"),
+		Actor:      query.Get("actor"),
+		TargetType: query.Get("target_type"),
+		TargetID:   query.Get("target_id"),
+		ProjectID:  query.Get("project_id"),
+	}
+
+	var err error
+	if v := query.Get("since"); v != "" {
+		filter.Since, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, fmt.Errorf("since must be an RFC 3339 time: %w", errs.ErrInvalidInput))
+			return
+		}
+	}
+	if v := query.Get("until"); v != "" {
+		filter.Until, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, fmt.Errorf("until must be an RFC 3339 time: %w", errs.ErrInvalidInput))
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			writeError(w, fmt.Errorf("limit must be a number: %w", errs.ErrInvalidInput))
+			return
+		}
+	}
+
+	events, err := h.service.Events(filter, auth.GetUser(r.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+}
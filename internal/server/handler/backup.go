@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+type BackupHandler struct {
+	service          service.BackupServiceInterface
+	podVolumeService service.PodVolumeServiceInterface
+	memberService    service.MemberServiceInterface
+}
+
+func NewBackupHandler(service *service.BackupService, podVolumeService *service.PodVolumeService, memberService *service.MemberService) *BackupHandler {
+	return &BackupHandler{service: service, podVolumeService: podVolumeService, memberService: memberService}
+}
+
+// target returns the backup target of a request, a pod volume
+// (/pods/{id}/volumes/{volumeId}/...) or a database (/databases/{id}/...),
+// after checking that the user has at least role in its project.
+func (h *BackupHandler) target(w http.ResponseWriter, r *http.Request, role string) (string, string, bool) {
+	volumeID := r.PathValue("volumeId")
+	if volumeID == "" {
+		if !authorizeDatabase(w, r, h.memberService, r.PathValue("id"), role) {
+			return "", "", false
+		}
+		return model.BackupTargetDatabase, r.PathValue("id"), true
+	}
+
+	if !authorizePod(w, r, h.memberService, r.PathValue("id"), role) {
+		return "", "", false
+	}
+
+	volume, err := h.podVolumeService.Volume(volumeID)
+	if err != nil {
+		writeError(w, err)
+		return "", "", false
+	}
+	if volume.PodID != r.PathValue("id") {
+		http.Error(w, "Volume not found", http.StatusNotFound)
+		return "", "", false
+	}
+	return model.BackupTargetVolume, volumeID, true
+}
+
+func (h *BackupHandler) Backups(w http.ResponseWriter, r *http.Request) {
+	targetType, targetID, ok := h.target(w, r, model.ProjectRoleViewer)
+	if !ok {
+		return
+	}
+
+	backups, err := h.service.Backups(targetType, targetID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(backups)
+}
+
+func (h *BackupHandler) Run(w http.ResponseWriter, r *http.Request) {
+	targetType, targetID, ok := h.target(w, r, model.ProjectRoleDeveloper)
+	if !ok {
+		return
+	}
+
+	userID := auth.GetUser(r.Context()).ID
+	backup, err := h.service.Run(userID, targetType, targetID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(backup)
+}
+
+func (h *BackupHandler) SaveSchedule(w http.ResponseWriter, r *http.Request) {
+	targetType, targetID, ok := h.target(w, r, model.ProjectRoleDeveloper)
+	if !ok {
+		return
+	}
+
+	var schedule model.BackupSchedule
+	err := json.NewDecoder(r.Body).Decode(&schedule)
+	if err != nil {
+		http.Error(w, "Failed to decode json", http.StatusInternalServerError)
+		return
+	}
+
+	if schedule.IntervalHours < 0 || schedule.IntervalHours > 24*30 {
+		http.Error(w, "Interval must be between 1 and 720 hours", http.StatusBadRequest)
+		return
+	}
+	if schedule.Retention < 0 || schedule.Retention > 365 {
+		http.Error(w, "Retention must be between 1 and 365 backups", http.StatusBadRequest)
+		return
+	}
+	if schedule.Destination != "" && schedule.Destination != model.BackupDestinationLocal && schedule.Destination != model.BackupDestinationS3 {
+		http.Error(w, "Destination must be local or s3", http.StatusBadRequest)
+		return
+	}
+
+	schedule.TargetType = targetType
+	schedule.TargetID = targetID
+	schedule.UserID = auth.GetUser(r.Context()).ID
+
+	err = h.service.SaveSchedule(&schedule)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *BackupHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !authorizeBackup(w, r, h.memberService, id, model.ProjectRoleOwner) {
+		return
+	}
+
+	err := h.service.Restore(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *BackupHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !authorizeBackup(w, r, h.memberService, id, model.ProjectRoleOwner) {
+		return
+	}
+
+	err := h.service.Delete(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
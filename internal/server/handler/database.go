@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+var (
+	// imageTagPattern matches valid Docker image tags.
+	imageTagPattern = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$`)
+	// databaseNamePattern matches database names that need no quoting.
+	databaseNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+	// envVarPattern matches portable env var names.
+	envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+type DatabaseHandler struct {
+	service       service.DatabaseServiceInterface
+	podService    service.PodServiceInterface
+	memberService service.MemberServiceInterface
+	auditService  service.AuditServiceInterface
+}
+
+func NewDatabaseHandler(service *service.DatabaseService, podService *service.PodService, memberService *service.MemberService, auditService *service.AuditService) *DatabaseHandler {
+	return &DatabaseHandler{service: service, podService: podService, memberService: memberService, auditService: auditService}
+}
+
+func databaseEvent(action string, database *model.Database) model.AuditEvent {
+	return model.AuditEvent{Action: action, TargetType: "database", TargetID: database.ID, TargetName: database.Title, ProjectID: database.ProjectID}
+}
+
+func (h *DatabaseHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var database model.Database
+
+	err := json.NewDecoder(r.Body).Decode(&database)
+	if err != nil {
+		http.Error(w, "Failed to decode json", http.StatusInternalServerError)
+		return
+	}
+
+	database.Title = strings.TrimSpace(database.Title)
+	if database.Title == "" {
+		http.Error(w, "Title is required", http.StatusBadRequest)
+		return
+	}
+	if database.ProjectID == "" {
+		http.Error(w, "Project ID is required", http.StatusBadRequest)
+		return
+	}
+	if !authorize(w, r, h.memberService, database.ProjectID, model.ProjectRoleDeveloper) {
+		return
+	}
+	if !service.IsDatabaseEngine(database.Engine) {
+		http.Error(w, "Engine must be postgres, mysql or redis", http.StatusBadRequest)
+		return
+	}
+	if database.Version != "" && !imageTagPattern.MatchString(database.Version) {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+	if database.DatabaseName != "" && !databaseNamePattern.MatchString(database.DatabaseName) {
+		http.Error(w, "Database name may only contain letters, digits and _", http.StatusBadRequest)
+		return
+	}
+
+	database.ID = uuid.New().String()
+	database.UserID = auth.GetUser(r.Context()).ID
+
+	_, err = h.service.Create(&database)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, databaseEvent("database.create", &database), nil, database)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(database)
+}
+
+func (h *DatabaseHandler) Database(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !authorizeDatabase(w, r, h.memberService, id, model.ProjectRoleViewer) {
+		return
+	}
+
+	database, err := h.service.Database(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// The connection URL holds the password, viewers don't get it
+	err = h.memberService.Authorize(database.ProjectID, auth.GetUser(r.Context()).ID, model.ProjectRoleDeveloper)
+	if err != nil {
+		database.URL = ""
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(database)
+}
+
+func (h *DatabaseHandler) DatabasesByProject(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	if !authorize(w, r, h.memberService, projectID, model.ProjectRoleViewer) {
+		return
+	}
+
+	databases, err := h.service.DatabasesByProject(projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(databases)
+}
+
+func (h *DatabaseHandler) DatabasesByUser(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUser(r.Context()).ID
+
+	databases, err := h.service.DatabasesByUser(userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(databases)
+}
+
+func (h *DatabaseHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !authorizeDatabase(w, r, h.memberService, id, model.ProjectRoleOwner) {
+		return
+	}
+
+	// Linked pods would lose their connection on the next deploy
+	links, err := h.service.CountLinks(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if links > 0 {
+		writeError(w, fmt.Errorf("cannot delete database linked to pods: %w", errs.ErrConflict))
+		return
+	}
+
+	existing, err := h.service.Database(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// The data volume is kept unless explicitly requested (?volumes=remove)
+	removeVolume := r.URL.Query().Get("volumes") == "remove"
+
+	err = h.service.Delete(id, removeVolume)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, databaseEvent("database.delete", existing), existing, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DatabaseHandler) Links(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
+
+	links, err := h.service.LinksByPod(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(links)
+}
+
+func (h *DatabaseHandler) Link(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+
+	var link model.PodDatabase
+	err := json.NewDecoder(r.Body).Decode(&link)
+	if err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	link.PodID = podID
+	link.EnvVar = strings.TrimSpace(link.EnvVar)
+	if link.EnvVar != "" && !envVarPattern.MatchString(link.EnvVar) {
+		http.Error(w, "Env var may only contain letters, digits and _", http.StatusBadRequest)
+		return
+	}
+
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+	pod, err := h.podService.Pod(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	database, err := h.service.Database(link.DatabaseID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if database.ProjectID != pod.ProjectID {
+		http.Error(w, "Database belongs to another project", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.Link(&link)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("database.link", podID), nil, link)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(link)
+}
+
+func (h *DatabaseHandler) Unlink(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	databaseID := r.PathValue("databaseId")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	err := h.service.Unlink(podID, databaseID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("database.unlink", podID), map[string]string{"database_id": databaseID}, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
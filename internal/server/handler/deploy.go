@@ -3,29 +3,67 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
 	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
 // Note: slog is kept for Deploy() goroutine logging
 
+// Page size limits for build log paging
+const (
+	defaultLogPageSize = 500
+	maxLogPageSize     = 5000
+)
+
 type DeployHandler struct {
-	service *service.DeployService
+	service       *service.DeployService
+	memberService service.MemberServiceInterface
+	auditService  service.AuditServiceInterface
 }
 
-func NewDeployHandler(service *service.DeployService) *DeployHandler {
-	return &DeployHandler{service: service}
+func NewDeployHandler(service *service.DeployService, memberService *service.MemberService, auditService *service.AuditService) *DeployHandler {
+	return &DeployHandler{service: service, memberService: memberService, auditService: auditService}
 }
 
 func (h *DeployHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
 	slog.Info("Deploy request received", "podID", podID, "remoteAddr", r.RemoteAddr)
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	// Body is optional - without a ref the branch tip is deployed, using the build cache
+	var req model.DeployRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
 
-	// Start deploy in background
+	// Validate and record the deployment before answering,
+	// so log streams opened right after this request follow the new build
+	req.Ref = strings.TrimSpace(req.Ref)
+	deployment, err := h.service.StartDeploy(podID, req, auth.GetUser(r.Context()).Email)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("pod.deploy", podID), nil, deployment)
+
+	// Build in background
 	go func() {
-		err := h.service.Deploy(context.Background(), podID)
+		err := h.service.RunDeploy(context.Background(), deployment)
 		if err != nil {
 			slog.Error("deploy failed", "podID", podID, "error", err)
 		}
@@ -34,18 +72,21 @@ func (h *DeployHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 	// Return immediately
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
-	json.NewEncoder(w).Encode(map[string]string{"status": "building"})
+	json.NewEncoder(w).Encode(map[string]string{"status": "building", "deployment_id": deployment.ID})
 }
 
-
 func (h *DeployHandler) Stop(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
 	err := h.service.Stop(r.Context(), podID)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, h.auditService.PodEvent("pod.stop", podID), nil, nil)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"status": "stopped"})
@@ -53,19 +94,35 @@ func (h *DeployHandler) Stop(w http.ResponseWriter, r *http.Request) {
 
 func (h *DeployHandler) Restart(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
-	err := h.service.Restart(r.Context(), podID)
+	imageName, err := h.service.StartRestart(r.Context(), podID)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, h.auditService.PodEvent("pod.restart", podID), nil, nil)
+
+	// Swap in background, waiting for the health check outlives the request
+	go func() {
+		err := h.service.RunRestart(context.Background(), podID, imageName)
+		if err != nil {
+			slog.Error("restart failed", "podID", podID, "error", err)
+		}
+	}()
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
 	json.NewEncoder(w).Encode(map[string]string{"status": "restarting"})
 }
 
 func (h *DeployHandler) Logs(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
 
 	logs, status, err := h.service.GetLogs(r.Context(), podID, 100)
 	if err != nil {
@@ -79,3 +136,164 @@ func (h *DeployHandler) Logs(w http.ResponseWriter, r *http.Request) {
 		"status": status,
 	})
 }
+
+// StreamLogs streams build and container logs as Server-Sent Events.
+// Clients resume with the Last-Event-ID header (or ?cursor=) after a reconnect.
+func (h *DeployHandler) StreamLogs(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	cursor := r.Header.Get("Last-Event-ID")
+	if cursor == "" {
+		cursor = r.URL.Query().Get("cursor")
+	}
+
+	// Writes happen from the stream and the keepalive ticker
+	var mu sync.Mutex
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	// Keepalive comments stop proxies from closing idle streams
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(15 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				mu.Lock()
+				start()
+				fmt.Fprint(w, ": keepalive\n\n")
+				flusher.Flush()
+				mu.Unlock()
+			}
+		}
+	}()
+
+	err := h.service.StreamLogs(ctx, podID, cursor, func(e model.LogEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		start()
+		if e.ID != "" {
+			fmt.Fprintf(w, "id: %s\n", e.ID)
+		}
+		_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Source, strings.ReplaceAll(e.Data, "\r", ""))
+		if err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+
+	cancel()
+	mu.Lock()
+	defer mu.Unlock()
+	if err != nil && ctx.Err() == nil {
+		if !started {
+			writeError(w, err)
+			return
+		}
+		slog.Error("log stream failed", "podID", podID, "error", err)
+		fmt.Fprint(w, "event: error\ndata: log stream failed\n\n")
+		flusher.Flush()
+	}
+}
+
+func (h *DeployHandler) Deployments(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
+
+	deployments, err := h.service.Deployments(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployments)
+}
+
+func (h *DeployHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+	deploymentID := r.PathValue("deploymentId")
+
+	deployment, err := h.service.StartRollback(r.Context(), podID, deploymentID, auth.GetUser(r.Context()).Email)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("pod.rollback", podID), nil, deployment)
+
+	// Swap in background, waiting for the health check outlives the request
+	go func() {
+		err := h.service.RunRollback(context.Background(), deployment)
+		if err != nil {
+			slog.Error("rollback failed", "podID", podID, "error", err)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(deployment)
+}
+
+func (h *DeployHandler) DeploymentLogs(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
+	deploymentID := r.PathValue("deploymentId")
+
+	offset := 0
+	limit := defaultLogPageSize
+	var err error
+	if v := r.URL.Query().Get("offset"); v != "" {
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxLogPageSize {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxLogPageSize), http.StatusBadRequest)
+			return
+		}
+	}
+
+	logs, err := h.service.DeploymentLogs(podID, deploymentID, offset, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logs)
+}
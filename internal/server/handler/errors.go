@@ -22,6 +22,8 @@ func writeError(w http.ResponseWriter, err error) {
 		status, msg = http.StatusNotFound, err.Error()
 	case errors.Is(err, errs.ErrUnauthorized):
 		status, msg = http.StatusUnauthorized, "Unauthorized"
+	case errors.Is(err, errs.ErrForbidden):
+		status, msg = http.StatusForbidden, err.Error()
 	case errors.Is(err, errs.ErrInvalidCredentials):
 		status, msg = http.StatusUnauthorized, "Invalid credentials"
 	case errors.Is(err, errs.ErrInvalidInput):
@@ -2,20 +2,27 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/deeploy-sh/deeploy/internal/server/auth"
 	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
 	"github.com/google/uuid"
 )
 
 type GitTokenHandler struct {
-	service *service.GitTokenService
+	service      *service.GitTokenService
+	auditService service.AuditServiceInterface
 }
 
-func NewGitTokenHandler(service *service.GitTokenService) *GitTokenHandler {
-	return &GitTokenHandler{service: service}
+func NewGitTokenHandler(service *service.GitTokenService, auditService *service.AuditService) *GitTokenHandler {
+	return &GitTokenHandler{service: service, auditService: auditService}
+}
+
+func gitTokenEvent(action string, token *model.GitToken) model.AuditEvent {
+	return model.AuditEvent{Action: action, TargetType: "git_token", TargetID: token.ID, TargetName: token.Name}
 }
 
 func (h *GitTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -47,6 +54,7 @@ func (h *GitTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, gitTokenEvent("git_token.create", gitToken), nil, gitToken)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -69,11 +77,22 @@ func (h *GitTokenHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *GitTokenHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	err := h.service.Delete(id)
+	existing, err := h.service.GitToken(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !auth.IsOwner(existing.UserID, r.Context()) {
+		writeError(w, fmt.Errorf("git token %s: %w", id, errs.ErrNotFound))
+		return
+	}
+
+	err = h.service.Delete(id)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, gitTokenEvent("git_token.delete", existing), existing, nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+type MemberHandler struct {
+	service      service.MemberServiceInterface
+	auditService service.AuditServiceInterface
+}
+
+func NewMemberHandler(service *service.MemberService, auditService *service.AuditService) *MemberHandler {
+	return &MemberHandler{service: service, auditService: auditService}
+}
+
+// members returns the members and invites of a project by user and invite ID.
+func (h *MemberHandler) members(projectID string) (map[string]model.ProjectMember, map[string]model.ProjectInvite, error) {
+	list, err := h.service.Members(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	members := make(map[string]model.ProjectMember, len(list.Members))
+	for _, m := range list.Members {
+		members[m.UserID] = m
+	}
+	invites := make(map[string]model.ProjectInvite, len(list.Invites))
+	for _, i := range list.Invites {
+		invites[i.ID] = i
+	}
+	return members, invites, nil
+}
+
+func (h *MemberHandler) List(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	if !authorize(w, r, h.service, projectID, model.ProjectRoleViewer) {
+		return
+	}
+
+	members, err := h.service.Members(projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(members)
+}
+
+func (h *MemberHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	if !authorize(w, r, h.service, projectID, model.ProjectRoleOwner) {
+		return
+	}
+
+	var invite model.ProjectInvite
+	err := json.NewDecoder(r.Body).Decode(&invite)
+	if err != nil {
+		http.Error(w, "Failed to decode json", http.StatusInternalServerError)
+		return
+	}
+
+	invite.ProjectID = projectID
+	invite.InvitedBy = auth.GetUser(r.Context()).ID
+
+	err = h.service.Invite(&invite)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, model.AuditEvent{Action: "invite.create", TargetType: "invite", TargetID: invite.ID, TargetName: invite.Email, ProjectID: projectID}, nil, invite)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(invite)
+}
+
+func (h *MemberHandler) RevokeInvite(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	if !authorize(w, r, h.service, projectID, model.ProjectRoleOwner) {
+		return
+	}
+
+	inviteID := r.PathValue("inviteId")
+	_, invites, err := h.members(projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = h.service.RevokeInvite(projectID, inviteID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	invite := invites[inviteID]
+	audit(r, h.auditService, model.AuditEvent{Action: "invite.revoke", TargetType: "invite", TargetID: inviteID, TargetName: invite.Email, ProjectID: projectID}, invite, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MemberHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	if !authorize(w, r, h.service, projectID, model.ProjectRoleOwner) {
+		return
+	}
+
+	var member model.ProjectMember
+	err := json.NewDecoder(r.Body).Decode(&member)
+	if err != nil {
+		http.Error(w, "Failed to decode json", http.StatusInternalServerError)
+		return
+	}
+
+	member.ProjectID = projectID
+	member.UserID = r.PathValue("userId")
+
+	members, _, err := h.members(projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = h.service.UpdateRole(member.ProjectID, member.UserID, member.Role)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	before := members[member.UserID]
+	after := before
+	after.Role = member.Role
+	audit(r, h.auditService, model.AuditEvent{Action: "member.update", TargetType: "member", TargetID: member.UserID, TargetName: before.Email, ProjectID: projectID}, before, after)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(member)
+}
+
+// Remove removes a member. Owners remove anyone, members can leave themselves.
+func (h *MemberHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	userID := r.PathValue("userId")
+
+	role := model.ProjectRoleOwner
+	if userID == auth.GetUser(r.Context()).ID {
+		role = model.ProjectRoleViewer
+	}
+	if !authorize(w, r, h.service, projectID, role) {
+		return
+	}
+
+	members, _, err := h.members(projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = h.service.RemoveMember(projectID, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	member := members[userID]
+	audit(r, h.auditService, model.AuditEvent{Action: "member.remove", TargetType: "member", TargetID: userID, TargetName: member.Email, ProjectID: projectID}, member, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,20 +2,48 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/deeploy-sh/deeploy/internal/server/auth"
 	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
 	"github.com/google/uuid"
 )
 
 type PodHandler struct {
-	service service.PodServiceInterface
+	service                   service.PodServiceInterface
+	memberService             service.MemberServiceInterface
+	gitTokenService           *service.GitTokenService
+	registryCredentialService *service.RegistryCredentialService
+	certificateService        service.CertificateServiceInterface
+	auditService              service.AuditServiceInterface
 }
 
-func NewPodHandler(service *service.PodService) *PodHandler {
-	return &PodHandler{service: service}
+func NewPodHandler(
+	service *service.PodService,
+	memberService *service.MemberService,
+	gitTokenService *service.GitTokenService,
+	registryCredentialService *service.RegistryCredentialService,
+	certificateService *service.CertificateService,
+	auditService *service.AuditService,
+) *PodHandler {
+	return &PodHandler{
+		service:                   service,
+		memberService:             memberService,
+		gitTokenService:           gitTokenService,
+		registryCredentialService: registryCredentialService,
+		certificateService:        certificateService,
+		auditService:              auditService,
+	}
+}
+
+func podEvent(action string, pod *model.Pod) model.AuditEvent {
+	return model.AuditEvent{Action: action, TargetType: "pod", TargetID: pod.ID, TargetName: pod.Title, ProjectID: pod.ProjectID}
 }
 
 func (h *PodHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +63,18 @@ func (h *PodHandler) Create(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Project ID is required", http.StatusBadRequest)
 		return
 	}
+	if !authorize(w, r, h.memberService, pod.ProjectID, model.ProjectRoleDeveloper) {
+		return
+	}
+	err = normalizePod(&pod)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.authorizeCredentials(w, r, &pod, nil) {
+		return
+	}
 
 	pod.ID = uuid.New().String()
 	pod.UserID = auth.GetUser(r.Context()).ID
@@ -44,6 +84,7 @@ func (h *PodHandler) Create(w http.ResponseWriter, r *http.Request) {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, podEvent("pod.create", &pod), nil, pod)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(pod)
@@ -51,6 +92,9 @@ func (h *PodHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 func (h *PodHandler) Pod(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, id, model.ProjectRoleViewer) {
+		return
+	}
 
 	pod, err := h.service.Pod(id)
 
@@ -65,6 +109,9 @@ func (h *PodHandler) Pod(w http.ResponseWriter, r *http.Request) {
 
 func (h *PodHandler) PodsByProject(w http.ResponseWriter, r *http.Request) {
 	projectID := r.PathValue("id")
+	if !authorize(w, r, h.memberService, projectID, model.ProjectRoleViewer) {
+		return
+	}
 
 	pods, err := h.service.PodsByProject(projectID)
 	if err != nil {
@@ -98,24 +145,162 @@ func (h *PodHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authorizePod(w, r, h.memberService, pod.ID, model.ProjectRoleDeveloper) {
+		return
+	}
+	existing, err := h.service.Pod(pod.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !h.authorizeCredentials(w, r, &pod, existing) {
+		return
+	}
+	// The container is managed by deploys, not by clients
+	pod.ContainerID = existing.ContainerID
+	pod.Status = existing.Status
+
+	err = normalizePod(&pod)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	err = h.service.Update(pod)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	updated, err := h.service.Pod(pod.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, podEvent("pod.update", updated), existing, updated)
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(pod)
+	json.NewEncoder(w).Encode(updated)
 }
 
 func (h *PodHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, id, model.ProjectRoleOwner) {
+		return
+	}
 
-	err := h.service.Delete(id)
+	// Named volumes are kept unless explicitly requested (?volumes=remove)
+	removeVolumes := r.URL.Query().Get("volumes") == "remove"
+
+	existing, err := h.service.Pod(id)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
 
+	err = h.service.Delete(id, removeVolumes)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Certificates of the domains are deleted with the pod, drop them from Traefik
+	err = h.certificateService.WriteConfig()
+	if err != nil {
+		slog.Warn("failed to write traefik certificates", "pod", id, "error", err)
+	}
+	audit(r, h.auditService, podEvent("pod.delete", existing), existing, nil)
+
 	w.WriteHeader(http.StatusNoContent) // 204 - Standard for successful DELETE
 }
+
+// authorizeCredentials checks that the git token and registry credential a pod
+// uses belong to the user of the request. Credentials already set on the existing
+// pod are kept as they are, so members can edit pods of a shared project.
+// On failure the error response is written and false returned.
+func (h *PodHandler) authorizeCredentials(w http.ResponseWriter, r *http.Request, pod, existing *model.Pod) bool {
+	if pod.GitTokenID != nil && *pod.GitTokenID != "" &&
+		(existing == nil || existing.GitTokenID == nil || *existing.GitTokenID != *pod.GitTokenID) {
+		token, err := h.gitTokenService.GitToken(*pod.GitTokenID)
+		if err == nil && !auth.IsOwner(token.UserID, r.Context()) {
+			err = fmt.Errorf("git token %s: %w", *pod.GitTokenID, errs.ErrNotFound)
+		}
+		if err != nil {
+			writeError(w, err)
+			return false
+		}
+	}
+
+	if pod.RegistryCredentialID != nil && *pod.RegistryCredentialID != "" &&
+		(existing == nil || existing.RegistryCredentialID == nil || *existing.RegistryCredentialID != *pod.RegistryCredentialID) {
+		credential, err := h.registryCredentialService.RegistryCredential(*pod.RegistryCredentialID)
+		if err == nil && !auth.IsOwner(credential.UserID, r.Context()) {
+			err = fmt.Errorf("registry credential %s: %w", *pod.RegistryCredentialID, errs.ErrNotFound)
+		}
+		if err != nil {
+			writeError(w, err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizePod applies defaults for the source, build strategy and health check
+// of a pod and rejects unknown values and invalid resource limits.
+// Limits exceeding the host's resources are rejected by the service.
+func normalizePod(pod *model.Pod) error {
+	switch pod.SourceType {
+	case "":
+		pod.SourceType = model.PodSourceGit
+	case model.PodSourceGit, model.PodSourceImage:
+	default:
+		return errors.New("Source type must be git or image")
+	}
+
+	switch pod.BuildStrategy {
+	case "":
+		pod.BuildStrategy = model.BuildStrategyDockerfile
+	case model.BuildStrategyDockerfile, model.BuildStrategyAuto:
+	default:
+		return errors.New("Build strategy must be dockerfile or auto")
+	}
+
+	if pod.HealthCheckPath == "" {
+		pod.HealthCheckPath = model.DefaultHealthCheckPath
+	}
+	if !strings.HasPrefix(pod.HealthCheckPath, "/") {
+		return errors.New("Health check path must start with /")
+	}
+	if pod.HealthCheckStatus != 0 && (pod.HealthCheckStatus < 100 || pod.HealthCheckStatus > 599) {
+		return errors.New("Health check status must be a HTTP status code (or 0 for any 2xx/3xx)")
+	}
+	if pod.HealthCheckInterval == 0 {
+		pod.HealthCheckInterval = model.DefaultHealthCheckInterval
+	}
+	if pod.HealthCheckTimeout == 0 {
+		pod.HealthCheckTimeout = model.DefaultHealthCheckTimeout
+	}
+	if pod.HealthCheckGracePeriod == 0 {
+		pod.HealthCheckGracePeriod = model.DefaultHealthCheckGracePeriod
+	}
+	if pod.HealthCheckInterval < 1 || pod.HealthCheckInterval > 300 ||
+		pod.HealthCheckTimeout < 1 || pod.HealthCheckTimeout > 300 {
+		return errors.New("Health check interval and timeout must be between 1 and 300 seconds")
+	}
+	if pod.HealthCheckGracePeriod < 1 || pod.HealthCheckGracePeriod > model.MaxHealthCheckGracePeriod {
+		return fmt.Errorf("Health check grace period must be between 1 and %d seconds", model.MaxHealthCheckGracePeriod)
+	}
+
+	if pod.MemoryLimit < 0 || pod.MemoryReservation < 0 || pod.CPULimit < 0 || pod.PIDsLimit < 0 {
+		return errors.New("Resource limits must not be negative")
+	}
+	// Docker rejects memory limits below 6MB
+	if pod.MemoryLimit != 0 && pod.MemoryLimit < 6 {
+		return errors.New("Memory limit must be at least 6 MB")
+	}
+	if pod.MemoryLimit != 0 && pod.MemoryReservation > pod.MemoryLimit {
+		return errors.New("Memory reservation must not exceed the memory limit")
+	}
+
+	return nil
+}
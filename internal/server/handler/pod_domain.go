@@ -4,74 +4,200 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log/slog"
 	"net/http"
+	"path"
+	"regexp"
 	"strings"
-	"sync"
 
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
 	"github.com/deeploy-sh/deeploy/internal/server/service"
 	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
 	"github.com/google/uuid"
 )
 
-// Note: slog is kept for getPublicIP() logging
-
 type PodDomainHandler struct {
-	service       *service.PodDomainService
-	podService    *service.PodService
-	isDevelopment bool
-	publicIP      string
-	publicIPOnce  sync.Once
+	service            *service.PodDomainService
+	podService         *service.PodService
+	certificateService service.CertificateServiceInterface
+	memberService      service.MemberServiceInterface
+	auditService       service.AuditServiceInterface
+	isDevelopment      bool
 }
 
-func NewPodDomainHandler(service *service.PodDomainService, podService *service.PodService, isDevelopment bool) *PodDomainHandler {
+func NewPodDomainHandler(service *service.PodDomainService, podService *service.PodService, certificateService *service.CertificateService, memberService *service.MemberService, auditService *service.AuditService, isDevelopment bool) *PodDomainHandler {
 	return &PodDomainHandler{
-		service:       service,
-		podService:    podService,
-		isDevelopment: isDevelopment,
+		service:            service,
+		podService:         podService,
+		certificateService: certificateService,
+		memberService:      memberService,
+		auditService:       auditService,
+		isDevelopment:      isDevelopment,
 	}
 }
 
-// setDomainURL computes the full URL based on environment
+// setDomainURL computes the full URL based on environment and SSL
 func (h *PodDomainHandler) setDomainURL(d *model.PodDomain) {
 	scheme := "https://"
-	if h.isDevelopment {
+	if h.isDevelopment || !d.SSLEnabled {
 		scheme = "http://"
 	}
-	d.URL = scheme + d.Domain
+	d.URL = scheme + d.Domain + d.Path
+}
+
+// setDNSRecord attaches the record a domain still needs, generated and
+// verified domains need none.
+func (h *PodDomainHandler) setDNSRecord(d *model.PodDomain) {
+	d.DNSRecord = nil
+	if d.Type != model.DomainTypeAuto && d.Status != model.DomainStatusVerified {
+		d.DNSRecord = h.service.DNSRecord(d.Domain)
+	}
+}
+
+// setCertificate attaches the uploaded certificate of a domain, if any.
+func (h *PodDomainHandler) setCertificate(d *model.PodDomain) error {
+	certificate, err := h.certificateService.CertificateByDomain(d.ID)
+	if errors.Is(err, errs.ErrNotFound) {
+		d.Certificate = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	d.Certificate = certificate
+	return nil
+}
+
+// trimURL strips the scheme and trailing slash users often paste along with a host.
+func trimURL(host string) string {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	return strings.TrimSuffix(host, "/")
+}
+
+// hostLabel is one dot separated part of a host name.
+var hostLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// validHost reports whether host is a plain host name like app.example.com.
+// Domains and redirect targets end up in Traefik rules and redirect labels,
+// so nothing else may pass.
+func validHost(host string) bool {
+	if len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if !hostLabel.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateDomain normalizes a domain and checks it against its type. Without
+// a type, *.example.com is a wildcard and a domain with a target a redirect.
+func validateDomain(d *model.PodDomain) error {
+	d.Domain = strings.ToLower(trimURL(d.Domain))
+	d.RedirectTo = strings.ToLower(trimURL(d.RedirectTo))
+	d.Path = strings.TrimSpace(d.Path)
+
+	if d.Domain == "" {
+		return errors.New("Domain is required")
+	}
+	if d.Type == "" {
+		switch {
+		case strings.HasPrefix(d.Domain, "*."):
+			d.Type = model.DomainTypeWildcard
+		case d.RedirectTo != "":
+			d.Type = model.DomainTypeRedirect
+		default:
+			d.Type = model.DomainTypeCustom
+		}
+	}
+
+	switch d.Type {
+	case model.DomainTypeCustom, model.DomainTypeAuto:
+	case model.DomainTypeWildcard:
+		suffix, ok := strings.CutPrefix(d.Domain, "*.")
+		if !ok || !strings.Contains(suffix, ".") {
+			return errors.New("Wildcard domains look like *.example.com")
+		}
+	case model.DomainTypeRedirect:
+		if d.RedirectTo == "" {
+			return errors.New("Redirect target is required")
+		}
+		if d.RedirectTo == d.Domain {
+			return errors.New("Domain can't redirect to itself")
+		}
+		if !validHost(d.RedirectTo) {
+			return errors.New("Redirect target must be a host like example.com")
+		}
+		if d.Path != "" {
+			return errors.New("Redirect domains redirect every path")
+		}
+	default:
+		return errors.New("Type must be custom, wildcard or redirect")
+	}
+	if d.Type != model.DomainTypeRedirect {
+		d.RedirectTo = ""
+	}
+	if d.Type != model.DomainTypeWildcard && strings.Contains(d.Domain, "*") {
+		return errors.New("Only wildcard domains may contain *")
+	}
+	if strings.ContainsAny(d.Domain, "/` ") {
+		return errors.New("Domain must be a host like app.example.com, set paths separately")
+	}
+	if !validHost(strings.TrimPrefix(d.Domain, "*.")) {
+		return errors.New("Domain must be a host like app.example.com")
+	}
+
+	if d.Path != "" {
+		if !strings.HasPrefix(d.Path, "/") || strings.ContainsAny(d.Path, "`?# ") {
+			return errors.New("Path must start with / like /api")
+		}
+		d.Path = path.Clean(d.Path)
+		if d.Path == "/" {
+			d.Path = ""
+		}
+	}
+	return nil
 }
 
 func (h *PodDomainHandler) Create(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
-	var req model.PodDomain
+	// SSL is on unless turned off, in production Traefik gets a certificate
+	// from Let's Encrypt. Development always serves plain HTTP.
+	req := model.PodDomain{SSLEnabled: true}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
 
-	if req.Domain == "" {
-		http.Error(w, "Domain is required", http.StatusBadRequest)
-		return
-	}
-
 	if req.Port == 0 {
 		req.Port = 80
 	}
 
-	// SSL is always enabled in production (automatic via Let's Encrypt)
-	// The actual SSL handling is done by Traefik based on isDevelopment flag
 	domain := &model.PodDomain{
 		ID:         uuid.New().String(),
 		PodID:      podID,
 		Domain:     req.Domain,
-		Type:       "custom",
+		Path:       req.Path,
+		RedirectTo: req.RedirectTo,
+		Type:       req.Type,
 		Port:       req.Port,
-		SSLEnabled: true, // Always true - SSL is automatic in production
+		SSLEnabled: req.SSLEnabled,
+	}
+	err = validateDomain(domain)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	_, err = h.service.Create(domain)
@@ -79,8 +205,10 @@ func (h *PodDomainHandler) Create(w http.ResponseWriter, r *http.Request) {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, h.auditService.PodEvent("domain.create", podID), nil, domain)
 
 	h.setDomainURL(domain)
+	h.setDNSRecord(domain)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(domain)
@@ -88,6 +216,9 @@ func (h *PodDomainHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 func (h *PodDomainHandler) List(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
 
 	domains, err := h.service.DomainsByPod(podID)
 	if err != nil {
@@ -95,75 +226,219 @@ func (h *PodDomainHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	certificates, err := h.certificateService.CertificatesByPod(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 	for i := range domains {
 		h.setDomainURL(&domains[i])
+		h.setDNSRecord(&domains[i])
+		for _, c := range certificates {
+			if c.PodDomainID == domains[i].ID {
+				domains[i].Certificate = &c
+			}
+		}
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(domains)
 }
 
 func (h *PodDomainHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
 	domainID := r.PathValue("domainId")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	existing, err := h.service.Domain(domainID)
+	if err != nil || existing.PodID != podID {
+		writeError(w, fmt.Errorf("domain %s: %w", domainID, errs.ErrNotFound))
+		return
+	}
+
+	// Removes the certificate from the Traefik TLS store before the domain is gone
+	err = h.certificateService.Delete(domainID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	err := h.service.Delete(domainID)
+	err = h.service.Delete(domainID)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, h.auditService.PodEvent("domain.delete", podID), existing, nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *PodDomainHandler) Update(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
 	domainID := r.PathValue("domainId")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
-	var req model.PodDomain
+	req := model.PodDomain{SSLEnabled: true}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
 
-	if req.Domain == "" {
-		http.Error(w, "Domain is required", http.StatusBadRequest)
-		return
-	}
-
 	if req.Port == 0 {
 		req.Port = 80
 	}
 
-	// Get existing domain to preserve type
 	existing, err := h.service.Domain(domainID)
-	if err != nil {
+	if err != nil || existing.PodID != podID {
 		writeError(w, fmt.Errorf("domain %s: %w", domainID, errs.ErrNotFound))
 		return
 	}
 
+	// Generated domains stay generated, others may change their type
+	domainType := req.Type
+	if existing.Type == model.DomainTypeAuto {
+		domainType = model.DomainTypeAuto
+	}
+
 	domain := model.PodDomain{
 		ID:         domainID,
 		PodID:      existing.PodID,
 		Domain:     req.Domain,
-		Type:       existing.Type, // Preserve original type
+		Path:       req.Path,
+		RedirectTo: req.RedirectTo,
+		Type:       domainType,
 		Port:       req.Port,
 		SSLEnabled: req.SSLEnabled,
 	}
+	err = validateDomain(&domain)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	err = h.service.Update(domain)
+	err = h.service.Update(&domain)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, h.auditService.PodEvent("domain.update", podID), existing, domain)
 
+	err = h.setCertificate(&domain)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 	h.setDomainURL(&domain)
+	h.setDNSRecord(&domain)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(domain)
+}
+
+// Verify checks the DNS of a domain again. Only verified domains are
+// routed, a domain verified now is live after the next deploy.
+func (h *PodDomainHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	domainID := r.PathValue("domainId")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	domain, err := h.service.Domain(domainID)
+	if err != nil || domain.PodID != podID {
+		writeError(w, fmt.Errorf("domain %s: %w", domainID, errs.ErrNotFound))
+		return
+	}
+
+	existing := *domain
+	err = h.service.Verify(domain)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("domain.verify", podID), existing, domain)
+
+	err = h.setCertificate(domain)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	h.setDomainURL(domain)
+	h.setDNSRecord(domain)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(domain)
+}
+
+// SkipVerification routes a domain without checking its DNS, for domains
+// behind a CDN or NAT. Skipping claims a host without proving control of
+// it, so only server admins may do it.
+func (h *PodDomainHandler) SkipVerification(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	domainID := r.PathValue("domainId")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleOwner) {
+		return
+	}
+	if !auth.GetUser(r.Context()).IsAdmin {
+		writeError(w, fmt.Errorf("only server admins may skip the DNS check: %w", errs.ErrForbidden))
+		return
+	}
+
+	domain, err := h.service.Domain(domainID)
+	if err != nil || domain.PodID != podID {
+		writeError(w, fmt.Errorf("domain %s: %w", domainID, errs.ErrNotFound))
+		return
+	}
+
+	existing := *domain
+	err = h.service.SkipVerification(domain)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	event := h.auditService.PodEvent("domain.skip_verification", podID)
+	event.TargetType = "domain"
+	event.TargetID = domain.ID
+	event.TargetName = domain.Domain + domain.Path
+	audit(r, h.auditService, event, existing, domain)
+
+	err = h.setCertificate(domain)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	h.setDomainURL(domain)
+	h.setDNSRecord(domain)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(domain)
 }
 
+// DNSRecord returns the record a domain needs, so it can be created before
+// the domain is added.
+func (h *PodDomainHandler) DNSRecord(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
+
+	record := h.service.DNSRecord(strings.ToLower(trimURL(r.URL.Query().Get("domain"))))
+	if record == nil {
+		http.Error(w, "Public IP of the server is unknown, set PUBLIC_IP", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(record)
+}
+
 func (h *PodDomainHandler) Generate(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
-	var req model.PodDomain
+	req := model.PodDomain{SSLEnabled: true}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
@@ -186,23 +461,20 @@ func (h *PodDomainHandler) Generate(w http.ResponseWriter, r *http.Request) {
 
 	// Build sslip.io domain (wildcard DNS that resolves to embedded IP)
 	// Format: subdomain.IP.sslip.io -> resolves to IP
-	var ip string
-	if h.isDevelopment {
-		ip = "127.0.0.1"
-	} else {
-		ip = h.getPublicIP()
+	ip := h.service.PublicIP()
+	if ip == "" {
+		ip = "127-0-0-1" // fallback
 	}
 	domainName := fmt.Sprintf("%s.%s.sslip.io", subdomain, ip)
 
-	// SSL is always enabled in production (automatic via Let's Encrypt)
 	// sslip.io domains work with HTTP challenge just like custom domains
 	domain := &model.PodDomain{
 		ID:         uuid.New().String(),
 		PodID:      podID,
 		Domain:     domainName,
-		Type:       "auto",
+		Type:       model.DomainTypeAuto,
 		Port:       req.Port,
-		SSLEnabled: true, // Always true - SSL is automatic in production
+		SSLEnabled: req.SSLEnabled,
 	}
 
 	_, err = h.service.Create(domain)
@@ -210,35 +482,88 @@ func (h *PodDomainHandler) Generate(w http.ResponseWriter, r *http.Request) {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, h.auditService.PodEvent("domain.create", podID), nil, domain)
 
 	h.setDomainURL(domain)
+	h.setDNSRecord(domain)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(domain)
 }
 
-// getPublicIP fetches the public IP via ipify.org (cached)
-func (h *PodDomainHandler) getPublicIP() string {
-	h.publicIPOnce.Do(func() {
-		resp, err := http.Get("https://api.ipify.org")
-		if err != nil {
-			slog.Warn("failed to get public IP", "error", err)
-			h.publicIP = "127-0-0-1" // fallback
-			return
-		}
-		defer resp.Body.Close()
+// UploadCertificate replaces the certificate of a domain with an uploaded
+// one. Traefik serves it instead of a Let's Encrypt certificate once the
+// pod is redeployed.
+func (h *PodDomainHandler) UploadCertificate(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	domainID := r.PathValue("domainId")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			slog.Warn("failed to read public IP response", "error", err)
-			h.publicIP = "127-0-0-1" // fallback
-			return
-		}
+	var req model.CertificateUpload
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if req.Certificate == "" || req.PrivateKey == "" {
+		http.Error(w, "Certificate and private key are required", http.StatusBadRequest)
+		return
+	}
+
+	domain, err := h.service.Domain(domainID)
+	if err != nil || domain.PodID != podID {
+		writeError(w, fmt.Errorf("domain %s: %w", domainID, errs.ErrNotFound))
+		return
+	}
+
+	certificate, err := h.certificateService.Upload(domain, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("certificate.upload", podID), nil, certificate)
+
+	domain.Certificate = certificate
+	h.setDomainURL(domain)
+	h.setDNSRecord(domain)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(domain)
+}
+
+// DeleteCertificate removes the uploaded certificate of a domain,
+// it falls back to Let's Encrypt on the next deploy.
+func (h *PodDomainHandler) DeleteCertificate(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	domainID := r.PathValue("domainId")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	domain, err := h.service.Domain(domainID)
+	if err != nil || domain.PodID != podID {
+		writeError(w, fmt.Errorf("domain %s: %w", domainID, errs.ErrNotFound))
+		return
+	}
+
+	existing, err := h.certificateService.CertificateByDomain(domainID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = h.certificateService.Delete(domainID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("certificate.delete", podID), existing, nil)
 
-		h.publicIP = strings.TrimSpace(string(body))
-		slog.Info("detected public IP", "ip", h.publicIP)
-	})
-	return h.publicIP
+	h.setDomainURL(domain)
+	h.setDNSRecord(domain)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(domain)
 }
 
 // generateSubdomain creates a URL-safe subdomain from title + random suffix.
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+func TestValidateDomain(t *testing.T) {
+	tests := []struct {
+		domain model.PodDomain
+		ok     bool
+		want   model.PodDomain
+	}{
+		{model.PodDomain{Domain: "App.Example.com"}, true, model.PodDomain{Domain: "app.example.com", Type: model.DomainTypeCustom}},
+		{model.PodDomain{Domain: "https://example.com/", Path: "/api/"}, true, model.PodDomain{Domain: "example.com", Path: "/api", Type: model.DomainTypeCustom}},
+		{model.PodDomain{Domain: "example.com", Path: "/"}, true, model.PodDomain{Domain: "example.com", Type: model.DomainTypeCustom}},
+		{model.PodDomain{Domain: "*.example.com"}, true, model.PodDomain{Domain: "*.example.com", Type: model.DomainTypeWildcard}},
+		{model.PodDomain{Domain: "www.example.com", RedirectTo: "Example.com"}, true, model.PodDomain{Domain: "www.example.com", RedirectTo: "example.com", Type: model.DomainTypeRedirect}},
+		{model.PodDomain{Domain: "example.com", RedirectTo: "www.example.com", Type: model.DomainTypeCustom}, true, model.PodDomain{Domain: "example.com", Type: model.DomainTypeCustom}},
+		{model.PodDomain{Domain: "web-127-0-0-1.sslip.io", Type: model.DomainTypeAuto}, true, model.PodDomain{Domain: "web-127-0-0-1.sslip.io", Type: model.DomainTypeAuto}},
+
+		{model.PodDomain{}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "*.com"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "app.*.example.com"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "example.com/api"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "example.com`"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "exa$mple.com"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "-example.com"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "example..com"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "example.com", Path: "api"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "example.com", Path: "/api?x"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "example.com", Type: "proxy"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "www.example.com", RedirectTo: "www.example.com"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "www.example.com", RedirectTo: "example.com", Path: "/docs"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "www.example.com", Type: model.DomainTypeRedirect}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "www.example.com", RedirectTo: "example.com/docs"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "www.example.com", RedirectTo: "evil.com${1}"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "www.example.com", RedirectTo: "example.com:8080"}, false, model.PodDomain{}},
+		{model.PodDomain{Domain: "www.example.com", RedirectTo: "a b.com"}, false, model.PodDomain{}},
+	}
+	for _, tt := range tests {
+		d := tt.domain
+		err := validateDomain(&d)
+		if (err == nil) != tt.ok {
+			t.Errorf("validateDomain(%+v) = %v, want ok %v", tt.domain, err, tt.ok)
+			continue
+		}
+		if tt.ok && (d.Domain != tt.want.Domain || d.Path != tt.want.Path || d.RedirectTo != tt.want.RedirectTo || d.Type != tt.want.Type) {
+			t.Errorf("validateDomain(%+v) normalized to %+v, want %+v", tt.domain, d, tt.want)
+		}
+	}
+}
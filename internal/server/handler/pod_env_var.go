@@ -10,18 +10,36 @@ import (
 )
 
 type PodEnvVarHandler struct {
-	service    *service.PodEnvVarService
-	podService *service.PodService
+	service       *service.PodEnvVarService
+	podService    *service.PodService
+	memberService service.MemberServiceInterface
+	auditService  service.AuditServiceInterface
 }
 
-func NewPodEnvVarHandler(service *service.PodEnvVarService, podService *service.PodService) *PodEnvVarHandler {
+func NewPodEnvVarHandler(service *service.PodEnvVarService, podService *service.PodService, memberService *service.MemberService, auditService *service.AuditService) *PodEnvVarHandler {
 	return &PodEnvVarHandler{
-		service:    service,
-		podService: podService,
+		service:       service,
+		podService:    podService,
+		memberService: memberService,
+		auditService:  auditService,
 	}
 }
+
+// envVarValues maps the keys of env vars to their values, for the audit log.
+func envVarValues(envVars []model.PodEnvVar) map[string]string {
+	values := make(map[string]string, len(envVars))
+	for _, v := range envVars {
+		values[v.Key] = v.Value
+	}
+	return values
+}
+
+// List returns the decrypted env vars of a pod, viewers can't read secrets.
 func (h *PodEnvVarHandler) List(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
 	envVars, err := h.service.EnvVarsByPod(podID)
 	if err != nil {
@@ -36,6 +54,9 @@ func (h *PodEnvVarHandler) List(w http.ResponseWriter, r *http.Request) {
 // BulkUpdate replaces all env vars for a pod (delete all + create new)
 func (h *PodEnvVarHandler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
 	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
 
 	var req model.PodEnvVarBulkUpdate
 	err := json.NewDecoder(r.Body).Decode(&req)
@@ -44,6 +65,24 @@ func (h *PodEnvVarHandler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Validate scopes before touching existing vars (default: runtime)
+	for i, v := range req.Vars {
+		switch v.Scope {
+		case "":
+			req.Vars[i].Scope = model.EnvVarScopeRuntime
+		case model.EnvVarScopeRuntime, model.EnvVarScopeBuild, model.EnvVarScopeBoth:
+		default:
+			http.Error(w, "Invalid scope for "+v.Key+": must be runtime, build or both", http.StatusBadRequest)
+			return
+		}
+	}
+
+	existing, err := h.service.EnvVarsByPod(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	// Delete all existing vars for this pod
 	err = h.service.DeleteByPod(podID)
 	if err != nil {
@@ -62,6 +101,7 @@ func (h *PodEnvVarHandler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
 			PodID: podID,
 			Key:   v.Key,
 			Value: v.Value,
+			Scope: v.Scope,
 		}
 
 		_, err := h.service.Create(envVar)
@@ -77,5 +117,9 @@ func (h *PodEnvVarHandler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	event := h.auditService.PodEvent("env_vars.update", podID)
+	event.TargetType = "env_vars"
+	audit(r, h.auditService, event, envVarValues(existing), envVarValues(envVars))
+
 	json.NewEncoder(w).Encode(envVars)
 }
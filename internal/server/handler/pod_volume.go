@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+// volumeNamePattern matches valid Docker volume names.
+var volumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// protectedHostPaths are never bind mounted, together with everything below
+// them. Mounting them would hand the container the host or deeploy itself.
+var protectedHostPaths = []string{
+	"/bin", "/boot", "/dev", "/etc", "/lib", "/lib64", "/proc", "/root", "/run",
+	"/sbin", "/sys", "/usr", "/var/lib/docker", "/var/run", "/opt/deeploy",
+}
+
+// validateHostPath checks the source of a bind mount, a clean absolute path.
+func validateHostPath(source string) error {
+	if source == "/" || path.Base(source) == "docker.sock" {
+		return fmt.Errorf("Host path %s can't be mounted", source)
+	}
+	for _, p := range protectedHostPaths {
+		if source == p || strings.HasPrefix(source, p+"/") {
+			return fmt.Errorf("Host path %s can't be mounted, %s is a system path", source, p)
+		}
+	}
+	return nil
+}
+
+// authorizeBindMount lets only server admins mount host paths, project
+// members could otherwise read and write any file of the host.
+func authorizeBindMount(w http.ResponseWriter, r *http.Request, volumeType string) bool {
+	if volumeType == model.VolumeTypeBind && !auth.GetUser(r.Context()).IsAdmin {
+		writeError(w, fmt.Errorf("only server admins may mount host paths: %w", errs.ErrForbidden))
+		return false
+	}
+	return true
+}
+
+type PodVolumeHandler struct {
+	service       *service.PodVolumeService
+	memberService service.MemberServiceInterface
+	auditService  service.AuditServiceInterface
+}
+
+func NewPodVolumeHandler(service *service.PodVolumeService, memberService *service.MemberService, auditService *service.AuditService) *PodVolumeHandler {
+	return &PodVolumeHandler{service: service, memberService: memberService, auditService: auditService}
+}
+
+func (h *PodVolumeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+
+	var req model.PodVolume
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+	if !authorizeBindMount(w, r, req.Type) {
+		return
+	}
+
+	volume := &model.PodVolume{
+		ID:        uuid.New().String(),
+		PodID:     podID,
+		Type:      req.Type,
+		Source:    req.Source,
+		MountPath: req.MountPath,
+		ReadOnly:  req.ReadOnly,
+	}
+	err = h.validate(volume)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.service.Create(volume)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("volume.create", podID), nil, volume)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(volume)
+}
+
+func (h *PodVolumeHandler) List(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleViewer) {
+		return
+	}
+
+	volumes, err := h.service.VolumesByPod(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(volumes)
+}
+
+func (h *PodVolumeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	volumeID := r.PathValue("volumeId")
+
+	var req model.PodVolume
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+	if !authorizeBindMount(w, r, req.Type) {
+		return
+	}
+
+	existing, err := h.service.Volume(volumeID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if existing.PodID != podID {
+		http.Error(w, "Volume not found", http.StatusNotFound)
+		return
+	}
+
+	volume := model.PodVolume{
+		ID:        volumeID,
+		PodID:     podID,
+		Type:      req.Type,
+		Source:    req.Source,
+		MountPath: req.MountPath,
+		ReadOnly:  req.ReadOnly,
+	}
+	err = h.validate(&volume)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.Update(&volume)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("volume.update", podID), existing, volume)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(volume)
+}
+
+func (h *PodVolumeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	volumeID := r.PathValue("volumeId")
+
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	existing, err := h.service.Volume(volumeID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if existing.PodID != podID {
+		http.Error(w, "Volume not found", http.StatusNotFound)
+		return
+	}
+
+	err = h.service.Delete(volumeID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("volume.delete", podID), existing, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validate normalizes a volume and checks it against the other volumes of its pod.
+func (h *PodVolumeHandler) validate(volume *model.PodVolume) error {
+	volume.Source = strings.TrimSpace(volume.Source)
+	volume.MountPath = strings.TrimSpace(volume.MountPath)
+
+	switch volume.Type {
+	case "":
+		volume.Type = model.VolumeTypeVolume
+		fallthrough
+	case model.VolumeTypeVolume:
+		if !volumeNamePattern.MatchString(volume.Source) {
+			return errors.New("Volume name may only contain letters, digits, _ . and -")
+		}
+	case model.VolumeTypeBind:
+		if !path.IsAbs(volume.Source) {
+			return errors.New("Host path must be absolute")
+		}
+		volume.Source = path.Clean(volume.Source)
+		err := validateHostPath(volume.Source)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("Volume type must be volume or bind")
+	}
+
+	if !path.IsAbs(volume.MountPath) {
+		return errors.New("Mount path must be absolute")
+	}
+	volume.MountPath = path.Clean(volume.MountPath)
+	if volume.MountPath == "/" {
+		return errors.New("Mount path must not be /")
+	}
+
+	volumes, err := h.service.VolumesByPod(volume.PodID)
+	if err != nil {
+		return err
+	}
+	for _, v := range volumes {
+		if v.ID != volume.ID && v.MountPath == volume.MountPath {
+			return errors.New("Mount path is already used by another volume of this pod")
+		}
+	}
+	return nil
+}
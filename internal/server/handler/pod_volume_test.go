@@ -0,0 +1,34 @@
+package handlers
+
+import "testing"
+
+func TestValidateHostPath(t *testing.T) {
+	tests := []struct {
+		source string
+		ok     bool
+	}{
+		{"/srv/myapp", true},
+		{"/home/deploy/uploads", true},
+		{"/data", true},
+		{"/etcetera", true},
+		{"/", false},
+		{"/etc", false},
+		{"/etc/ssl", false},
+		{"/root/.ssh", false},
+		{"/proc", false},
+		{"/sys/fs", false},
+		{"/dev/sda", false},
+		{"/var/run/docker.sock", false},
+		{"/run/docker.sock", false},
+		{"/srv/docker.sock", false},
+		{"/var/lib/docker/volumes", false},
+		{"/opt/deeploy/data", false},
+		{"/usr/bin", false},
+	}
+	for _, tt := range tests {
+		err := validateHostPath(tt.source)
+		if (err == nil) != tt.ok {
+			t.Errorf("validateHostPath(%q) = %v, want ok %v", tt.source, err, tt.ok)
+		}
+	}
+}
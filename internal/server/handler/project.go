@@ -13,12 +13,19 @@ import (
 )
 
 type ProjectHandler struct {
-	service    service.ProjectServiceInterface
-	podService service.PodServiceInterface
+	service         service.ProjectServiceInterface
+	podService      service.PodServiceInterface
+	databaseService service.DatabaseServiceInterface
+	memberService   service.MemberServiceInterface
+	auditService    service.AuditServiceInterface
 }
 
-func NewProjectHandler(service *service.ProjectService, podService *service.PodService) *ProjectHandler {
-	return &ProjectHandler{service: service, podService: podService}
+func NewProjectHandler(service *service.ProjectService, podService *service.PodService, databaseService *service.DatabaseService, memberService *service.MemberService, auditService *service.AuditService) *ProjectHandler {
+	return &ProjectHandler{service: service, podService: podService, databaseService: databaseService, memberService: memberService, auditService: auditService}
+}
+
+func projectEvent(action string, project *model.Project) model.AuditEvent {
+	return model.AuditEvent{Action: action, TargetType: "project", TargetID: project.ID, TargetName: project.Title, ProjectID: project.ID}
 }
 
 func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -37,12 +44,14 @@ func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	project.ID = uuid.New().String()
 	project.UserID = auth.GetUser(r.Context()).ID
+	project.Role = model.ProjectRoleOwner
 
 	_, err = h.service.Create(&project)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, projectEvent("project.create", &project), nil, project)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(project)
@@ -51,12 +60,18 @@ func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *ProjectHandler) Project(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	project, err := h.service.Project(id)
+	role, err := h.memberService.Role(id, auth.GetUser(r.Context()).ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
+	project, err := h.service.Project(id)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	project.Role = role
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(project)
@@ -84,11 +99,27 @@ func (h *ProjectHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authorize(w, r, h.memberService, project.ID, model.ProjectRoleOwner) {
+		return
+	}
+
+	existing, err := h.service.Project(project.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	err = h.service.Update(project)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	updated, err := h.service.Project(project.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, projectEvent("project.update", updated), existing, updated)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(project)
@@ -96,6 +127,9 @@ func (h *ProjectHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 func (h *ProjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
+	if !authorize(w, r, h.memberService, id, model.ProjectRoleOwner) {
+		return
+	}
 
 	// Check if project has pods
 	podCount, err := h.podService.CountByProject(id)
@@ -108,11 +142,29 @@ func (h *ProjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Check if project has databases
+	databaseCount, err := h.databaseService.CountByProject(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if databaseCount > 0 {
+		writeError(w, fmt.Errorf("cannot delete project with existing databases: %w", errs.ErrConflict))
+		return
+	}
+
+	existing, err := h.service.Project(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	err = h.service.Delete(id)
 	if err != nil {
 		writeError(w, err)
 		return
 	}
+	audit(r, h.auditService, projectEvent("project.delete", existing), existing, nil)
 
 	w.WriteHeader(http.StatusNoContent) // 204 - Standard for successful DELETE
 }
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+type RegistryCredentialHandler struct {
+	service      *service.RegistryCredentialService
+	auditService service.AuditServiceInterface
+}
+
+func NewRegistryCredentialHandler(service *service.RegistryCredentialService, auditService *service.AuditService) *RegistryCredentialHandler {
+	return &RegistryCredentialHandler{service: service, auditService: auditService}
+}
+
+func registryCredentialEvent(action string, credential *model.RegistryCredential) model.AuditEvent {
+	return model.AuditEvent{Action: action, TargetType: "registry_credential", TargetID: credential.ID, TargetName: credential.Name}
+}
+
+func (h *RegistryCredentialHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.RegistryCredentialCreate
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" || req.Registry == "" || req.Username == "" || req.Password == "" {
+		http.Error(w, "Name, registry, username and password are required", http.StatusBadRequest)
+		return
+	}
+
+	userID := auth.GetUser(r.Context()).ID
+
+	credential := &model.RegistryCredential{
+		ID:       uuid.New().String(),
+		UserID:   userID,
+		Name:     req.Name,
+		Registry: strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(req.Registry, "https://"), "http://"), "/"),
+		Username: req.Username,
+		Password: req.Password,
+	}
+
+	created, err := h.service.Create(credential)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, registryCredentialEvent("registry_credential.create", credential), nil, credential)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (h *RegistryCredentialHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUser(r.Context()).ID
+
+	credentials, err := h.service.RegistryCredentialsByUser(userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(credentials)
+}
+
+func (h *RegistryCredentialHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	existing, err := h.service.RegistryCredential(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !auth.IsOwner(existing.UserID, r.Context()) {
+		writeError(w, fmt.Errorf("registry credential %s: %w", id, errs.ErrNotFound))
+		return
+	}
+
+	err = h.service.Delete(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, registryCredentialEvent("registry_credential.delete", existing), existing, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
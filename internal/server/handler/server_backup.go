@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+// maxServerBackupSize limits uploaded server backups, they hold configuration only.
+const maxServerBackupSize = 100 << 20
+
+type ServerBackupHandler struct {
+	service            service.ServerBackupServiceInterface
+	databaseService    service.DatabaseServiceInterface
+	certificateService service.CertificateServiceInterface
+	traefikService     *service.TraefikService
+	auditService       service.AuditServiceInterface
+}
+
+func NewServerBackupHandler(service *service.ServerBackupService, databaseService *service.DatabaseService, certificateService *service.CertificateService, traefikService *service.TraefikService, auditService *service.AuditService) *ServerBackupHandler {
+	return &ServerBackupHandler{service: service, databaseService: databaseService, certificateService: certificateService, traefikService: traefikService, auditService: auditService}
+}
+
+// Backup downloads an archive of the server configuration.
+func (h *ServerBackupHandler) Backup(w http.ResponseWriter, r *http.Request) {
+	// Buffered, so a failed backup still gets an error status
+	var buf bytes.Buffer
+	_, err := h.service.Backup(&buf)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	filename := "deeploy-backup-" + time.Now().Format("2006-01-02") + ".tar.gz"
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Write(buf.Bytes())
+}
+
+// Restore replaces the server configuration with an uploaded archive.
+// X-Encryption-Key carries the ENCRYPTION_KEY of the exporting server
+// if it differs from this one.
+func (h *ServerBackupHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, maxServerBackupSize)
+
+	manifest, err := h.service.Restore(body, r.Header.Get("X-Encryption-Key"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	h.databaseService.ProvisionPending()
+
+	// The TLS store and DNS credentials aren't archived, they hold secrets in plain text
+	err = h.certificateService.WriteConfig()
+	if err != nil {
+		slog.Warn("failed to write traefik certificates", "error", err)
+	}
+	err = h.traefikService.ApplyDNSSettings()
+	if err != nil {
+		slog.Warn("failed to apply dns settings", "error", err)
+	}
+	audit(r, h.auditService, model.AuditEvent{Action: "settings.restore", TargetType: "settings", TargetName: "server backup"}, nil, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(manifest)
+}
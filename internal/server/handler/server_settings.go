@@ -2,18 +2,28 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
 type ServerSettingsHandler struct {
-	traefik *service.TraefikService
+	traefik      *service.TraefikService
+	auditService service.AuditServiceInterface
 }
 
-func NewServerSettingsHandler(traefik *service.TraefikService) *ServerSettingsHandler {
-	return &ServerSettingsHandler{traefik: traefik}
+func NewServerSettingsHandler(traefik *service.TraefikService, auditService *service.AuditService) *ServerSettingsHandler {
+	return &ServerSettingsHandler{traefik: traefik, auditService: auditService}
+}
+
+// domainEvent records a change of the server domain, empty when unset.
+func (h *ServerSettingsHandler) domainEvent(r *http.Request, action, before, after string) {
+	event := model.AuditEvent{Action: action, TargetType: "settings", TargetName: "server domain"}
+	audit(r, h.auditService, event, domainResponse{Domain: before}, domainResponse{Domain: after})
 }
 
 type domainResponse struct {
@@ -54,10 +64,17 @@ func (h *ServerSettingsHandler) SetServerDomain(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	previous, err := h.traefik.GetServerDomain()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	if err := h.traefik.SetServerDomain(domain); err != nil {
 		writeError(w, err)
 		return
 	}
+	h.domainEvent(r, "settings.domain.update", previous, domain)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(domainResponse{Domain: domain})
@@ -65,10 +82,87 @@ func (h *ServerSettingsHandler) SetServerDomain(w http.ResponseWriter, r *http.R
 
 // DeleteServerDomain removes the server domain and Traefik config.
 func (h *ServerSettingsHandler) DeleteServerDomain(w http.ResponseWriter, r *http.Request) {
+	previous, err := h.traefik.GetServerDomain()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	if err := h.traefik.DeleteServerDomain(); err != nil {
 		writeError(w, err)
 		return
 	}
+	h.domainEvent(r, "settings.domain.delete", previous, "")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dnsSettings returns the DNS settings, empty when no provider is configured.
+func (h *ServerSettingsHandler) dnsSettings() (*model.DNSSettings, error) {
+	settings, err := h.traefik.DNSSettings()
+	if errors.Is(err, errs.ErrNotFound) {
+		return &model.DNSSettings{Credentials: map[string]string{}}, nil
+	}
+	return settings, err
+}
+
+// dnsEvent records a change of the DNS provider. Credentials are redacted.
+func (h *ServerSettingsHandler) dnsEvent(r *http.Request, action string, before, after *model.DNSSettings) {
+	event := model.AuditEvent{Action: action, TargetType: "settings", TargetName: "dns provider"}
+	audit(r, h.auditService, event, before, after)
+}
+
+// GetDNSSettings returns the DNS provider of the ACME DNS challenge,
+// without secret credentials.
+func (h *ServerSettingsHandler) GetDNSSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.dnsSettings()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// SetDNSSettings switches Let's Encrypt to the DNS challenge of a provider.
+func (h *ServerSettingsHandler) SetDNSSettings(w http.ResponseWriter, r *http.Request) {
+	var req model.DNSSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	previous, err := h.dnsSettings()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	settings, err := h.traefik.SetDNSSettings(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	h.dnsEvent(r, "settings.dns.update", previous, settings)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// DeleteDNSSettings goes back to the HTTP challenge.
+func (h *ServerSettingsHandler) DeleteDNSSettings(w http.ResponseWriter, r *http.Request) {
+	previous, err := h.dnsSettings()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.traefik.DeleteDNSSettings(); err != nil {
+		writeError(w, err)
+		return
+	}
+	h.dnsEvent(r, "settings.dns.delete", previous, nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
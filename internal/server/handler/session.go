@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+type SessionHandler struct {
+	service      service.SessionServiceInterface
+	auditService service.AuditServiceInterface
+}
+
+func NewSessionHandler(service *service.SessionService, auditService *service.AuditService) *SessionHandler {
+	return &SessionHandler{service: service, auditService: auditService}
+}
+
+// List returns the sessions of the user, the one of the request is marked current.
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUser(r.Context()).ID
+
+	sessions, err := h.service.SessionsByUser(userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if current := auth.GetSession(r.Context()); current != nil {
+		for i := range sessions {
+			sessions[i].Current = sessions[i].ID == current.ID
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+// Delete revokes a session, its access and refresh tokens stop working.
+func (h *SessionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	existing, err := h.service.Session(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !auth.IsOwner(existing.UserID, r.Context()) {
+		writeError(w, fmt.Errorf("session %s: %w", id, errs.ErrNotFound))
+		return
+	}
+
+	err = h.service.Delete(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	event := model.AuditEvent{Action: "session.revoke", TargetType: "session", TargetID: existing.ID, TargetName: existing.Client}
+	audit(r, h.auditService, event, existing, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
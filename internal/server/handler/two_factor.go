@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/server/ui/pages"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+// TwoFactorHandler serves the web pages to turn two-factor authentication on and off.
+type TwoFactorHandler struct {
+	service      service.TwoFactorServiceInterface
+	auditService service.AuditServiceInterface
+}
+
+func NewTwoFactorHandler(service *service.TwoFactorService, auditService *service.AuditService) *TwoFactorHandler {
+	return &TwoFactorHandler{service: service, auditService: auditService}
+}
+
+func twoFactorEvent(action string, user *model.User) model.AuditEvent {
+	return model.AuditEvent{Action: action, TargetType: "user", TargetID: user.ID, TargetName: user.Email}
+}
+
+// View shows a new QR code, or the form to turn two-factor off once it is on.
+func (h *TwoFactorHandler) View(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUser(r.Context())
+	if user.TOTPEnabled {
+		pages.TwoFactorEnabled("").Render(r.Context(), w)
+		return
+	}
+	h.renderSetup(w, r, "")
+}
+
+func (h *TwoFactorHandler) renderSetup(w http.ResponseWriter, r *http.Request, codeErr string) {
+	setup, err := h.service.Setup(auth.GetUser(r.Context()).ID)
+	if err != nil {
+		slog.Error("two-factor setup failed", "error", err)
+		http.Error(w, "Something went wrong. Please try again.", http.StatusInternalServerError)
+		return
+	}
+	pages.TwoFactorSetup(setup, codeErr).Render(r.Context(), w)
+}
+
+// Enable turns two-factor on with a code of the QR code and shows the recovery codes.
+func (h *TwoFactorHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	codes, err := h.service.Enable(auth.GetUser(r.Context()).ID, r.FormValue("code"))
+	if errors.Is(err, errs.ErrConflict) {
+		http.Redirect(w, r, "/account/two-factor", http.StatusSeeOther)
+		return
+	}
+	if errors.Is(err, errs.ErrInvalidInput) {
+		h.renderSetup(w, r, "Invalid code")
+		return
+	}
+	if err != nil {
+		slog.Error("two-factor not enabled", "error", err)
+		h.renderSetup(w, r, "Something went wrong. Please try again.")
+		return
+	}
+	audit(r, h.auditService, twoFactorEvent("two_factor.enable", auth.GetUser(r.Context())), nil, nil)
+	pages.TwoFactorRecoveryCodes(codes).Render(r.Context(), w)
+}
+
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	err := h.service.Disable(auth.GetUser(r.Context()).ID, r.FormValue("code"))
+	if errors.Is(err, errs.ErrInvalidCredentials) {
+		pages.TwoFactorEnabled("Invalid code").Render(r.Context(), w)
+		return
+	}
+	if err != nil {
+		slog.Error("two-factor not disabled", "error", err)
+		pages.TwoFactorEnabled("Something went wrong. Please try again.").Render(r.Context(), w)
+		return
+	}
+	audit(r, h.auditService, twoFactorEvent("two_factor.disable", auth.GetUser(r.Context())), nil, nil)
+	http.Redirect(w, r, "/account/two-factor", http.StatusSeeOther)
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/deeploy-sh/deeploy/internal/server/auth"
 	"github.com/deeploy-sh/deeploy/internal/server/cookie"
@@ -11,14 +13,26 @@ import (
 	"github.com/deeploy-sh/deeploy/internal/server/service"
 	"github.com/deeploy-sh/deeploy/internal/server/ui/pages"
 	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
 type UserHandler struct {
-	service service.UserServiceInterface
+	service          service.UserServiceInterface
+	memberService    service.MemberServiceInterface
+	sessionService   service.SessionServiceInterface
+	twoFactorService service.TwoFactorServiceInterface
 }
 
-func NewUserHandler(service *service.UserService) *UserHandler {
-	return &UserHandler{service: service}
+func NewUserHandler(service *service.UserService, memberService *service.MemberService, sessionService *service.SessionService, twoFactorService *service.TwoFactorService) *UserHandler {
+	return &UserHandler{service: service, memberService: memberService, sessionService: sessionService, twoFactorService: twoFactorService}
+}
+
+// inviteError is the form message for an invite that can't be accepted.
+func inviteError(err error) string {
+	if errors.Is(err, errs.ErrInvalidInput) {
+		return "This invitation was sent to another email address"
+	}
+	return "This invitation is invalid or has expired"
 }
 
 func (h *UserHandler) LandingView(w http.ResponseWriter, r *http.Request) {
@@ -28,6 +42,27 @@ func (h *UserHandler) LandingView(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) AuthView(w http.ResponseWriter, r *http.Request) {
 	isCLI := r.URL.Query().Get("cli") == "true"
 	session := r.URL.Query().Get("session")
+	invite := r.URL.Query().Get("invite")
+
+	// Invite links sign up new users and sign in existing ones
+	if invite != "" {
+		projectInvite, err := h.memberService.InviteByToken(invite)
+		if err != nil {
+			pages.Login(forms.LoginErrors{General: inviteError(err)}, forms.LoginForm{}, isCLI, session, "").Render(r.Context(), w)
+			return
+		}
+
+		hasEmail, err := h.service.HasEmail(projectInvite.Email)
+		if err != nil {
+			slog.Error("failed to check if user exists", "error", err)
+		}
+		if hasEmail {
+			pages.Login(forms.LoginErrors{}, forms.LoginForm{Email: projectInvite.Email}, isCLI, session, invite).Render(r.Context(), w)
+			return
+		}
+		pages.Register(forms.RegisterErrors{}, forms.RegisterForm{Email: projectInvite.Email}, isCLI, session, invite).Render(r.Context(), w)
+		return
+	}
 
 	hasUser, err := h.service.HasUser()
 	if err != nil {
@@ -35,16 +70,17 @@ func (h *UserHandler) AuthView(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if hasUser {
-		pages.Login(forms.LoginErrors{}, forms.LoginForm{}, isCLI, session).Render(r.Context(), w)
+		pages.Login(forms.LoginErrors{}, forms.LoginForm{}, isCLI, session, "").Render(r.Context(), w)
 		return
 	}
 
-	pages.Register(forms.RegisterErrors{}, forms.RegisterForm{}, isCLI, session).Render(r.Context(), w)
+	pages.Register(forms.RegisterErrors{}, forms.RegisterForm{}, isCLI, session, "").Render(r.Context(), w)
 }
 
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	isCLI := r.URL.Query().Get("cli") == "true"
 	session := r.URL.Query().Get("session")
+	invite := r.URL.Query().Get("invite")
 
 	form := forms.LoginForm{
 		Email:    r.FormValue("email"),
@@ -52,76 +88,177 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 	formErrs := form.Validate()
 	if formErrs.HasErrors() {
-		pages.Login(formErrs, form, isCLI, session).Render(r.Context(), w)
+		pages.Login(formErrs, form, isCLI, session, invite).Render(r.Context(), w)
 		return
 	}
 
-	token, err := h.service.Login(form.Email, form.Password)
+	user, err := h.service.Login(form.Email, form.Password)
 	if err != nil {
 		slog.Warn("login failed", "error", err)
 		formErrs.Email = "Email or password incorrect"
 		formErrs.Password = "Email or password incorrect"
-		pages.Login(formErrs, form, isCLI, session).Render(r.Context(), w)
+		pages.Login(formErrs, form, isCLI, session, invite).Render(r.Context(), w)
 		return
 	}
 
-	if isCLI && session != "" {
-		auth.SetSessionToken(session, token)
-		pages.CliAuthSuccess().Render(r.Context(), w)
+	// The session only starts after the second factor
+	if user.TOTPEnabled {
+		challenge := auth.CreateLoginChallenge(user.ID)
+		pages.LoginTwoFactor("", challenge, isCLI, session, invite).Render(r.Context(), w)
 		return
 	}
 
-	cookie.SetCookie(w, token)
-	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+	h.completeLogin(w, r, user)
 }
 
-func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
+// LoginTwoFactor is the second step of a login with two-factor authentication.
+func (h *UserHandler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
 	isCLI := r.URL.Query().Get("cli") == "true"
 	session := r.URL.Query().Get("session")
+	invite := r.URL.Query().Get("invite")
+	challenge := r.FormValue("challenge")
 
-	// Single-tenant mode: Only allow registration if no user exists
-	// TODO: Remove this check for multi-user support
-	hasUser, _ := h.service.HasUser()
-	if hasUser {
-		http.Redirect(w, r, "/auth", http.StatusSeeOther)
+	userID, ok := auth.LoginChallengeUser(challenge)
+	if !ok {
+		pages.Login(forms.LoginErrors{General: "Sign in expired, please try again"}, forms.LoginForm{}, isCLI, session, invite).Render(r.Context(), w)
+		return
+	}
+
+	err := h.twoFactorService.Verify(userID, r.FormValue("code"))
+	if errors.Is(err, errs.ErrInvalidCredentials) {
+		slog.Warn("two-factor code rejected", "userID", userID)
+		pages.LoginTwoFactor("Invalid code", challenge, isCLI, session, invite).Render(r.Context(), w)
+		return
+	}
+	if err != nil {
+		slog.Error("two-factor verification failed", "error", err)
+		pages.LoginTwoFactor("Something went wrong. Please try again.", challenge, isCLI, session, invite).Render(r.Context(), w)
+		return
+	}
+	auth.DeleteLoginChallenge(challenge)
+
+	user, err := h.service.GetUserByID(userID)
+	if err != nil || user == nil {
+		slog.Error("failed to load user", "userID", userID, "error", err)
+		pages.Login(forms.LoginErrors{General: "Something went wrong. Please try again."}, forms.LoginForm{}, isCLI, session, invite).Render(r.Context(), w)
 		return
 	}
 
+	h.completeLogin(w, r, user)
+}
+
+// completeLogin accepts a pending invite and signs the user in.
+func (h *UserHandler) completeLogin(w http.ResponseWriter, r *http.Request, user *model.User) {
+	isCLI := r.URL.Query().Get("cli") == "true"
+	session := r.URL.Query().Get("session")
+	invite := r.URL.Query().Get("invite")
+
+	if invite != "" {
+		err := h.memberService.AcceptInvite(invite, user.Email)
+		if err != nil {
+			slog.Warn("invite not accepted", "error", err)
+			formErrs := forms.LoginErrors{General: inviteError(err)}
+			pages.Login(formErrs, forms.LoginForm{Email: user.Email}, isCLI, session, invite).Render(r.Context(), w)
+			return
+		}
+	}
+
+	h.signIn(w, r, user.ID)
+}
+
+// Register creates the first user of a server. Later users need an invite to a project.
+func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
+	isCLI := r.URL.Query().Get("cli") == "true"
+	session := r.URL.Query().Get("session")
+	invite := r.URL.Query().Get("invite")
+
 	form := forms.RegisterForm{
 		Email:           r.FormValue("email"),
 		Password:        r.FormValue("password"),
 		PasswordConfirm: r.FormValue("passwordConfirm"),
 	}
+
+	if invite == "" {
+		hasUser, _ := h.service.HasUser()
+		if hasUser {
+			http.Redirect(w, r, "/auth", http.StatusSeeOther)
+			return
+		}
+	}
+
 	formErrs := form.Validate()
+	if invite != "" && !formErrs.HasErrors() {
+		// Check the invite before the account is created
+		projectInvite, err := h.memberService.InviteByToken(invite)
+		if err != nil {
+			formErrs.General = inviteError(err)
+		} else if !strings.EqualFold(projectInvite.Email, form.Email) {
+			formErrs.Email = "Use the email address the invitation was sent to"
+		}
+	}
 	if formErrs.HasErrors() {
-		pages.Register(formErrs, form, isCLI, session).Render(r.Context(), w)
+		pages.Register(formErrs, form, isCLI, session, invite).Render(r.Context(), w)
 		return
 	}
 
-	token, err := h.service.Register(form)
+	user, err := h.service.Register(form)
 	if err == errs.ErrDuplicateEmail {
 		formErrs.Email = "Email address is already in use"
-		pages.Register(formErrs, form, isCLI, session).Render(r.Context(), w)
+		pages.Register(formErrs, form, isCLI, session, invite).Render(r.Context(), w)
 		return
 	}
 	if err != nil {
 		slog.Error("user creation failed", "error", err)
 		formErrs.General = "Something went wrong. Please try again."
-		pages.Register(formErrs, form, isCLI, session).Render(r.Context(), w)
+		pages.Register(formErrs, form, isCLI, session, invite).Render(r.Context(), w)
+		return
+	}
+
+	if invite != "" {
+		err = h.memberService.AcceptInvite(invite, form.Email)
+		if err != nil {
+			slog.Error("invite not accepted", "error", err)
+		}
+	}
+
+	h.signIn(w, r, user.ID)
+}
+
+// signIn starts a session for the user. The TUI picks its tokens up by
+// polling, browsers get them as cookies.
+func (h *UserHandler) signIn(w http.ResponseWriter, r *http.Request, userID string) {
+	isCLI := r.URL.Query().Get("cli") == "true"
+	session := r.URL.Query().Get("session")
+
+	tokens, err := h.sessionService.Create(userID, auth.ClientName(r, isCLI && session != ""), auth.ClientIP(r))
+	if err != nil {
+		slog.Error("failed to create session", "error", err)
+		http.Error(w, "Something went wrong. Please try again.", http.StatusInternalServerError)
 		return
 	}
 
 	if isCLI && session != "" {
-		auth.SetSessionToken(session, token)
+		auth.SetSessionTokens(session, tokens)
 		pages.CliAuthSuccess().Render(r.Context(), w)
 		return
 	}
 
-	cookie.SetCookie(w, token)
-	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+	cookie.SetCookie(w, tokens)
+	path := "/dashboard"
+	if redirect := cookie.PopRedirect(w, r); redirect != "" {
+		path = redirect
+	}
+	http.Redirect(w, r, path, http.StatusSeeOther)
 }
 
+// Logout ends the session of the browser, its tokens stop working right away.
 func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if refreshToken := cookie.GetRefreshTokenFromCookie(r); refreshToken != "" {
+		err := h.sessionService.Revoke(refreshToken)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			slog.Error("failed to revoke session", "error", err)
+		}
+	}
 	cookie.ClearCookie(w)
 	http.Redirect(w, r, "/auth", http.StatusSeeOther)
 }
@@ -134,7 +271,7 @@ func (h *UserHandler) PollCLISession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, ready := auth.GetSessionToken(session)
+	tokens, ready := auth.GetSessionTokens(session)
 
 	w.Header().Set("Content-Type", "application/json")
 
@@ -144,7 +281,27 @@ func (h *UserHandler) PollCLISession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Token is ready - delete session and return token
+	// Tokens are ready - delete session and return them
 	auth.DeleteSession(session)
-	json.NewEncoder(w).Encode(map[string]string{"token": token})
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// Refresh renews the access token of the TUI. The refresh token is
+// replaced, the old one can't be used again.
+func (h *UserHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req model.RefreshRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.RefreshToken == "" {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := h.sessionService.Refresh(req.RefreshToken, auth.ClientIP(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
 }
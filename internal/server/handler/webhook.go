@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+// maxWebhookBodySize limits push payloads (GitHub caps them at 25MB, real pushes are far smaller)
+const maxWebhookBodySize = 5 << 20
+
+// zeroCommit is sent as "after" when a branch is deleted
+const zeroCommit = "0000000000000000000000000000000000000000"
+
+type WebhookHandler struct {
+	service       *service.PodWebhookService
+	podService    *service.PodService
+	deployService *service.DeployService
+	memberService service.MemberServiceInterface
+	auditService  service.AuditServiceInterface
+}
+
+func NewWebhookHandler(service *service.PodWebhookService, podService *service.PodService, deployService *service.DeployService, memberService *service.MemberService, auditService *service.AuditService) *WebhookHandler {
+	return &WebhookHandler{
+		service:       service,
+		podService:    podService,
+		deployService: deployService,
+		memberService: memberService,
+		auditService:  auditService,
+	}
+}
+
+// pushEvent is the subset of a push payload shared by GitHub, GitLab and Gitea
+type pushEvent struct {
+	Ref   string `json:"ref"`
+	After string `json:"after"`
+}
+
+// webhookURL builds the public webhook URL of a pod from the incoming request
+func webhookURL(r *http.Request, podID string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/api/webhooks/%s", scheme, r.Host, podID)
+}
+
+// Webhook returns the webhook of a pod, 404 until RegenerateSecret enabled it
+func (h *WebhookHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	webhook, err := h.service.Webhook(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	webhook.URL = webhookURL(r, podID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// RegenerateSecret enables the webhook of a pod or replaces its secret
+func (h *WebhookHandler) RegenerateSecret(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("id")
+	if !authorizePod(w, r, h.memberService, podID, model.ProjectRoleDeveloper) {
+		return
+	}
+
+	webhook, err := h.service.RegenerateSecret(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	audit(r, h.auditService, h.auditService.PodEvent("webhook.regenerate", podID), nil, nil)
+	webhook.URL = webhookURL(r, podID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// Receive handles push events from GitHub, GitLab and Gitea.
+// Public endpoint - requests are authenticated by the webhook secret.
+func (h *WebhookHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	podID := r.PathValue("podId")
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	webhook, err := h.service.Webhook(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	provider, event, err := verifyWebhook(r.Header, body, webhook.Secret)
+	if err != nil {
+		slog.Warn("webhook rejected", "podID", podID, "error", err)
+		writeError(w, err)
+		return
+	}
+
+	if !isPushEvent(provider, event) {
+		// ping, tag pushes, issues, ... - acknowledge without deploying
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ignored", "reason": "not a push event"})
+		return
+	}
+
+	var push pushEvent
+	err = json.Unmarshal(body, &push)
+	if err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	pod, err := h.podService.Pod(podID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if pod.SourceType == model.PodSourceImage {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ignored", "reason": "pod deploys from an image"})
+		return
+	}
+
+	branch, ok := strings.CutPrefix(push.Ref, "refs/heads/")
+	if !ok || branch != pod.Branch || push.After == zeroCommit {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ignored", "reason": "branch does not match"})
+		return
+	}
+
+	slog.Info("webhook deploy triggered", "podID", podID, "provider", provider, "branch", branch, "commit", push.After)
+
+	// Deploy exactly the pushed commit. Pushes during a deploy are queued,
+	// the latest one is deployed once the running deploy finishes.
+	triggeredBy := "webhook (" + provider + ")"
+	req := model.DeployRequest{Ref: push.After}
+	deployment, err := h.deployService.StartDeploy(podID, req, triggeredBy)
+	if errors.Is(err, errs.ErrConflict) && h.deployService.QueueDeploy(podID, req, triggeredBy) {
+		queueEvent := podEvent("pod.deploy_queue", pod)
+		queueEvent.ActorEmail = triggeredBy
+		audit(r, h.auditService, queueEvent, nil, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"status": "queued"})
+		return
+	}
+	if errors.Is(err, errs.ErrConflict) {
+		// The running deploy finished meanwhile
+		deployment, err = h.deployService.StartDeploy(podID, req, triggeredBy)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	deployEvent := podEvent("pod.deploy", pod)
+	deployEvent.ActorEmail = triggeredBy
+	audit(r, h.auditService, deployEvent, nil, deployment)
+
+	go func() {
+		err := h.deployService.RunDeploy(context.Background(), deployment)
+		if err != nil {
+			slog.Error("deploy failed", "podID", podID, "error", err)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"status": "building", "deployment_id": deployment.ID})
+}
+
+// verifyWebhook detects the provider from its headers and checks the signature or token.
+// Returns the provider name and event type.
+func verifyWebhook(header http.Header, body []byte, secret string) (string, string, error) {
+	// Gitea also sends X-GitHub-Event for compatibility, so check it first
+	if event := header.Get("X-Gitea-Event"); event != "" {
+		if !validHMAC(body, secret, header.Get("X-Gitea-Signature")) {
+			return "", "", fmt.Errorf("invalid gitea signature: %w", errs.ErrUnauthorized)
+		}
+		return "gitea", event, nil
+	}
+
+	if event := header.Get("X-GitHub-Event"); event != "" {
+		signature, _ := strings.CutPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+		if !validHMAC(body, secret, signature) {
+			return "", "", fmt.Errorf("invalid github signature: %w", errs.ErrUnauthorized)
+		}
+		return "github", event, nil
+	}
+
+	if event := header.Get("X-Gitlab-Event"); event != "" {
+		// GitLab sends the secret as plain token instead of signing the payload
+		token := header.Get("X-Gitlab-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return "", "", fmt.Errorf("invalid gitlab token: %w", errs.ErrUnauthorized)
+		}
+		return "gitlab", event, nil
+	}
+
+	return "", "", fmt.Errorf("unknown webhook provider: %w", errs.ErrInvalidInput)
+}
+
+// validHMAC checks a hex encoded HMAC-SHA256 signature of the body
+func validHMAC(body []byte, secret, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func isPushEvent(provider, event string) bool {
+	switch provider {
+	case "gitlab":
+		return event == "Push Hook"
+	default:
+		return event == "push"
+	}
+}
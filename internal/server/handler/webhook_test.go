@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+)
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	secret := "s3cret"
+
+	tests := []struct {
+		name     string
+		header   map[string]string
+		provider string
+		err      error
+	}{
+		{"github", map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(body, secret)}, "github", nil},
+		{"github without prefix", map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": sign(body, secret)}, "github", nil},
+		{"github wrong secret", map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(body, "other")}, "", errs.ErrUnauthorized},
+		{"github missing signature", map[string]string{"X-GitHub-Event": "push"}, "", errs.ErrUnauthorized},
+		{"github invalid hex", map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=zz"}, "", errs.ErrUnauthorized},
+		{"gitea", map[string]string{"X-Gitea-Event": "push", "X-GitHub-Event": "push", "X-Gitea-Signature": sign(body, secret)}, "gitea", nil},
+		{"gitea with only a github signature", map[string]string{"X-Gitea-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(body, secret)}, "", errs.ErrUnauthorized},
+		{"gitea signature of another body", map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": sign([]byte("{}"), secret)}, "", errs.ErrUnauthorized},
+		{"gitlab", map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": secret}, "gitlab", nil},
+		{"gitlab wrong token", map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "s3cre"}, "", errs.ErrUnauthorized},
+		{"gitlab missing token", map[string]string{"X-Gitlab-Event": "Push Hook"}, "", errs.ErrUnauthorized},
+		{"unknown provider", map[string]string{"X-Hub-Signature-256": "sha256=" + sign(body, secret)}, "", errs.ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		for k, v := range tt.header {
+			header.Set(k, v)
+		}
+		provider, _, err := verifyWebhook(header, body, secret)
+		if provider != tt.provider || !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+			t.Errorf("%s: got %q, %v, want %q, %v", tt.name, provider, err, tt.provider, tt.err)
+		}
+	}
+}
+
+func TestIsPushEvent(t *testing.T) {
+	tests := []struct {
+		provider, event string
+		want            bool
+	}{
+		{"github", "push", true},
+		{"github", "ping", false},
+		{"gitea", "push", true},
+		{"gitlab", "Push Hook", true},
+		{"gitlab", "push", false},
+		{"gitlab", "Tag Push Hook", false},
+	}
+	for _, tt := range tests {
+		if got := isPushEvent(tt.provider, tt.event); got != tt.want {
+			t.Errorf("isPushEvent(%q, %q) = %v, want %v", tt.provider, tt.event, got, tt.want)
+		}
+	}
+}
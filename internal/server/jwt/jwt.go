@@ -9,10 +9,14 @@ import (
 
 var JwtSecret = []byte(os.Getenv("JWT_SECRET"))
 
-func CreateToken(userID string) (string, error) {
+// TokenTTL is short, clients renew access tokens with the refresh token of their session.
+const TokenTTL = 15 * time.Minute
+
+func CreateToken(userID, sessionID string) (string, error) {
 	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwtlib.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+		"user_id":    userID,
+		"session_id": sessionID,
+		"exp":        time.Now().Add(TokenTTL).Unix(),
 	})
 	return token.SignedString(JwtSecret)
 }
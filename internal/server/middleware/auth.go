@@ -2,21 +2,46 @@ package middleware
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/deeploy-sh/deeploy/internal/server/auth"
 	"github.com/deeploy-sh/deeploy/internal/server/cookie"
 	"github.com/deeploy-sh/deeploy/internal/server/jwt"
 	"github.com/deeploy-sh/deeploy/internal/server/service"
 	"github.com/deeploy-sh/deeploy/internal/server/ui/pages"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
 type AuthMiddleWare struct {
-	userService service.UserServiceInterface
+	userService     service.UserServiceInterface
+	apiTokenService service.APITokenServiceInterface
+	sessionService  service.SessionServiceInterface
 }
 
-func NewAuthMiddleware(userService service.UserServiceInterface) *AuthMiddleWare {
-	return &AuthMiddleWare{userService: userService}
+func NewAuthMiddleware(userService service.UserServiceInterface, apiTokenService service.APITokenServiceInterface, sessionService service.SessionServiceInterface) *AuthMiddleWare {
+	return &AuthMiddleWare{userService: userService, apiTokenService: apiTokenService, sessionService: sessionService}
+}
+
+// deployPatterns are the routes an API token with deploy scope may call besides GET requests.
+var deployPatterns = map[string]bool{
+	"POST /api/pods/{id}/deploy":                              true,
+	"POST /api/pods/{id}/stop":                                true,
+	"POST /api/pods/{id}/restart":                             true,
+	"POST /api/pods/{id}/deployments/{deploymentId}/rollback": true,
+}
+
+// requiredScope returns the API token scope needed for a request.
+func requiredScope(r *http.Request) string {
+	switch {
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return model.APITokenScopeRead
+	case deployPatterns[r.Pattern]:
+		return model.APITokenScopeDeploy
+	default:
+		return model.APITokenScopeAdmin
+	}
 }
 
 func getToken(r *http.Request) string {
@@ -38,64 +63,141 @@ func (m *AuthMiddleWare) Auth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		t, claims, err := jwt.ValidateToken(token)
-		if err != nil || !t.Valid {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		userID, ok := claims["user_id"].(string)
-		if !ok || userID == "" {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		// API tokens for scripts and CI
+		if strings.HasPrefix(token, model.APITokenPrefix) {
+			apiToken, err := m.apiTokenService.Authenticate(token)
+			if err != nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if !service.ScopeAllows(apiToken.Scope, requiredScope(r)) {
+				http.Error(w, "Token scope does not allow this request", http.StatusForbidden)
+				return
+			}
+			user, err := m.userService.GetUserByID(apiToken.UserID)
+			if err != nil || user == nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			ctx := context.WithValue(r.Context(), "user", user)
+			ctx = context.WithValue(ctx, "api_token", apiToken)
+			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
 
-		user, err := m.userService.GetUserByID(userID)
-		if err != nil || user == nil {
+		user, session, ok := m.authenticate(w, r, token)
+		if !ok {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		ctx := context.WithValue(r.Context(), "user", user)
+		ctx = context.WithValue(ctx, "session", session)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	}
 }
 
-func RequireAuth(next http.HandlerFunc, redirectTo ...string) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		isCLI := r.URL.Query().Get("cli") == "true"
+// authenticate returns the user and session of an access token. Browsers
+// send an expired access token with their refresh token, both cookies are
+// renewed then.
+func (m *AuthMiddleWare) authenticate(w http.ResponseWriter, r *http.Request, token string) (*model.User, *model.Session, bool) {
+	t, claims, err := jwt.ValidateToken(token)
+	if err != nil || !t.Valid {
+		refreshToken := cookie.GetRefreshTokenFromCookie(r)
+		if refreshToken == "" || token != cookie.GetTokenFromCookie(r) {
+			return nil, nil, false
+		}
+		tokens, err := m.sessionService.Refresh(refreshToken, auth.ClientIP(r))
+		if err != nil {
+			cookie.ClearCookie(w)
+			return nil, nil, false
+		}
+		cookie.SetCookie(w, tokens)
+		_, claims, err = jwt.ValidateToken(tokens.Token)
+		if err != nil {
+			return nil, nil, false
+		}
+	}
 
-		token := getToken(r)
-		if token == "" {
-			path := "/"
-			if len(redirectTo) > 0 {
-				path = redirectTo[0]
-			}
+	userID, _ := claims["user_id"].(string)
+	sessionID, _ := claims["session_id"].(string)
+	if userID == "" || sessionID == "" {
+		return nil, nil, false
+	}
 
-			// CLI Auth need CLI params
-			if isCLI {
-				if r.URL.RawQuery != "" {
-					path += "?" + r.URL.RawQuery // Behalte cli=true&port=xyz
-				}
-			}
+	session, err := m.sessionService.Authenticate(sessionID, userID)
+	if err != nil {
+		return nil, nil, false
+	}
 
-			http.Redirect(w, r, path, http.StatusSeeOther)
+	user, err := m.userService.GetUserByID(userID)
+	if err != nil || user == nil {
+		return nil, nil, false
+	}
+
+	return user, session, true
+}
+
+// Admin is Auth for server wide endpoints, limited to the server admin.
+func (m *AuthMiddleWare) Admin(next http.HandlerFunc) http.HandlerFunc {
+	return m.Auth(func(w http.ResponseWriter, r *http.Request) {
+		if !auth.GetUser(r.Context()).IsAdmin {
+			http.Error(w, "Only the server admin can do this", http.StatusForbidden)
+			return
+		}
+		// Server backups hold every secret, reading them needs more than read scope
+		apiToken := auth.GetAPIToken(r.Context())
+		if apiToken != nil && apiToken.Scope != model.APITokenScopeAdmin {
+			http.Error(w, "Token scope does not allow this request", http.StatusForbidden)
 			return
 		}
 		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireAuth is Auth for web pages, browsers without a session are sent
+// to the login and come back afterwards.
+func (m *AuthMiddleWare) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := cookie.GetTokenFromCookie(r)
+		if token != "" {
+			user, session, ok := m.authenticate(w, r, token)
+			if ok {
+				ctx := context.WithValue(r.Context(), "user", user)
+				ctx = context.WithValue(ctx, "session", session)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+
+		cookie.SetRedirectCookie(w, r.URL.Path)
+		http.Redirect(w, r, "/auth", http.StatusSeeOther)
 	}
 }
 
-func RequireGuest(next http.HandlerFunc, redirectTo ...string) http.HandlerFunc {
+func (m *AuthMiddleWare) RequireGuest(next http.HandlerFunc, redirectTo ...string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		isCLI := r.URL.Query().Get("cli") == "true"
 		session := r.URL.Query().Get("session")
 
-		token := getToken(r)
-		if token != "" {
-			// CLI flow - store token for polling session
+		// Invite links always show the auth page, to sign in the invited account
+		token := cookie.GetTokenFromCookie(r)
+		if token != "" && r.URL.Query().Get("invite") == "" {
+			user, _, ok := m.authenticate(w, r, token)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// CLI flow - the TUI gets a session of its own
 			if isCLI && session != "" {
-				auth.SetSessionToken(session, token)
+				tokens, err := m.sessionService.Create(user.ID, auth.ClientName(r, true), auth.ClientIP(r))
+				if err != nil {
+					slog.Error("failed to create session", "error", err)
+					next.ServeHTTP(w, r)
+					return
+				}
+				auth.SetSessionTokens(session, tokens)
 				pages.CliAuthSuccess().Render(r.Context(), w)
 				return
 			}
@@ -105,6 +207,9 @@ func RequireGuest(next http.HandlerFunc, redirectTo ...string) http.HandlerFunc
 			if len(redirectTo) > 0 {
 				path = redirectTo[0]
 			}
+			if redirect := cookie.PopRedirect(w, r); redirect != "" {
+				path = redirect
+			}
 			http.Redirect(w, r, path, http.StatusSeeOther)
 			return
 		}
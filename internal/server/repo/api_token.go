@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type APITokenRepoInterface interface {
+	Create(token *model.APIToken) error
+	APIToken(id string) (*model.APIToken, error)
+	APITokenByHash(tokenHash string) (*model.APIToken, error)
+	APITokensByUser(userID string) ([]model.APIToken, error)
+	MarkUsed(id string, usedAt time.Time) error
+	Delete(id string) error
+}
+
+type APITokenRepo struct {
+	db *sqlx.DB
+}
+
+func NewAPITokenRepo(db *sqlx.DB) *APITokenRepo {
+	return &APITokenRepo{db: db}
+}
+
+func (r *APITokenRepo) Create(token *model.APIToken) error {
+	query := `INSERT INTO api_tokens (id, user_id, name, scope, prefix, token_hash, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(query, token.ID, token.UserID, token.Name, token.Scope, token.Prefix, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *APITokenRepo) APIToken(id string) (*model.APIToken, error) {
+	token := &model.APIToken{}
+	query := `SELECT id, user_id, name, scope, prefix, token_hash, expires_at, last_used_at, created_at FROM api_tokens WHERE id = $1`
+
+	err := r.db.Get(token, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("api token %s: %w", id, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *APITokenRepo) APITokenByHash(tokenHash string) (*model.APIToken, error) {
+	token := &model.APIToken{}
+	query := `SELECT id, user_id, name, scope, prefix, token_hash, expires_at, last_used_at, created_at FROM api_tokens WHERE token_hash = $1`
+
+	err := r.db.Get(token, query, tokenHash)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("api token: %w", errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *APITokenRepo) APITokensByUser(userID string) ([]model.APIToken, error) {
+	tokens := []model.APIToken{}
+	query := `SELECT id, user_id, name, scope, prefix, token_hash, expires_at, last_used_at, created_at FROM api_tokens WHERE user_id = $1 ORDER BY created_at`
+
+	err := r.db.Select(&tokens, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// MarkUsed stores when a token was used last.
+func (r *APITokenRepo) MarkUsed(id string, usedAt time.Time) error {
+	query := `UPDATE api_tokens SET last_used_at = $1 WHERE id = $2`
+
+	_, err := r.db.Exec(query, usedAt, id)
+	return err
+}
+
+func (r *APITokenRepo) Delete(id string) error {
+	query := `DELETE FROM api_tokens WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("api token %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
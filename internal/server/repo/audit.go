@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type AuditRepoInterface interface {
+	Create(event *model.AuditEvent) error
+	Events(filter model.AuditFilter, visibleTo string) ([]model.AuditEvent, error)
+}
+
+type AuditRepo struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepo(db *sqlx.DB) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+func (r *AuditRepo) Create(event *model.AuditEvent) error {
+	query := `INSERT INTO audit_events (id, actor_id, actor_email, action, target_type, target_id, target_name, project_id, changes, ip, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.Exec(query, event.ID, event.ActorID, event.ActorEmail, event.Action, event.TargetType, event.TargetID, event.TargetName, event.ProjectID, event.Changes, event.IP, event.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Events returns the newest events matching the filter. With visibleTo set,
+// only events of that user and of projects they are a member of are returned.
+func (r *AuditRepo) Events(filter model.AuditFilter, visibleTo string) ([]model.AuditEvent, error) {
+	var conditions []string
+	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if visibleTo != "" {
+		args = append(args, visibleTo)
+		conditions = append(conditions, fmt.Sprintf(`(actor_id = $%d OR project_id IN (SELECT project_id FROM project_members WHERE user_id = $%[1]d))`, len(args)))
+	}
+	if prefix, ok := strings.CutSuffix(filter.Action, "."); ok {
+		add(`action LIKE $%d`, prefix+".%")
+	} else if filter.Action != "" {
+		add(`action = $%d`, filter.Action)
+	}
+	if filter.Actor != "" {
+		args = append(args, filter.Actor)
+		conditions = append(conditions, fmt.Sprintf(`(actor_id = $%d OR actor_email = $%[1]d)`, len(args)))
+	}
+	if filter.TargetType != "" {
+		add(`target_type = $%d`, filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		add(`target_id = $%d`, filter.TargetID)
+	}
+	if filter.ProjectID != "" {
+		add(`project_id = $%d`, filter.ProjectID)
+	}
+	if !filter.Since.IsZero() {
+		add(`created_at >= $%d`, filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		add(`created_at < $%d`, filter.Until)
+	}
+
+	query := `SELECT id, actor_id, actor_email, action, target_type, target_id, target_name, project_id, changes, ip, created_at FROM audit_events`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id LIMIT $%d`, len(args))
+
+	events := []model.AuditEvent{}
+	err := r.db.Select(&events, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
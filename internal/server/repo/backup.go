@@ -0,0 +1,195 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type BackupRepoInterface interface {
+	CreateSchedule(schedule *model.BackupSchedule) error
+	Schedule(targetType, targetID string) (*model.BackupSchedule, error)
+	EnabledSchedules() ([]model.BackupSchedule, error)
+	UpdateSchedule(schedule model.BackupSchedule) error
+	MarkScheduleRun(id string) error
+	Create(backup *model.Backup) error
+	Backup(id string) (*model.Backup, error)
+	BackupsByTarget(targetType, targetID string) ([]model.Backup, error)
+	Finish(id, status string, size int64, errMsg *string) error
+	FailRunning() error
+	Delete(id string) error
+}
+
+type BackupRepo struct {
+	db *sqlx.DB
+}
+
+func NewBackupRepo(db *sqlx.DB) *BackupRepo {
+	return &BackupRepo{db: db}
+}
+
+const backupScheduleColumns = `id, user_id, target_type, target_id, interval_hours, retention, destination, enabled, last_run_at, created_at, updated_at`
+
+const backupColumns = `id, user_id, target_type, target_id, destination, storage_key, size, status, error, started_at, finished_at`
+
+func (r *BackupRepo) CreateSchedule(schedule *model.BackupSchedule) error {
+	query := `INSERT INTO backup_schedules (id, user_id, target_type, target_id, interval_hours, retention, destination, enabled) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.Exec(query, schedule.ID, schedule.UserID, schedule.TargetType, schedule.TargetID, schedule.IntervalHours, schedule.Retention, schedule.Destination, schedule.Enabled)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BackupRepo) Schedule(targetType, targetID string) (*model.BackupSchedule, error) {
+	schedule := &model.BackupSchedule{}
+	query := `SELECT ` + backupScheduleColumns + ` FROM backup_schedules WHERE target_type = $1 AND target_id = $2`
+
+	err := r.db.Get(schedule, query, targetType, targetID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("backup schedule of %s %s: %w", targetType, targetID, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (r *BackupRepo) EnabledSchedules() ([]model.BackupSchedule, error) {
+	schedules := []model.BackupSchedule{}
+	query := `SELECT ` + backupScheduleColumns + ` FROM backup_schedules WHERE enabled = true`
+
+	err := r.db.Select(&schedules, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (r *BackupRepo) UpdateSchedule(schedule model.BackupSchedule) error {
+	query := `UPDATE backup_schedules SET interval_hours = $1, retention = $2, destination = $3, enabled = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $5`
+
+	result, err := r.db.Exec(query, schedule.IntervalHours, schedule.Retention, schedule.Destination, schedule.Enabled, schedule.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("backup schedule %s: %w", schedule.ID, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// MarkScheduleRun stamps last_run_at, the next run is due interval_hours later.
+func (r *BackupRepo) MarkScheduleRun(id string) error {
+	query := `UPDATE backup_schedules SET last_run_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BackupRepo) Create(backup *model.Backup) error {
+	query := `INSERT INTO backups (id, user_id, target_type, target_id, destination, storage_key, status) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(query, backup.ID, backup.UserID, backup.TargetType, backup.TargetID, backup.Destination, backup.StorageKey, backup.Status)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BackupRepo) Backup(id string) (*model.Backup, error) {
+	backup := &model.Backup{}
+	query := `SELECT ` + backupColumns + ` FROM backups WHERE id = $1`
+
+	err := r.db.Get(backup, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("backup %s: %w", id, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+// BackupsByTarget returns all backups of a database or volume, newest first.
+func (r *BackupRepo) BackupsByTarget(targetType, targetID string) ([]model.Backup, error) {
+	backups := []model.Backup{}
+	query := `SELECT ` + backupColumns + ` FROM backups WHERE target_type = $1 AND target_id = $2 ORDER BY started_at DESC, id`
+
+	err := r.db.Select(&backups, query, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+// Finish sets the final status and size of a backup and stamps finished_at.
+func (r *BackupRepo) Finish(id, status string, size int64, errMsg *string) error {
+	query := `UPDATE backups SET status = $1, size = $2, error = $3, finished_at = CURRENT_TIMESTAMP WHERE id = $4`
+
+	result, err := r.db.Exec(query, status, size, errMsg, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("backup %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// FailRunning marks backups interrupted by a server restart as failed.
+func (r *BackupRepo) FailRunning() error {
+	query := `UPDATE backups SET status = $1, error = $2, finished_at = CURRENT_TIMESTAMP WHERE status = $3`
+
+	_, err := r.db.Exec(query, model.BackupStatusFailed, "interrupted by server restart", model.BackupStatusRunning)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BackupRepo) Delete(id string) error {
+	query := `DELETE FROM backups WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("backup %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type CertificateRepoInterface interface {
+	Create(certificate *model.Certificate) error
+	CertificateByDomain(domainID string) (*model.Certificate, error)
+	CertificatesByPod(podID string) ([]model.Certificate, error)
+	Certificates() ([]model.Certificate, error)
+	DeleteByDomain(domainID string) error
+}
+
+type CertificateRepo struct {
+	db *sqlx.DB
+}
+
+func NewCertificateRepo(db *sqlx.DB) *CertificateRepo {
+	return &CertificateRepo{db: db}
+}
+
+func (r *CertificateRepo) Create(certificate *model.Certificate) error {
+	query := `INSERT INTO certificates (id, pod_domain_id, certificate, private_key, issuer, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(query, certificate.ID, certificate.PodDomainID, certificate.Certificate, certificate.PrivateKey, certificate.Issuer, certificate.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CertificateRepo) CertificateByDomain(domainID string) (*model.Certificate, error) {
+	certificate := &model.Certificate{}
+	query := `SELECT id, pod_domain_id, certificate, private_key, issuer, expires_at, created_at, updated_at FROM certificates WHERE pod_domain_id = $1`
+
+	err := r.db.Get(certificate, query, domainID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("certificate of domain %s: %w", domainID, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+func (r *CertificateRepo) CertificatesByPod(podID string) ([]model.Certificate, error) {
+	certificates := []model.Certificate{}
+	query := `SELECT c.id, c.pod_domain_id, c.certificate, c.private_key, c.issuer, c.expires_at, c.created_at, c.updated_at
+		FROM certificates c JOIN pod_domains d ON d.id = c.pod_domain_id WHERE d.pod_id = $1`
+
+	err := r.db.Select(&certificates, query, podID)
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+func (r *CertificateRepo) Certificates() ([]model.Certificate, error) {
+	certificates := []model.Certificate{}
+	query := `SELECT id, pod_domain_id, certificate, private_key, issuer, expires_at, created_at, updated_at FROM certificates ORDER BY created_at`
+
+	err := r.db.Select(&certificates, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+func (r *CertificateRepo) DeleteByDomain(domainID string) error {
+	query := `DELETE FROM certificates WHERE pod_domain_id = $1`
+
+	_, err := r.db.Exec(query, domainID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type DatabaseRepoInterface interface {
+	Create(database *model.Database) error
+	Database(id string) (*model.Database, error)
+	DatabasesByProject(id string) ([]model.Database, error)
+	DatabasesByUser(id string) ([]model.Database, error)
+	CountByProject(id string) (int, error)
+	PendingDatabases() ([]model.Database, error)
+	UpdateContainer(id string, containerID *string, status string) error
+	Delete(id string) error
+
+	Link(link model.PodDatabase) error
+	LinksByPod(podID string) ([]model.PodDatabase, error)
+	CountLinks(databaseID string) (int, error)
+	Unlink(podID, databaseID string) error
+}
+
+type DatabaseRepo struct {
+	db *sqlx.DB
+}
+
+func NewDatabaseRepo(db *sqlx.DB) *DatabaseRepo {
+	return &DatabaseRepo{db: db}
+}
+
+const databaseColumns = `id, user_id, project_id, title, engine, version, username, password, database_name, container_id, status, created_at, updated_at`
+
+func (r *DatabaseRepo) Create(database *model.Database) error {
+	query := `INSERT INTO databases (id, user_id, project_id, title, engine, version, username, password, database_name, status) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.Exec(query, database.ID, database.UserID, database.ProjectID, database.Title, database.Engine, database.Version, database.Username, database.Password, database.DatabaseName, database.Status)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DatabaseRepo) Database(id string) (*model.Database, error) {
+	database := &model.Database{}
+	query := `SELECT ` + databaseColumns + ` FROM databases WHERE id = $1`
+
+	err := r.db.Get(database, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("database %s: %w", id, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func (r *DatabaseRepo) DatabasesByProject(id string) ([]model.Database, error) {
+	databases := []model.Database{}
+	query := `SELECT ` + databaseColumns + ` FROM databases WHERE project_id = $1 ORDER BY title`
+
+	err := r.db.Select(&databases, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+// DatabasesByUser returns the databases of all projects a user is a member of.
+func (r *DatabaseRepo) DatabasesByUser(id string) ([]model.Database, error) {
+	databases := []model.Database{}
+	query := `SELECT ` + databaseColumns + ` FROM databases WHERE project_id IN (SELECT project_id FROM project_members WHERE user_id = $1) ORDER BY title`
+
+	err := r.db.Select(&databases, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+func (r *DatabaseRepo) CountByProject(id string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM databases WHERE project_id = $1`
+
+	err := r.db.Get(&count, query, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// PendingDatabases returns databases waiting for their container.
+func (r *DatabaseRepo) PendingDatabases() ([]model.Database, error) {
+	databases := []model.Database{}
+	query := `SELECT ` + databaseColumns + ` FROM databases WHERE status = $1 AND container_id IS NULL`
+
+	err := r.db.Select(&databases, query, model.DatabaseStatusProvisioning)
+	if err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+func (r *DatabaseRepo) UpdateContainer(id string, containerID *string, status string) error {
+	query := `UPDATE databases SET container_id = $1, status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`
+
+	result, err := r.db.Exec(query, containerID, status, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("database %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *DatabaseRepo) Delete(id string) error {
+	query := `DELETE FROM databases WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("database %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *DatabaseRepo) Link(link model.PodDatabase) error {
+	query := `INSERT INTO pod_databases (pod_id, database_id, env_var) VALUES ($1, $2, $3)`
+
+	_, err := r.db.Exec(query, link.PodID, link.DatabaseID, link.EnvVar)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DatabaseRepo) LinksByPod(podID string) ([]model.PodDatabase, error) {
+	links := []model.PodDatabase{}
+	query := `SELECT pod_id, database_id, env_var, created_at FROM pod_databases WHERE pod_id = $1 ORDER BY env_var`
+
+	err := r.db.Select(&links, query, podID)
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *DatabaseRepo) CountLinks(databaseID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM pod_databases WHERE database_id = $1`
+
+	err := r.db.Get(&count, query, databaseID)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *DatabaseRepo) Unlink(podID, databaseID string) error {
+	query := `DELETE FROM pod_databases WHERE pod_id = $1 AND database_id = $2`
+
+	result, err := r.db.Exec(query, podID, databaseID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("database link %s: %w", databaseID, errs.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type DeploymentRepoInterface interface {
+	Create(deployment *model.Deployment) error
+	Deployment(id string) (*model.Deployment, error)
+	DeploymentsByPod(podID string) ([]model.Deployment, error)
+	UpdateCommit(id, commitSHA, commitMessage string) error
+	Finish(id, status string) error
+}
+
+type DeploymentRepo struct {
+	db *sqlx.DB
+}
+
+func NewDeploymentRepo(db *sqlx.DB) *DeploymentRepo {
+	return &DeploymentRepo{db: db}
+}
+
+const deploymentColumns = `id, pod_id, ref, commit_sha, commit_message, branch, image_tag, status, triggered_by, rollback_of, no_cache, started_at, finished_at, created_at, updated_at`
+
+func (r *DeploymentRepo) Create(deployment *model.Deployment) error {
+	query := `INSERT INTO deployments (id, pod_id, ref, commit_sha, commit_message, branch, image_tag, status, triggered_by, rollback_of, no_cache) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.Exec(query, deployment.ID, deployment.PodID, deployment.Ref, deployment.CommitSHA, deployment.CommitMessage, deployment.Branch, deployment.ImageTag, deployment.Status, deployment.TriggeredBy, deployment.RollbackOf, deployment.NoCache)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DeploymentRepo) Deployment(id string) (*model.Deployment, error) {
+	deployment := &model.Deployment{}
+	query := `SELECT ` + deploymentColumns + ` FROM deployments WHERE id = $1`
+
+	err := r.db.Get(deployment, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("deployment %s: %w", id, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
+// DeploymentsByPod returns all deployments of a pod, newest first.
+func (r *DeploymentRepo) DeploymentsByPod(podID string) ([]model.Deployment, error) {
+	deployments := []model.Deployment{}
+	query := `SELECT ` + deploymentColumns + ` FROM deployments WHERE pod_id = $1 ORDER BY started_at DESC, created_at DESC`
+
+	err := r.db.Select(&deployments, query, podID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
+}
+
+// UpdateCommit stores the resolved commit once it is known (after clone).
+func (r *DeploymentRepo) UpdateCommit(id, commitSHA, commitMessage string) error {
+	query := `UPDATE deployments SET commit_sha = $1, commit_message = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`
+
+	result, err := r.db.Exec(query, commitSHA, commitMessage, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("deployment %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Finish sets the final status of a deployment and stamps finished_at.
+func (r *DeploymentRepo) Finish(id, status string) error {
+	query := `UPDATE deployments SET status = $1, finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := r.db.Exec(query, status, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("deployment %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
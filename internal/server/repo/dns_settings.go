@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type DNSSettingsRepoInterface interface {
+	Get() (*model.DNSSettings, error)
+	Save(settings *model.DNSSettings) error
+	Delete() error
+}
+
+type DNSSettingsRepo struct {
+	db *sqlx.DB
+}
+
+func NewDNSSettingsRepo(db *sqlx.DB) *DNSSettingsRepo {
+	return &DNSSettingsRepo{db: db}
+}
+
+func (r *DNSSettingsRepo) Get() (*model.DNSSettings, error) {
+	settings := &model.DNSSettings{}
+	query := `SELECT provider, credentials, updated_at FROM dns_settings WHERE id = 1`
+
+	err := r.db.Get(settings, query)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("dns settings: %w", errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// Save creates or replaces the settings.
+func (r *DNSSettingsRepo) Save(settings *model.DNSSettings) error {
+	query := `
+		INSERT INTO dns_settings (id, provider, credentials, updated_at)
+		VALUES (1, $1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (id) DO UPDATE SET provider = $1, credentials = $2, updated_at = CURRENT_TIMESTAMP
+	`
+	_, err := r.db.Exec(query, settings.Provider, settings.CredentialsData)
+	return err
+}
+
+func (r *DNSSettingsRepo) Delete() error {
+	query := `DELETE FROM dns_settings WHERE id = 1`
+	_, err := r.db.Exec(query)
+	return err
+}
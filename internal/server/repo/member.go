@@ -0,0 +1,191 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type MemberRepoInterface interface {
+	Role(projectID, userID string) (string, error)
+	Members(projectID string) ([]model.ProjectMember, error)
+	AddMember(member model.ProjectMember) error
+	UpdateRole(projectID, userID, role string) error
+	RemoveMember(projectID, userID string) error
+	CountOwners(projectID string) (int, error)
+
+	CreateInvite(invite *model.ProjectInvite) error
+	Invites(projectID string) ([]model.ProjectInvite, error)
+	InviteByTokenHash(tokenHash string) (*model.ProjectInvite, error)
+	DeleteInvite(id string) error
+}
+
+type MemberRepo struct {
+	db *sqlx.DB
+}
+
+func NewMemberRepo(db *sqlx.DB) *MemberRepo {
+	return &MemberRepo{db: db}
+}
+
+// Role returns the role of a user in a project, ErrNotFound if the user is no member.
+func (r *MemberRepo) Role(projectID, userID string) (string, error) {
+	var role string
+	query := `SELECT role FROM project_members WHERE project_id = $1 AND user_id = $2`
+
+	err := r.db.Get(&role, query, projectID, userID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("project %s: %w", projectID, errs.ErrNotFound)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (r *MemberRepo) Members(projectID string) ([]model.ProjectMember, error) {
+	members := []model.ProjectMember{}
+	query := `SELECT m.project_id, m.user_id, u.email, m.role, m.created_at
+		FROM project_members m JOIN users u ON u.id = m.user_id
+		WHERE m.project_id = $1 ORDER BY u.email`
+
+	err := r.db.Select(&members, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *MemberRepo) AddMember(member model.ProjectMember) error {
+	query := `INSERT INTO project_members (project_id, user_id, role) VALUES ($1, $2, $3)`
+
+	_, err := r.db.Exec(query, member.ProjectID, member.UserID, member.Role)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *MemberRepo) UpdateRole(projectID, userID, role string) error {
+	query := `UPDATE project_members SET role = $1 WHERE project_id = $2 AND user_id = $3`
+
+	result, err := r.db.Exec(query, role, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("member %s: %w", userID, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *MemberRepo) RemoveMember(projectID, userID string) error {
+	query := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("member %s: %w", userID, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *MemberRepo) CountOwners(projectID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM project_members WHERE project_id = $1 AND role = $2`
+
+	err := r.db.Get(&count, query, projectID, model.ProjectRoleOwner)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CreateInvite stores an invite, replacing a pending invite of the same email.
+func (r *MemberRepo) CreateInvite(invite *model.ProjectInvite) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM project_invites WHERE project_id = $1 AND email = $2`, invite.ProjectID, invite.Email)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO project_invites (id, project_id, email, role, token_hash, invited_by, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err = tx.Exec(query, invite.ID, invite.ProjectID, invite.Email, invite.Role, invite.TokenHash, invite.InvitedBy, invite.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *MemberRepo) Invites(projectID string) ([]model.ProjectInvite, error) {
+	invites := []model.ProjectInvite{}
+	query := `SELECT id, project_id, email, role, token_hash, invited_by, expires_at, created_at FROM project_invites WHERE project_id = $1 ORDER BY email`
+
+	err := r.db.Select(&invites, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (r *MemberRepo) InviteByTokenHash(tokenHash string) (*model.ProjectInvite, error) {
+	invite := &model.ProjectInvite{}
+	query := `SELECT id, project_id, email, role, token_hash, invited_by, expires_at, created_at FROM project_invites WHERE token_hash = $1`
+
+	err := r.db.Get(invite, query, tokenHash)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("invite: %w", errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (r *MemberRepo) DeleteInvite(id string) error {
+	query := `DELETE FROM project_invites WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("invite %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
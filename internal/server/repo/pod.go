@@ -16,6 +16,7 @@ type PodRepoInterface interface {
 	PodsByUser(id string) ([]model.Pod, error)
 	CountByProject(id string) (int, error)
 	Update(pod model.Pod) error
+	UpdateCommit(id string, commitSHA, commitMessage *string) error
 	Delete(id string) error
 }
 
@@ -27,10 +28,12 @@ func NewPodRepo(db *sqlx.DB) *PodRepo {
 	return &PodRepo{db: db}
 }
 
+const podColumns = `id, user_id, project_id, title, source_type, repo_url, branch, dockerfile_path, build_strategy, git_token_id, image, registry_credential_id, health_check_path, health_check_status, health_check_interval, health_check_timeout, health_check_grace_period, memory_limit, memory_reservation, cpu_limit, pids_limit, container_id, status, commit_sha, commit_message, created_at, updated_at`
+
 func (r *PodRepo) Create(pod *model.Pod) error {
-	query := `INSERT INTO pods (id, user_id, project_id, title, repo_url, branch, dockerfile_path, git_token_id, status) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	query := `INSERT INTO pods (id, user_id, project_id, title, source_type, repo_url, branch, dockerfile_path, build_strategy, git_token_id, image, registry_credential_id, health_check_path, health_check_status, health_check_interval, health_check_timeout, health_check_grace_period, memory_limit, memory_reservation, cpu_limit, pids_limit, status) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
 
-	_, err := r.db.Exec(query, pod.ID, pod.UserID, pod.ProjectID, pod.Title, pod.RepoURL, pod.Branch, pod.DockerfilePath, pod.GitTokenID, pod.Status)
+	_, err := r.db.Exec(query, pod.ID, pod.UserID, pod.ProjectID, pod.Title, pod.SourceType, pod.RepoURL, pod.Branch, pod.DockerfilePath, pod.BuildStrategy, pod.GitTokenID, pod.Image, pod.RegistryCredentialID, pod.HealthCheckPath, pod.HealthCheckStatus, pod.HealthCheckInterval, pod.HealthCheckTimeout, pod.HealthCheckGracePeriod, pod.MemoryLimit, pod.MemoryReservation, pod.CPULimit, pod.PIDsLimit, pod.Status)
 	if err != nil {
 		return err
 	}
@@ -40,7 +43,7 @@ func (r *PodRepo) Create(pod *model.Pod) error {
 
 func (r *PodRepo) Pod(id string) (*model.Pod, error) {
 	pod := &model.Pod{}
-	query := `SELECT id, user_id, project_id, title, repo_url, branch, dockerfile_path, git_token_id, container_id, status, created_at, updated_at FROM pods WHERE id = $1`
+	query := `SELECT ` + podColumns + ` FROM pods WHERE id = $1`
 
 	err := r.db.Get(pod, query, id)
 	if err == sql.ErrNoRows {
@@ -55,7 +58,7 @@ func (r *PodRepo) Pod(id string) (*model.Pod, error) {
 
 func (r *PodRepo) PodsByProject(id string) ([]model.Pod, error) {
 	pods := []model.Pod{}
-	query := `SELECT id, user_id, project_id, title, repo_url, branch, dockerfile_path, git_token_id, container_id, status, created_at, updated_at FROM pods WHERE project_id = $1`
+	query := `SELECT ` + podColumns + ` FROM pods WHERE project_id = $1`
 
 	err := r.db.Select(&pods, query, id)
 	if err == sql.ErrNoRows {
@@ -80,9 +83,10 @@ func (r *PodRepo) CountByProject(id string) (int, error) {
 	return count, nil
 }
 
+// PodsByUser returns the pods of all projects a user is a member of.
 func (r *PodRepo) PodsByUser(id string) ([]model.Pod, error) {
 	pods := []model.Pod{}
-	query := `SELECT id, user_id, project_id, title, repo_url, branch, dockerfile_path, git_token_id, container_id, status, created_at, updated_at FROM pods WHERE user_id = $1`
+	query := `SELECT ` + podColumns + ` FROM pods WHERE project_id IN (SELECT project_id FROM project_members WHERE user_id = $1)`
 
 	err := r.db.Select(&pods, query, id)
 	if err == sql.ErrNoRows {
@@ -96,9 +100,9 @@ func (r *PodRepo) PodsByUser(id string) ([]model.Pod, error) {
 }
 
 func (r *PodRepo) Update(pod model.Pod) error {
-	query := `UPDATE pods SET title = $1, repo_url = $2, branch = $3, dockerfile_path = $4, git_token_id = $5, container_id = $6, status = $7 WHERE id = $8`
+	query := `UPDATE pods SET title = $1, source_type = $2, repo_url = $3, branch = $4, dockerfile_path = $5, build_strategy = $6, git_token_id = $7, image = $8, registry_credential_id = $9, health_check_path = $10, health_check_status = $11, health_check_interval = $12, health_check_timeout = $13, health_check_grace_period = $14, memory_limit = $15, memory_reservation = $16, cpu_limit = $17, pids_limit = $18, container_id = $19, status = $20 WHERE id = $21`
 
-	result, err := r.db.Exec(query, pod.Title, pod.RepoURL, pod.Branch, pod.DockerfilePath, pod.GitTokenID, pod.ContainerID, pod.Status, pod.ID)
+	result, err := r.db.Exec(query, pod.Title, pod.SourceType, pod.RepoURL, pod.Branch, pod.DockerfilePath, pod.BuildStrategy, pod.GitTokenID, pod.Image, pod.RegistryCredentialID, pod.HealthCheckPath, pod.HealthCheckStatus, pod.HealthCheckInterval, pod.HealthCheckTimeout, pod.HealthCheckGracePeriod, pod.MemoryLimit, pod.MemoryReservation, pod.CPULimit, pod.PIDsLimit, pod.ContainerID, pod.Status, pod.ID)
 	if err != nil {
 		return err
 	}
@@ -114,6 +118,26 @@ func (r *PodRepo) Update(pod model.Pod) error {
 	return nil
 }
 
+// UpdateCommit stores the live commit of a pod after a successful deploy or rollback.
+func (r *PodRepo) UpdateCommit(id string, commitSHA, commitMessage *string) error {
+	query := `UPDATE pods SET commit_sha = $1, commit_message = $2 WHERE id = $3`
+
+	result, err := r.db.Exec(query, commitSHA, commitMessage, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("pod %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
 func (r *PodRepo) Delete(id string) error {
 	query := `DELETE FROM pods WHERE id = $1`
 
@@ -3,6 +3,8 @@ package repo
 import (
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
@@ -13,8 +15,11 @@ type PodDomainRepoInterface interface {
 	Create(domain *model.PodDomain) error
 	Domain(id string) (*model.PodDomain, error)
 	DomainByName(domain string) (*model.PodDomain, error)
+	DomainByRoute(domain, path string) (*model.PodDomain, error)
 	DomainsByPod(podID string) ([]model.PodDomain, error)
+	DomainsNearHost(host string) ([]model.PodDomain, error)
 	Update(domain model.PodDomain) error
+	UpdateStatus(id, status string, verifiedAt *time.Time) error
 	Delete(id string) error
 	DeleteByPod(podID string) error
 }
@@ -28,9 +33,9 @@ func NewPodDomainRepo(db *sqlx.DB) *PodDomainRepo {
 }
 
 func (r *PodDomainRepo) Create(domain *model.PodDomain) error {
-	query := `INSERT INTO pod_domains (id, pod_id, domain, type, port, ssl_enabled) VALUES ($1, $2, $3, $4, $5, $6)`
+	query := `INSERT INTO pod_domains (id, pod_id, domain, path, redirect_to, type, port, ssl_enabled) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
-	_, err := r.db.Exec(query, domain.ID, domain.PodID, domain.Domain, domain.Type, domain.Port, domain.SSLEnabled)
+	_, err := r.db.Exec(query, domain.ID, domain.PodID, domain.Domain, domain.Path, domain.RedirectTo, domain.Type, domain.Port, domain.SSLEnabled)
 	if err != nil {
 		return err
 	}
@@ -40,7 +45,7 @@ func (r *PodDomainRepo) Create(domain *model.PodDomain) error {
 
 func (r *PodDomainRepo) Domain(id string) (*model.PodDomain, error) {
 	domain := &model.PodDomain{}
-	query := `SELECT id, pod_id, domain, type, port, ssl_enabled, created_at, updated_at FROM pod_domains WHERE id = $1`
+	query := `SELECT id, pod_id, domain, path, redirect_to, type, port, ssl_enabled, status, verified_at, created_at, updated_at FROM pod_domains WHERE id = $1`
 
 	err := r.db.Get(domain, query, id)
 	if err == sql.ErrNoRows {
@@ -55,7 +60,7 @@ func (r *PodDomainRepo) Domain(id string) (*model.PodDomain, error) {
 
 func (r *PodDomainRepo) DomainByName(domainName string) (*model.PodDomain, error) {
 	domain := &model.PodDomain{}
-	query := `SELECT id, pod_id, domain, type, port, ssl_enabled, created_at, updated_at FROM pod_domains WHERE domain = $1`
+	query := `SELECT id, pod_id, domain, path, redirect_to, type, port, ssl_enabled, status, verified_at, created_at, updated_at FROM pod_domains WHERE domain = $1`
 
 	err := r.db.Get(domain, query, domainName)
 	if err == sql.ErrNoRows {
@@ -68,9 +73,25 @@ func (r *PodDomainRepo) DomainByName(domainName string) (*model.PodDomain, error
 	return domain, nil
 }
 
+// DomainByRoute returns the domain routing a host and path prefix.
+func (r *PodDomainRepo) DomainByRoute(domainName, path string) (*model.PodDomain, error) {
+	domain := &model.PodDomain{}
+	query := `SELECT id, pod_id, domain, path, redirect_to, type, port, ssl_enabled, status, verified_at, created_at, updated_at FROM pod_domains WHERE domain = $1 AND path = $2`
+
+	err := r.db.Get(domain, query, domainName, path)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("domain %s%s: %w", domainName, path, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return domain, nil
+}
+
 func (r *PodDomainRepo) DomainsByPod(podID string) ([]model.PodDomain, error) {
 	domains := []model.PodDomain{}
-	query := `SELECT id, pod_id, domain, type, port, ssl_enabled, created_at, updated_at FROM pod_domains WHERE pod_id = $1`
+	query := `SELECT id, pod_id, domain, path, redirect_to, type, port, ssl_enabled, status, verified_at, created_at, updated_at FROM pod_domains WHERE pod_id = $1`
 
 	err := r.db.Select(&domains, query, podID)
 	if err == sql.ErrNoRows {
@@ -83,10 +104,25 @@ func (r *PodDomainRepo) DomainsByPod(podID string) ([]model.PodDomain, error) {
 	return domains, nil
 }
 
+// DomainsNearHost returns the domains on host and on its sibling hosts,
+// which includes every wildcard overlapping it. Callers filter the exact overlap.
+func (r *PodDomainRepo) DomainsNearHost(host string) ([]model.PodDomain, error) {
+	domains := []model.PodDomain{}
+	query := `SELECT id, pod_id, domain, path, redirect_to, type, port, ssl_enabled, status, verified_at, created_at, updated_at FROM pod_domains WHERE domain = $1 OR domain LIKE $2`
+
+	_, parent, _ := strings.Cut(host, ".")
+	err := r.db.Select(&domains, query, host, "%."+parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
+
 func (r *PodDomainRepo) Update(domain model.PodDomain) error {
-	query := `UPDATE pod_domains SET domain = $1, type = $2, port = $3, ssl_enabled = $4 WHERE id = $5`
+	query := `UPDATE pod_domains SET domain = $1, path = $2, redirect_to = $3, type = $4, port = $5, ssl_enabled = $6 WHERE id = $7`
 
-	result, err := r.db.Exec(query, domain.Domain, domain.Type, domain.Port, domain.SSLEnabled, domain.ID)
+	result, err := r.db.Exec(query, domain.Domain, domain.Path, domain.RedirectTo, domain.Type, domain.Port, domain.SSLEnabled, domain.ID)
 	if err != nil {
 		return err
 	}
@@ -102,6 +138,23 @@ func (r *PodDomainRepo) Update(domain model.PodDomain) error {
 	return nil
 }
 
+// UpdateStatus stores the result of a DNS check.
+func (r *PodDomainRepo) UpdateStatus(id, status string, verifiedAt *time.Time) error {
+	query := `UPDATE pod_domains SET status = $1, verified_at = $2 WHERE id = $3`
+	result, err := r.db.Exec(query, status, verifiedAt, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("domain %s: %w", id, errs.ErrNotFound)
+	}
+	return nil
+}
+
 func (r *PodDomainRepo) Delete(id string) error {
 	query := `DELETE FROM pod_domains WHERE id = $1`
 
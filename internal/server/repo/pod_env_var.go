@@ -27,9 +27,9 @@ func NewPodEnvVarRepo(db *sqlx.DB) *PodEnvVarRepo {
 }
 
 func (r *PodEnvVarRepo) Create(envVar *model.PodEnvVar) error {
-	query := `INSERT INTO pod_env_vars (id, pod_id, key, value) VALUES ($1, $2, $3, $4)`
+	query := `INSERT INTO pod_env_vars (id, pod_id, key, value, scope) VALUES ($1, $2, $3, $4, $5)`
 
-	_, err := r.db.Exec(query, envVar.ID, envVar.PodID, envVar.Key, envVar.Value)
+	_, err := r.db.Exec(query, envVar.ID, envVar.PodID, envVar.Key, envVar.Value, envVar.Scope)
 	if err != nil {
 		return err
 	}
@@ -39,7 +39,7 @@ func (r *PodEnvVarRepo) Create(envVar *model.PodEnvVar) error {
 
 func (r *PodEnvVarRepo) EnvVar(id string) (*model.PodEnvVar, error) {
 	envVar := &model.PodEnvVar{}
-	query := `SELECT id, pod_id, key, value, created_at, updated_at FROM pod_env_vars WHERE id = $1`
+	query := `SELECT id, pod_id, key, value, scope, created_at, updated_at FROM pod_env_vars WHERE id = $1`
 
 	err := r.db.Get(envVar, query, id)
 	if err == sql.ErrNoRows {
@@ -54,7 +54,7 @@ func (r *PodEnvVarRepo) EnvVar(id string) (*model.PodEnvVar, error) {
 
 func (r *PodEnvVarRepo) EnvVarsByPod(podID string) ([]model.PodEnvVar, error) {
 	envVars := []model.PodEnvVar{}
-	query := `SELECT id, pod_id, key, value, created_at, updated_at FROM pod_env_vars WHERE pod_id = $1`
+	query := `SELECT id, pod_id, key, value, scope, created_at, updated_at FROM pod_env_vars WHERE pod_id = $1`
 
 	err := r.db.Select(&envVars, query, podID)
 	if err == sql.ErrNoRows {
@@ -68,9 +68,9 @@ func (r *PodEnvVarRepo) EnvVarsByPod(podID string) ([]model.PodEnvVar, error) {
 }
 
 func (r *PodEnvVarRepo) Update(envVar model.PodEnvVar) error {
-	query := `UPDATE pod_env_vars SET key = $1, value = $2 WHERE id = $3`
+	query := `UPDATE pod_env_vars SET key = $1, value = $2, scope = $3 WHERE id = $4`
 
-	result, err := r.db.Exec(query, envVar.Key, envVar.Value, envVar.ID)
+	result, err := r.db.Exec(query, envVar.Key, envVar.Value, envVar.Scope, envVar.ID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type PodVolumeRepoInterface interface {
+	Create(volume *model.PodVolume) error
+	Volume(id string) (*model.PodVolume, error)
+	VolumesByPod(podID string) ([]model.PodVolume, error)
+	Update(volume model.PodVolume) error
+	Delete(id string) error
+}
+
+type PodVolumeRepo struct {
+	db *sqlx.DB
+}
+
+func NewPodVolumeRepo(db *sqlx.DB) *PodVolumeRepo {
+	return &PodVolumeRepo{db: db}
+}
+
+func (r *PodVolumeRepo) Create(volume *model.PodVolume) error {
+	query := `INSERT INTO pod_volumes (id, pod_id, type, source, volume_name, mount_path, read_only) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(query, volume.ID, volume.PodID, volume.Type, volume.Source, volume.VolumeName, volume.MountPath, volume.ReadOnly)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PodVolumeRepo) Volume(id string) (*model.PodVolume, error) {
+	volume := &model.PodVolume{}
+	query := `SELECT id, pod_id, type, source, volume_name, mount_path, read_only, created_at, updated_at FROM pod_volumes WHERE id = $1`
+
+	err := r.db.Get(volume, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("volume %s: %w", id, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return volume, nil
+}
+
+func (r *PodVolumeRepo) VolumesByPod(podID string) ([]model.PodVolume, error) {
+	volumes := []model.PodVolume{}
+	query := `SELECT id, pod_id, type, source, volume_name, mount_path, read_only, created_at, updated_at FROM pod_volumes WHERE pod_id = $1 ORDER BY mount_path`
+
+	err := r.db.Select(&volumes, query, podID)
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+func (r *PodVolumeRepo) Update(volume model.PodVolume) error {
+	query := `UPDATE pod_volumes SET type = $1, source = $2, volume_name = $3, mount_path = $4, read_only = $5, updated_at = CURRENT_TIMESTAMP WHERE id = $6`
+
+	result, err := r.db.Exec(query, volume.Type, volume.Source, volume.VolumeName, volume.MountPath, volume.ReadOnly, volume.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("volume %s: %w", volume.ID, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *PodVolumeRepo) Delete(id string) error {
+	query := `DELETE FROM pod_volumes WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("volume %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type PodWebhookRepoInterface interface {
+	Create(webhook *model.PodWebhook) error
+	WebhookByPod(podID string) (*model.PodWebhook, error)
+	UpdateSecret(podID, secret string) error
+}
+
+type PodWebhookRepo struct {
+	db *sqlx.DB
+}
+
+func NewPodWebhookRepo(db *sqlx.DB) *PodWebhookRepo {
+	return &PodWebhookRepo{db: db}
+}
+
+func (r *PodWebhookRepo) Create(webhook *model.PodWebhook) error {
+	query := `INSERT INTO pod_webhooks (id, pod_id, secret) VALUES ($1, $2, $3)`
+
+	_, err := r.db.Exec(query, webhook.ID, webhook.PodID, webhook.Secret)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PodWebhookRepo) WebhookByPod(podID string) (*model.PodWebhook, error) {
+	webhook := &model.PodWebhook{}
+	query := `SELECT id, pod_id, secret, created_at, updated_at FROM pod_webhooks WHERE pod_id = $1`
+
+	err := r.db.Get(webhook, query, podID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("webhook for pod %s: %w", podID, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *PodWebhookRepo) UpdateSecret(podID, secret string) error {
+	query := `UPDATE pod_webhooks SET secret = $1, updated_at = CURRENT_TIMESTAMP WHERE pod_id = $2`
+
+	result, err := r.db.Exec(query, secret, podID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook for pod %s: %w", podID, errs.ErrNotFound)
+	}
+
+	return nil
+}
@@ -25,15 +25,27 @@ func NewProjectRepo(db *sqlx.DB) *ProjectRepo {
 	return &ProjectRepo{db: db}
 }
 
+// Create stores a project with its creator as owner.
 func (r *ProjectRepo) Create(project *model.Project) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `INSERT INTO projects (id, user_id, title) VALUES ($1, $2, $3)`
+	_, err = tx.Exec(query, project.ID, project.UserID, project.Title)
+	if err != nil {
+		return err
+	}
 
-	_, err := r.db.Exec(query, project.ID, project.UserID, project.Title)
+	query = `INSERT INTO project_members (project_id, user_id, role) VALUES ($1, $2, $3)`
+	_, err = tx.Exec(query, project.ID, project.UserID, model.ProjectRoleOwner)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (r *ProjectRepo) Project(id string) (*model.Project, error) {
@@ -51,9 +63,12 @@ func (r *ProjectRepo) Project(id string) (*model.Project, error) {
 	return project, nil
 }
 
+// ProjectsByUser returns the projects a user is a member of, with the user's role.
 func (r *ProjectRepo) ProjectsByUser(id string) ([]model.Project, error) {
 	projects := []model.Project{}
-	query := `SELECT id, user_id, title, created_at, updated_at FROM projects WHERE user_id = $1`
+	query := `SELECT p.id, p.user_id, p.title, p.created_at, p.updated_at, m.role
+		FROM projects p JOIN project_members m ON m.project_id = p.id
+		WHERE m.user_id = $1`
 
 	err := r.db.Select(&projects, query, id)
 	if err == sql.ErrNoRows {
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type RecoveryCodeRepoInterface interface {
+	Replace(userID string, codes []model.RecoveryCode) error
+	Use(userID, codeHash string, usedAt time.Time) error
+	DeleteByUser(userID string) error
+}
+
+type RecoveryCodeRepo struct {
+	db *sqlx.DB
+}
+
+func NewRecoveryCodeRepo(db *sqlx.DB) *RecoveryCodeRepo {
+	return &RecoveryCodeRepo{db: db}
+}
+
+// Replace deletes the codes of a user and stores new ones.
+func (r *RecoveryCodeRepo) Replace(userID string, codes []model.RecoveryCode) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM recovery_codes WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)`
+	for _, code := range codes {
+		_, err = tx.Exec(query, code.ID, userID, code.CodeHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Use marks an unused code as used. Unknown and used codes return ErrNotFound.
+func (r *RecoveryCodeRepo) Use(userID, codeHash string, usedAt time.Time) error {
+	query := `UPDATE recovery_codes SET used_at = $1 WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL`
+
+	result, err := r.db.Exec(query, usedAt, userID, codeHash)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("recovery code: %w", errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodeRepo) DeleteByUser(userID string) error {
+	query := `DELETE FROM recovery_codes WHERE user_id = $1`
+
+	_, err := r.db.Exec(query, userID)
+	return err
+}
@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type RegistryCredentialRepoInterface interface {
+	Create(credential *model.RegistryCredential) error
+	RegistryCredential(id string) (*model.RegistryCredential, error)
+	RegistryCredentialsByUser(userID string) ([]model.RegistryCredential, error)
+	Delete(id string) error
+}
+
+type RegistryCredentialRepo struct {
+	db *sqlx.DB
+}
+
+func NewRegistryCredentialRepo(db *sqlx.DB) *RegistryCredentialRepo {
+	return &RegistryCredentialRepo{db: db}
+}
+
+func (r *RegistryCredentialRepo) Create(credential *model.RegistryCredential) error {
+	query := `INSERT INTO registry_credentials (id, user_id, name, registry, username, password) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(query, credential.ID, credential.UserID, credential.Name, credential.Registry, credential.Username, credential.Password)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RegistryCredentialRepo) RegistryCredential(id string) (*model.RegistryCredential, error) {
+	credential := &model.RegistryCredential{}
+	query := `SELECT id, user_id, name, registry, username, password, created_at, updated_at FROM registry_credentials WHERE id = $1`
+
+	err := r.db.Get(credential, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("registry credential %s: %w", id, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+func (r *RegistryCredentialRepo) RegistryCredentialsByUser(userID string) ([]model.RegistryCredential, error) {
+	credentials := []model.RegistryCredential{}
+	query := `SELECT id, user_id, name, registry, username, password, created_at, updated_at FROM registry_credentials WHERE user_id = $1`
+
+	err := r.db.Select(&credentials, query, userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func (r *RegistryCredentialRepo) Delete(id string) error {
+	query := `DELETE FROM registry_credentials WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("registry credential %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/deeploy-sh/deeploy/internal/server/db"
+	"github.com/jmoiron/sqlx"
+)
+
+type ServerBackupRepoInterface interface {
+	SchemaVersion() (int64, error)
+	Rows(table string) ([]map[string]any, error)
+	Replace(tables []string, rows map[string][]map[string]any) error
+}
+
+// ServerBackupRepo reads and writes whole tables for server backups.
+// Table names come from a fixed list in the service, never from user input.
+type ServerBackupRepo struct {
+	db *sqlx.DB
+}
+
+func NewServerBackupRepo(db *sqlx.DB) *ServerBackupRepo {
+	return &ServerBackupRepo{db: db}
+}
+
+func (r *ServerBackupRepo) SchemaVersion() (int64, error) {
+	return db.Version(r.db)
+}
+
+// Rows returns all rows of a table as column -> value maps.
+func (r *ServerBackupRepo) Rows(table string) ([]map[string]any, error) {
+	rows, err := r.db.Queryx(`SELECT * FROM ` + table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []map[string]any{}
+	for rows.Next() {
+		row := map[string]any{}
+		err := rows.MapScan(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// Replace deletes all rows of the tables and inserts the given rows in one
+// transaction. Tables are deleted in reverse and filled in the given order,
+// so it must satisfy their foreign keys. Columns unknown to the current
+// schema are skipped, missing columns get their defaults.
+func (r *ServerBackupRepo) Replace(tables []string, rows map[string][]map[string]any) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range slices.Backward(tables) {
+		_, err := tx.Exec(`DELETE FROM ` + table)
+		if err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	for _, table := range tables {
+		columns, err := tableColumns(tx, table)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows[table] {
+			var names, placeholders []string
+			var values []any
+			for _, column := range columns {
+				value, ok := row[column]
+				if !ok {
+					continue
+				}
+				names = append(names, column)
+				values = append(values, value)
+				placeholders = append(placeholders, fmt.Sprintf("$%d", len(values)))
+			}
+
+			query := `INSERT INTO ` + table + ` (` + strings.Join(names, ", ") + `) VALUES (` + strings.Join(placeholders, ", ") + `)`
+			_, err := tx.Exec(query, values...)
+			if err != nil {
+				return fmt.Errorf("failed to restore %s: %w", table, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// tableColumns returns the column names of a table in the current schema.
+func tableColumns(tx *sqlx.Tx, table string) ([]string, error) {
+	rows, err := tx.Queryx(`SELECT * FROM ` + table + ` LIMIT 0`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return rows.Columns()
+}
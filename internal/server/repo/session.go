@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type SessionRepoInterface interface {
+	Create(session *model.Session) error
+	Session(id string) (*model.Session, error)
+	SessionByRefreshHash(tokenHash string) (*model.Session, error)
+	SessionByPreviousHash(tokenHash string) (*model.Session, error)
+	SessionsByUser(userID string) ([]model.Session, error)
+	Rotate(id, oldHash, newHash, ip string, expiresAt, seenAt time.Time) error
+	MarkSeen(id string, seenAt time.Time) error
+	Delete(id string) error
+	DeleteExpired(now time.Time) error
+}
+
+type SessionRepo struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepo(db *sqlx.DB) *SessionRepo {
+	return &SessionRepo{db: db}
+}
+
+const sessionColumns = `id, user_id, refresh_token_hash, previous_token_hash, client, ip, expires_at, last_seen_at, created_at`
+
+func (r *SessionRepo) Create(session *model.Session) error {
+	query := `INSERT INTO sessions (id, user_id, refresh_token_hash, client, ip, expires_at, last_seen_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(query, session.ID, session.UserID, session.RefreshTokenHash, session.Client, session.IP, session.ExpiresAt, session.LastSeenAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SessionRepo) Session(id string) (*model.Session, error) {
+	session := &model.Session{}
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1`
+
+	err := r.db.Get(session, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session %s: %w", id, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepo) SessionByRefreshHash(tokenHash string) (*model.Session, error) {
+	session := &model.Session{}
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE refresh_token_hash = $1`
+
+	err := r.db.Get(session, query, tokenHash)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session: %w", errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// SessionByPreviousHash finds the session a refresh token was replaced in.
+func (r *SessionRepo) SessionByPreviousHash(tokenHash string) (*model.Session, error) {
+	session := &model.Session{}
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE previous_token_hash = $1`
+
+	err := r.db.Get(session, query, tokenHash)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session: %w", errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepo) SessionsByUser(userID string) ([]model.Session, error) {
+	sessions := []model.Session{}
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE user_id = $1 ORDER BY last_seen_at DESC`
+
+	err := r.db.Select(&sessions, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Rotate replaces the refresh token of a session. It fails with ErrNotFound
+// when oldHash is no longer current, so a token can only be used once.
+func (r *SessionRepo) Rotate(id, oldHash, newHash, ip string, expiresAt, seenAt time.Time) error {
+	query := `UPDATE sessions SET refresh_token_hash = $1, previous_token_hash = $2, ip = $3, expires_at = $4, last_seen_at = $5 WHERE id = $6 AND refresh_token_hash = $7`
+
+	result, err := r.db.Exec(query, newHash, oldHash, ip, expiresAt, seenAt, id, oldHash)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("session %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// MarkSeen stores when a session was used last.
+func (r *SessionRepo) MarkSeen(id string, seenAt time.Time) error {
+	query := `UPDATE sessions SET last_seen_at = $1 WHERE id = $2`
+
+	_, err := r.db.Exec(query, seenAt, id)
+	return err
+}
+
+func (r *SessionRepo) Delete(id string) error {
+	query := `DELETE FROM sessions WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("session %s: %w", id, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *SessionRepo) DeleteExpired(now time.Time) error {
+	query := `DELETE FROM sessions WHERE expires_at < $1`
+
+	_, err := r.db.Exec(query, now)
+	return err
+}
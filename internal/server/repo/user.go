@@ -2,7 +2,9 @@ package repo
 
 import (
 	"database/sql"
+	"fmt"
 
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
 	"github.com/jmoiron/sqlx"
 )
@@ -12,6 +14,8 @@ type UserRepoInterface interface {
 	CreateUser(user *model.User) error
 	GetUserByEmail(email string) (*model.User, error)
 	GetUserByID(id string) (*model.User, error)
+	UpdateTOTP(id, secret string, enabled bool) error
+	UseTOTPStep(id string, step int64) error
 }
 
 type UserRepo struct {
@@ -35,9 +39,9 @@ func (r *UserRepo) CountUsers() (int, error) {
 }
 
 func (r *UserRepo) CreateUser(user *model.User) error {
-	query := `INSERT INTO users (id, email, password) VALUES ($1, $2, $3)`
+	query := `INSERT INTO users (id, email, password, is_admin) VALUES ($1, $2, $3, $4)`
 
-	_, err := r.db.Exec(query, user.ID, user.Email, user.Password)
+	_, err := r.db.Exec(query, user.ID, user.Email, user.Password, user.IsAdmin)
 	if err != nil {
 		return err
 	}
@@ -47,7 +51,7 @@ func (r *UserRepo) CreateUser(user *model.User) error {
 
 func (r *UserRepo) GetUserByEmail(email string) (*model.User, error) {
 	user := &model.User{}
-	query := `SELECT id, email, password, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT id, email, password, is_admin, totp_secret, totp_enabled, created_at, updated_at FROM users WHERE email = $1`
 
 	err := r.db.Get(user, query, email)
 	if err == sql.ErrNoRows {
@@ -62,7 +66,7 @@ func (r *UserRepo) GetUserByEmail(email string) (*model.User, error) {
 
 func (r *UserRepo) GetUserByID(id string) (*model.User, error) {
 	user := &model.User{}
-	query := `SELECT id, email, password, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT id, email, password, is_admin, totp_secret, totp_enabled, created_at, updated_at FROM users WHERE id = $1`
 
 	err := r.db.Get(user, query, id)
 	if err == sql.ErrNoRows {
@@ -74,3 +78,32 @@ func (r *UserRepo) GetUserByID(id string) (*model.User, error) {
 
 	return user, nil
 }
+
+// UpdateTOTP stores the second factor of a user, an empty secret removes it.
+func (r *UserRepo) UpdateTOTP(id, secret string, enabled bool) error {
+	query := `UPDATE users SET totp_secret = $1, totp_enabled = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`
+
+	_, err := r.db.Exec(query, secret, enabled, id)
+	return err
+}
+
+// UseTOTPStep records the time step of an accepted TOTP code. It returns
+// ErrNotFound when a code of that step or a later one was accepted already.
+func (r *UserRepo) UseTOTPStep(id string, step int64) error {
+	query := `UPDATE users SET totp_last_step = $1 WHERE id = $2 AND totp_last_step < $1`
+
+	result, err := r.db.Exec(query, step, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("totp step %d: %w", step, errs.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,415 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/server/app"
+	"github.com/deeploy-sh/deeploy/internal/server/config"
+	"github.com/deeploy-sh/deeploy/internal/server/forms"
+	"github.com/deeploy-sh/deeploy/internal/server/jwt"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+// testServer is the full API on a fresh SQLite database. Docker is never
+// reached, the requests under test are rejected or answered before that.
+type testServer struct {
+	t       *testing.T
+	app     *app.App
+	handler http.Handler
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	jwt.JwtSecret = []byte("test-secret-with-at-least-32-characters")
+
+	dir := t.TempDir()
+	a, err := app.New(&config.Config{
+		AppEnv:            "test",
+		DBDriver:          "sqlite",
+		DBConnection:      filepath.Join(dir, "deeploy.db"),
+		BuildDir:          filepath.Join(dir, "builds"),
+		BuildLogDir:       filepath.Join(dir, "build-logs"),
+		BuildLogRetention: 20,
+		TraefikConfigDir:  filepath.Join(dir, "traefik"),
+		PublicIP:          "127.0.0.1",
+		BackupDir:         filepath.Join(dir, "backups"),
+	})
+	if err != nil {
+		t.Fatalf("app: %v", err)
+	}
+	t.Cleanup(func() { a.Close() })
+
+	return &testServer{t: t, app: a, handler: Setup(a)}
+}
+
+// register creates a user and returns the access token of a new session.
+func (s *testServer) register(email string) string {
+	s.t.Helper()
+	return s.signIn(email).Token
+}
+
+// signIn creates a user and returns the tokens of a new session.
+func (s *testServer) signIn(email string) *model.AuthTokens {
+	s.t.Helper()
+	user, err := s.app.UserService.Register(forms.RegisterForm{Email: email, Password: "password123", PasswordConfirm: "password123"})
+	if err != nil {
+		s.t.Fatalf("register %s: %v", email, err)
+	}
+	tokens, err := s.app.SessionService.Create(user.ID, "TUI", "127.0.0.1")
+	if err != nil {
+		s.t.Fatalf("sign in %s: %v", email, err)
+	}
+	return tokens
+}
+
+// do sends a request as the user of token. Non-nil bodies are sent as JSON.
+func (s *testServer) do(token, method, path string, body any) *httptest.ResponseRecorder {
+	s.t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		err := json.NewEncoder(&buf).Encode(body)
+		if err != nil {
+			s.t.Fatalf("encode body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// create sends a request that must succeed and decodes its response into v.
+func (s *testServer) create(token, method, path string, body, v any) {
+	s.t.Helper()
+	rec := s.do(token, method, path, body)
+	if rec.Code >= 300 {
+		s.t.Fatalf("%s %s: %d %s", method, path, rec.Code, rec.Body.String())
+	}
+	err := json.NewDecoder(rec.Body).Decode(v)
+	if err != nil {
+		s.t.Fatalf("%s %s: decode: %v", method, path, err)
+	}
+}
+
+// fixture is the data of the first user.
+type fixture struct {
+	project  model.Project
+	pod      model.Pod
+	volume   model.PodVolume
+	gitToken model.GitToken
+	database model.Database
+	backup   model.Backup
+}
+
+// seed creates a project with a pod, volume, database, backup and git token as owner.
+func (s *testServer) seed(owner string) fixture {
+	s.t.Helper()
+	var f fixture
+
+	s.create(owner, "POST", "/api/projects", map[string]string{"title": "Shop"}, &f.project)
+	s.create(owner, "POST", "/api/pods", map[string]string{"title": "web", "project_id": f.project.ID}, &f.pod)
+	s.create(owner, "POST", "/api/pods/"+f.pod.ID+"/volumes", map[string]string{"source": "uploads", "mount_path": "/data"}, &f.volume)
+	s.create(owner, "POST", "/api/git-tokens", map[string]string{"name": "github", "provider": "github", "token": "ghp_secret"}, &f.gitToken)
+
+	// Databases and backups are inserted directly, creating them through the API starts containers
+	f.database = model.Database{
+		ID:           uuid.New().String(),
+		UserID:       f.project.UserID,
+		ProjectID:    f.project.ID,
+		Title:        "main",
+		Engine:       model.DatabaseEnginePostgres,
+		Version:      "17",
+		Username:     "postgres",
+		Password:     "database-secret",
+		DatabaseName: "app",
+		Status:       model.DatabaseStatusRunning,
+	}
+	err := repo.NewDatabaseRepo(s.app.DB).Create(&f.database)
+	if err != nil {
+		s.t.Fatalf("create database: %v", err)
+	}
+	f.backup = model.Backup{
+		ID:          uuid.New().String(),
+		UserID:      f.project.UserID,
+		TargetType:  model.BackupTargetDatabase,
+		TargetID:    f.database.ID,
+		Destination: model.BackupDestinationLocal,
+		StorageKey:  "database/" + f.database.ID + "/backup.sql.gz",
+		Status:      model.BackupStatusSuccess,
+	}
+	err = repo.NewBackupRepo(s.app.DB).Create(&f.backup)
+	if err != nil {
+		s.t.Fatalf("create backup: %v", err)
+	}
+
+	return f
+}
+
+type accessCase struct {
+	method string
+	path   string
+	body   any
+}
+
+func TestOtherUserCannotAccessData(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	f := s.seed(alice)
+
+	project := "/api/projects/" + f.project.ID
+	pod := "/api/pods/" + f.pod.ID
+	database := "/api/databases/" + f.database.ID
+
+	var domain model.PodDomain
+	s.create(alice, "POST", pod+"/domains", map[string]any{"domain": "shop.example.com", "port": 3000}, &domain)
+	var credential model.RegistryCredential
+	s.create(alice, "POST", "/api/registry-credentials", map[string]string{"name": "ghcr", "registry": "ghcr.io", "username": "alice", "password": "registry-secret"}, &credential)
+	deployment := model.Deployment{
+		ID:          uuid.New().String(),
+		PodID:       f.pod.ID,
+		Branch:      "main",
+		ImageTag:    "deeploy-web:1",
+		Status:      model.DeploymentStatusSuccess,
+		TriggeredBy: "alice@example.com",
+	}
+	err := repo.NewDeploymentRepo(s.app.DB).Create(&deployment)
+	if err != nil {
+		t.Fatalf("create deployment: %v", err)
+	}
+	domainPath := pod + "/domains/" + domain.ID
+	deploymentPath := pod + "/deployments/" + deployment.ID
+
+	cases := []accessCase{
+		// Projects and members
+		{"GET", project, nil},
+		{"PUT", "/api/projects", map[string]string{"id": f.project.ID, "title": "Taken"}},
+		{"DELETE", project, nil},
+		{"GET", project + "/pods", nil},
+		{"GET", project + "/databases", nil},
+		{"GET", project + "/members", nil},
+		{"POST", project + "/invites", map[string]string{"email": "bob@example.com", "role": "owner"}},
+
+		// Pods
+		{"POST", "/api/pods", map[string]string{"title": "intruder", "project_id": f.project.ID}},
+		{"GET", pod, nil},
+		{"PUT", "/api/pods", map[string]string{"id": f.pod.ID, "title": "Taken"}},
+		{"DELETE", pod, nil},
+
+		// Deploys and logs
+		{"POST", pod + "/deploy", nil},
+		{"POST", pod + "/stop", nil},
+		{"POST", pod + "/restart", nil},
+		{"GET", pod + "/logs", nil},
+		{"GET", pod + "/deployments", nil},
+		{"GET", deploymentPath + "/logs", nil},
+		{"POST", deploymentPath + "/rollback", nil},
+		{"GET", pod + "/logs/stream", nil},
+
+		// Domains, volumes, env vars and webhooks
+		{"GET", pod + "/domains", nil},
+		{"POST", pod + "/domains", map[string]string{"domain": "taken.example.com"}},
+		{"PUT", domainPath, map[string]any{"domain": "taken.example.com", "port": 3000}},
+		{"DELETE", domainPath, nil},
+		{"POST", domainPath + "/verify", nil},
+		{"POST", domainPath + "/skip-verification", nil},
+		{"PUT", domainPath + "/certificate", map[string]string{"certificate": "cert", "private_key": "key"}},
+		{"DELETE", domainPath + "/certificate", nil},
+		{"GET", pod + "/volumes", nil},
+		{"POST", pod + "/volumes", map[string]string{"source": "stolen", "mount_path": "/stolen"}},
+		{"PUT", pod + "/volumes/" + f.volume.ID, map[string]string{"source": "uploads", "mount_path": "/moved"}},
+		{"DELETE", pod + "/volumes/" + f.volume.ID, nil},
+		{"GET", pod + "/vars", nil},
+		{"PUT", pod + "/vars", map[string]any{"vars": []map[string]string{{"key": "A", "value": "b"}}}},
+		{"GET", pod + "/webhook", nil},
+		{"POST", pod + "/webhook/regenerate", nil},
+
+		// Databases and their links
+		{"POST", "/api/databases", map[string]string{"title": "intruder", "project_id": f.project.ID, "engine": "postgres"}},
+		{"GET", database, nil},
+		{"DELETE", database, nil},
+		{"GET", pod + "/databases", nil},
+		{"POST", pod + "/databases", map[string]string{"database_id": f.database.ID}},
+		{"DELETE", pod + "/databases/" + f.database.ID, nil},
+
+		// Backups
+		{"GET", database + "/backups", nil},
+		{"POST", database + "/backups", nil},
+		{"PUT", database + "/backups/schedule", map[string]any{"interval_hours": 24, "retention": 7}},
+		{"GET", pod + "/volumes/" + f.volume.ID + "/backups", nil},
+		{"POST", "/api/backups/" + f.backup.ID + "/restore", nil},
+		{"DELETE", "/api/backups/" + f.backup.ID, nil},
+
+		// Credentials
+		{"DELETE", "/api/git-tokens/" + f.gitToken.ID, nil},
+		{"DELETE", "/api/registry-credentials/" + credential.ID, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.method+" "+c.path, func(t *testing.T) {
+			rec := s.do(bob, c.method, c.path, c.body)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("got %d %q, want 404", rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	// Nothing of alice shows up in the lists of bob
+	for _, path := range []string{"/api/projects", "/api/pods", "/api/databases", "/api/git-tokens", "/api/registry-credentials"} {
+		var items []json.RawMessage
+		s.create(bob, "GET", path, nil, &items)
+		if len(items) != 0 {
+			t.Errorf("GET %s: bob sees %d items of alice", path, len(items))
+		}
+	}
+
+	// Alice's data is unchanged
+	var got model.Pod
+	s.create(alice, "GET", pod, nil, &got)
+	if got.Title != "web" {
+		t.Errorf("pod title = %q, want web", got.Title)
+	}
+	var tokens []model.GitToken
+	s.create(alice, "GET", "/api/git-tokens", nil, &tokens)
+	if len(tokens) != 1 {
+		t.Errorf("alice has %d git tokens, want 1", len(tokens))
+	}
+}
+
+func TestOtherUserCannotUseCredentials(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	f := s.seed(alice)
+
+	var project model.Project
+	s.create(bob, "POST", "/api/projects", map[string]string{"title": "Mine"}, &project)
+
+	rec := s.do(bob, "POST", "/api/pods", map[string]string{"title": "clone", "project_id": project.ID, "git_token_id": f.gitToken.ID})
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("pod with foreign git token: got %d, want 404", rec.Code)
+	}
+
+	// A database can only be linked to pods of its own project
+	var pod model.Pod
+	s.create(bob, "POST", "/api/pods", map[string]string{"title": "web", "project_id": project.ID}, &pod)
+	rec = s.do(bob, "POST", "/api/pods/"+pod.ID+"/databases", map[string]string{"database_id": f.database.ID})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("link foreign database: got %d, want 400", rec.Code)
+	}
+}
+
+func TestProjectRoles(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	f := s.seed(alice)
+
+	invite := &model.ProjectInvite{ProjectID: f.project.ID, Email: "bob@example.com", Role: model.ProjectRoleViewer, InvitedBy: f.project.UserID}
+	err := s.app.MemberService.Invite(invite)
+	if err != nil {
+		t.Fatalf("invite: %v", err)
+	}
+	err = s.app.MemberService.AcceptInvite(invite.Token, "bob@example.com")
+	if err != nil {
+		t.Fatalf("accept invite: %v", err)
+	}
+
+	pod := "/api/pods/" + f.pod.ID
+
+	// Viewers read
+	for _, path := range []string{"/api/projects/" + f.project.ID, pod, pod + "/volumes", "/api/databases/" + f.database.ID} {
+		rec := s.do(bob, "GET", path, nil)
+		if rec.Code != http.StatusOK {
+			t.Errorf("viewer GET %s: got %d, want 200", path, rec.Code)
+		}
+	}
+
+	var database model.Database
+	s.create(bob, "GET", "/api/databases/"+f.database.ID, nil, &database)
+	if database.URL != "" {
+		t.Errorf("viewer gets the database URL %q", database.URL)
+	}
+
+	// Viewers don't change anything or see secrets
+	forbidden := []accessCase{
+		{"PUT", "/api/projects", map[string]string{"id": f.project.ID, "title": "Taken"}},
+		{"PUT", "/api/pods", map[string]string{"id": f.pod.ID, "title": "Taken"}},
+		{"DELETE", pod, nil},
+		{"GET", pod + "/vars", nil},
+		{"GET", pod + "/webhook", nil},
+		{"POST", pod + "/volumes", map[string]string{"source": "more", "mount_path": "/more"}},
+		{"POST", "/api/backups/" + f.backup.ID + "/restore", nil},
+		{"POST", "/api/projects/" + f.project.ID + "/invites", map[string]string{"email": "eve@example.com", "role": "owner"}},
+	}
+	for _, c := range forbidden {
+		rec := s.do(bob, c.method, c.path, c.body)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("viewer %s %s: got %d, want 403", c.method, c.path, rec.Code)
+		}
+	}
+
+	// Developers change pods, owners delete them
+	bobID := memberID(t, s, alice, f.project.ID, "bob@example.com")
+	err = s.app.MemberService.UpdateRole(f.project.ID, bobID, model.ProjectRoleDeveloper)
+	if err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+
+	var updated model.Pod
+	s.create(bob, "PUT", "/api/pods", map[string]string{"id": f.pod.ID, "title": "renamed"}, &updated)
+	if updated.Title != "renamed" || updated.ProjectID != f.project.ID {
+		t.Errorf("updated pod = %+v, want the stored pod titled renamed", updated)
+	}
+	rec := s.do(bob, "DELETE", pod, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("developer DELETE pod: got %d, want 403", rec.Code)
+	}
+
+	var domain model.PodDomain
+	s.create(bob, "POST", pod+"/domains", map[string]any{"domain": "shop.example.com", "port": 3000}, &domain)
+	rec = s.do(bob, "POST", pod+"/domains/"+domain.ID+"/skip-verification", nil)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("developer skipping the DNS check: got %d, want 403", rec.Code)
+	}
+}
+
+// memberID returns the user ID of the member with email.
+func memberID(t *testing.T, s *testServer, token, projectID, email string) string {
+	t.Helper()
+	var members model.Members
+	s.create(token, "GET", "/api/projects/"+projectID+"/members", nil, &members)
+	for _, m := range members.Members {
+		if m.Email == email {
+			return m.UserID
+		}
+	}
+	t.Fatalf("%s is no member", email)
+	return ""
+}
+
+func TestServerSettingsAdminOnly(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+
+	for _, path := range []string{"/api/settings/domain", "/api/settings/backup"} {
+		rec := s.do(bob, "GET", path, nil)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("GET %s as bob: got %d, want 403", path, rec.Code)
+		}
+	}
+
+	rec := s.do(alice, "GET", "/api/settings/domain", nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /api/settings/domain as admin: got %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+// apiToken creates an API token for the user of session.
+func (s *testServer) apiToken(session, scope string) model.APIToken {
+	s.t.Helper()
+	var token model.APIToken
+	s.create(session, "POST", "/api/tokens", model.APITokenCreate{Name: scope, Scope: scope}, &token)
+	if token.Token == "" {
+		s.t.Fatalf("created token has no secret")
+	}
+	return token
+}
+
+func TestAPITokenScopes(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	read := s.apiToken(alice, model.APITokenScopeRead).Token
+	admin := s.apiToken(alice, model.APITokenScopeAdmin).Token
+
+	cases := []struct {
+		token  string
+		method string
+		path   string
+		body   any
+		want   int
+	}{
+		{read, "GET", "/api/projects", nil, http.StatusOK},
+		{read, "POST", "/api/projects", map[string]string{"title": "Shop"}, http.StatusForbidden},
+		{read, "GET", "/api/settings/backup", nil, http.StatusForbidden},
+		{admin, "POST", "/api/projects", map[string]string{"title": "Shop"}, http.StatusOK},
+		{admin, "POST", "/api/tokens", model.APITokenCreate{Name: "more", Scope: "admin"}, http.StatusForbidden},
+		{"dpl_unknown", "GET", "/api/projects", nil, http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		rec := s.do(c.token, c.method, c.path, c.body)
+		if rec.Code != c.want {
+			t.Errorf("%s %s: got %d %q, want %d", c.method, c.path, rec.Code, rec.Body.String(), c.want)
+		}
+	}
+
+	// Listing shows the last use, never the secret
+	var tokens []model.APIToken
+	s.create(alice, "GET", "/api/tokens", nil, &tokens)
+	if len(tokens) != 2 {
+		t.Fatalf("got %d tokens, want 2", len(tokens))
+	}
+	for _, token := range tokens {
+		if token.Token != "" {
+			t.Errorf("token %s is listed with its secret", token.Name)
+		}
+		if token.LastUsedAt == nil {
+			t.Errorf("token %s has no last use", token.Name)
+		}
+	}
+}
+
+func TestAPITokenRevokeAndExpiry(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	token := s.apiToken(alice, model.APITokenScopeRead)
+
+	rec := s.do(bob, "DELETE", "/api/tokens/"+token.ID, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("bob revokes alice's token: got %d, want 404", rec.Code)
+	}
+
+	rec = s.do(alice, "DELETE", "/api/tokens/"+token.ID, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("revoke: got %d, want 204", rec.Code)
+	}
+	rec = s.do(token.Token, "GET", "/api/projects", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("revoked token: got %d, want 401", rec.Code)
+	}
+
+	expiring := s.apiToken(alice, model.APITokenScopeRead)
+	_, err := s.app.DB.Exec(`UPDATE api_tokens SET expires_at = $1 WHERE id = $2`, time.Now().UTC().Add(-time.Minute), expiring.ID)
+	if err != nil {
+		t.Fatalf("expire token: %v", err)
+	}
+	rec = s.do(expiring.Token, "GET", "/api/projects", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expired token: got %d, want 401", rec.Code)
+	}
+}
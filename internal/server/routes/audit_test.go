@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+// auditEvents returns the audit events visible to the user of token.
+func (s *testServer) auditEvents(token, query string) []model.AuditEvent {
+	s.t.Helper()
+	var events []model.AuditEvent
+	s.create(token, "GET", "/api/audit"+query, nil, &events)
+	return events
+}
+
+func TestAuditRecordsChanges(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	f := s.seed(alice)
+
+	pod := f.pod
+	pod.Title = "frontend"
+	var updated model.Pod
+	s.create(alice, "PUT", "/api/pods", pod, &updated)
+
+	rec := s.do(alice, "PUT", "/api/pods/"+f.pod.ID+"/vars", model.PodEnvVarBulkUpdate{Vars: []model.PodEnvVar{{Key: "API_KEY", Value: "env-secret"}}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update env vars: got %d %s", rec.Code, rec.Body.String())
+	}
+
+	events := s.auditEvents(alice, "?target_id="+f.pod.ID)
+	var actions []string
+	for _, e := range events {
+		actions = append(actions, e.Action)
+		if e.ActorEmail != "alice@example.com" || e.ProjectID != f.project.ID || e.IP == "" {
+			t.Errorf("%s: missing actor, project or ip: %+v", e.Action, e)
+		}
+	}
+	// Newest first
+	want := []string{"env_vars.update", "pod.update", "volume.create", "pod.create"}
+	if strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Fatalf("got actions %v, want %v", actions, want)
+	}
+
+	change := events[1].Changes["title"]
+	if change.Before != "web" || change.After != "frontend" {
+		t.Errorf("pod.update title: got %+v, want web -> frontend", change)
+	}
+	if len(events[1].Changes) != 1 {
+		t.Errorf("pod.update: got changes %v, want only the title", events[1].Changes)
+	}
+
+	change = events[0].Changes["API_KEY"]
+	if change.Before != nil || change.After != model.AuditRedacted {
+		t.Errorf("env_vars.update: got %+v, want a redacted new value", change)
+	}
+
+	events = s.auditEvents(alice, "?target_type=git_token")
+	if len(events) != 1 || events[0].Action != "git_token.create" {
+		t.Fatalf("got git token events %+v, want the created token", events)
+	}
+
+	rec = s.do(alice, "GET", "/api/audit", nil)
+	if strings.Contains(rec.Body.String(), "env-secret") || strings.Contains(rec.Body.String(), "ghp_secret") {
+		t.Errorf("audit log leaks a secret: %s", rec.Body.String())
+	}
+}
+
+func TestAuditVisibility(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	carol := s.register("carol@example.com")
+	f := s.seed(bob)
+
+	invite := &model.ProjectInvite{ProjectID: f.project.ID, Email: "carol@example.com", Role: model.ProjectRoleViewer, InvitedBy: f.project.UserID}
+	err := s.app.MemberService.Invite(invite)
+	if err != nil {
+		t.Fatalf("invite: %v", err)
+	}
+	err = s.app.MemberService.AcceptInvite(invite.Token, "carol@example.com")
+	if err != nil {
+		t.Fatalf("accept invite: %v", err)
+	}
+
+	var token model.GitToken
+	s.create(carol, "POST", "/api/git-tokens", map[string]string{"name": "gitea", "provider": "gitea", "token": "secret"}, &token)
+
+	// The admin sees everything
+	if events := s.auditEvents(alice, "?actor=bob@example.com&action=pod."); len(events) != 1 {
+		t.Errorf("admin: got %d pod events of bob, want 1", len(events))
+	}
+
+	// Members see the project, but not the personal events of others
+	for _, e := range s.auditEvents(carol, "") {
+		if e.ProjectID != f.project.ID && e.ActorEmail != "carol@example.com" {
+			t.Errorf("carol sees %s of %s", e.Action, e.ActorEmail)
+		}
+	}
+	if events := s.auditEvents(carol, "?project_id="+f.project.ID+"&action=project.create"); len(events) != 1 {
+		t.Errorf("member: got %d project.create events, want 1", len(events))
+	}
+
+	// Others see nothing of the project
+	if events := s.auditEvents(bob, "?target_type=git_token&actor=carol@example.com"); len(events) != 0 {
+		t.Errorf("bob sees %d git token events of carol", len(events))
+	}
+
+	rec := s.do(bob, "GET", "/api/audit?since=yesterday", nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid since: got %d, want 400", rec.Code)
+	}
+}
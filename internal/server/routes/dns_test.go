@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+func TestDNSSettings(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	dir := filepath.Join(s.app.Cfg.TraefikConfigDir, "dns")
+
+	if rec := s.do(bob, "PUT", "/api/settings/dns", model.DNSSettings{Provider: "cloudflare"}); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin: got %d, want 403", rec.Code)
+	}
+	for _, body := range []model.DNSSettings{
+		{Provider: "godaddy"},
+		{Provider: "cloudflare"},
+		{Provider: "cloudflare", Credentials: map[string]string{"CF_DNS_API_TOKEN": "a\nb"}},
+	} {
+		if rec := s.do(alice, "PUT", "/api/settings/dns", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("set %+v: got %d, want 400", body, rec.Code)
+		}
+	}
+
+	var settings model.DNSSettings
+	s.create(alice, "PUT", "/api/settings/dns", model.DNSSettings{Provider: "rfc2136", Credentials: map[string]string{
+		"RFC2136_NAMESERVER":  "127.0.0.1:53",
+		"RFC2136_TSIG_KEY":    "deeploy",
+		"RFC2136_TSIG_SECRET": "tsig-secret",
+	}}, &settings)
+	if settings.Credentials["RFC2136_NAMESERVER"] != "127.0.0.1:53" || settings.Credentials["RFC2136_TSIG_SECRET"] != "" {
+		t.Errorf("got credentials %v, want the nameserver without the secret", settings.Credentials)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "RFC2136_TSIG_SECRET"))
+	if err != nil || string(data) != "tsig-secret" {
+		t.Errorf("secret file: got %q, %v", data, err)
+	}
+
+	// Empty secrets keep the saved ones
+	s.create(alice, "PUT", "/api/settings/dns", model.DNSSettings{Provider: "rfc2136", Credentials: map[string]string{
+		"RFC2136_NAMESERVER": "10.0.0.53:53",
+		"RFC2136_TSIG_KEY":   "deeploy",
+	}}, &settings)
+	data, err = os.ReadFile(filepath.Join(dir, "RFC2136_TSIG_SECRET"))
+	if err != nil || string(data) != "tsig-secret" {
+		t.Errorf("kept secret file: got %q, %v", data, err)
+	}
+
+	resolver, err := s.app.TraefikService.CertResolver()
+	if err != nil || resolver != "letsencrypt-dns-rfc2136" {
+		t.Errorf("got resolver %q, %v, want letsencrypt-dns-rfc2136", resolver, err)
+	}
+
+	// Switching providers drops the credentials of the previous one
+	s.create(alice, "PUT", "/api/settings/dns", model.DNSSettings{Provider: "cloudflare", Credentials: map[string]string{"CF_DNS_API_TOKEN": "cf-token"}}, &settings)
+	if _, err := os.Stat(filepath.Join(dir, "RFC2136_TSIG_SECRET")); !os.IsNotExist(err) {
+		t.Errorf("rfc2136 secret left after switching providers: %v", err)
+	}
+
+	rec := s.do(alice, "GET", "/api/audit?action=settings.dns", nil)
+	if strings.Contains(rec.Body.String(), "tsig-secret") || strings.Contains(rec.Body.String(), "cf-token") {
+		t.Errorf("audit log leaks a dns credential: %s", rec.Body.String())
+	}
+
+	if rec := s.do(alice, "DELETE", "/api/settings/dns", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: got %d", rec.Code)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("dns credentials left after delete: %v", err)
+	}
+	resolver, _ = s.app.TraefikService.CertResolver()
+	if resolver != "letsencrypt" {
+		t.Errorf("got resolver %q after delete, want letsencrypt", resolver)
+	}
+}
@@ -0,0 +1,278 @@
+package routes
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/service"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+func TestDomainTypes(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	f := s.seed(alice)
+	domains := "/api/pods/" + f.pod.ID + "/domains"
+
+	var wildcard, redirect, api model.PodDomain
+	s.create(alice, "POST", domains, map[string]any{"domain": "*.Example.com", "port": 8080}, &wildcard)
+	if wildcard.Type != model.DomainTypeWildcard || wildcard.Domain != "*.example.com" {
+		t.Errorf("got %+v, want a lowercase wildcard domain", wildcard)
+	}
+
+	s.create(alice, "POST", domains, map[string]any{"domain": "https://www.example.com/", "redirect_to": "example.com"}, &redirect)
+	if redirect.Type != model.DomainTypeRedirect || redirect.Domain != "www.example.com" || redirect.RedirectTo != "example.com" {
+		t.Errorf("got %+v, want a redirect from www.example.com", redirect)
+	}
+
+	s.create(alice, "POST", domains, map[string]any{"domain": "example.com", "path": "/api/", "port": 3000}, &api)
+	if api.Type != model.DomainTypeCustom || api.Path != "/api" {
+		t.Errorf("got %+v, want a custom domain on /api", api)
+	}
+
+	// The same host is free for other paths, the same route is taken
+	var root model.PodDomain
+	s.create(alice, "POST", domains, map[string]any{"domain": "example.com", "port": 3000}, &root)
+	if rec := s.do(alice, "POST", domains, map[string]any{"domain": "example.com", "path": "/api"}); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate route: got %d, want 409", rec.Code)
+	}
+
+	for _, body := range []map[string]any{
+		{"domain": "*.com"},
+		{"domain": "app.*.example.com"},
+		{"domain": "example.com/api"},
+		{"domain": "www.example.com", "redirect_to": "www.example.com"},
+		{"domain": "www.example.com", "redirect_to": "example.com", "path": "/docs"},
+		{"domain": "example.com", "path": "api"},
+		{"domain": "example.com", "type": "proxy"},
+	} {
+		if rec := s.do(alice, "POST", domains, body); rec.Code != http.StatusBadRequest {
+			t.Errorf("create %v: got %d, want 400", body, rec.Code)
+		}
+	}
+
+	// Without a target a redirect becomes a normal domain again
+	var updated model.PodDomain
+	s.create(alice, "PUT", domains+"/"+redirect.ID, map[string]any{"domain": "www.example.com", "port": 8080}, &updated)
+	if updated.Type != model.DomainTypeCustom || updated.RedirectTo != "" {
+		t.Errorf("got %+v, want a custom domain", updated)
+	}
+}
+
+// selfSigned returns a PEM certificate and key for the given hosts.
+func selfSigned(t *testing.T, hosts ...string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Test CA"},
+		DNSNames:     hosts,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(cert), string(keyPEM)
+}
+
+func TestDomainCertificates(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	f := s.seed(alice)
+	domains := "/api/pods/" + f.pod.ID + "/domains"
+	store := filepath.Join(s.app.Cfg.TraefikConfigDir, service.CertificatesFile)
+
+	var internal, corp model.PodDomain
+	s.create(alice, "POST", domains, map[string]any{"domain": "internal.lan", "ssl_enabled": false}, &internal)
+	if internal.SSLEnabled || !strings.HasPrefix(internal.URL, "http://") {
+		t.Errorf("got %+v, want a plain HTTP domain", internal)
+	}
+	s.create(alice, "POST", domains, map[string]any{"domain": "app.corp.com"}, &corp)
+	if !corp.SSLEnabled {
+		t.Errorf("got %+v, want SSL on by default", corp)
+	}
+
+	certificate := domains + "/" + corp.ID + "/certificate"
+	other, otherKey := selfSigned(t, "other.com")
+	if rec := s.do(alice, "PUT", certificate, model.CertificateUpload{Certificate: other, PrivateKey: otherKey}); rec.Code != http.StatusBadRequest {
+		t.Errorf("certificate of another host: got %d, want 400", rec.Code)
+	}
+	cert, key := selfSigned(t, "*.corp.com")
+	if rec := s.do(alice, "PUT", certificate, model.CertificateUpload{Certificate: cert, PrivateKey: otherKey}); rec.Code != http.StatusBadRequest {
+		t.Errorf("mismatched key: got %d, want 400", rec.Code)
+	}
+
+	rec := s.do(alice, "PUT", certificate, model.CertificateUpload{Certificate: cert, PrivateKey: key})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("upload: got %d %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "PRIVATE KEY") {
+		t.Errorf("upload response leaks the key: %s", rec.Body.String())
+	}
+
+	var listed []model.PodDomain
+	s.create(alice, "GET", domains, nil, &listed)
+	for _, d := range listed {
+		if (d.ID == corp.ID) != (d.Certificate != nil) {
+			t.Errorf("%s: got certificate %+v", d.Domain, d.Certificate)
+		}
+		if d.Certificate != nil && (d.Certificate.Issuer != "Test CA" || d.Certificate.ExpiresAt.Before(time.Now())) {
+			t.Errorf("got certificate %+v, want issuer and expiry", d.Certificate)
+		}
+	}
+
+	data, err := os.ReadFile(store)
+	if err != nil {
+		t.Fatalf("read tls store: %v", err)
+	}
+	keyLine := strings.Split(key, "\n")[1]
+	if !strings.Contains(string(data), "keyFile: |") || !strings.Contains(string(data), keyLine) {
+		t.Errorf("tls store misses the key:\n%s", data)
+	}
+
+	if rec := s.do(alice, "DELETE", certificate, nil); rec.Code != http.StatusOK {
+		t.Fatalf("delete: got %d %s", rec.Code, rec.Body.String())
+	}
+	if _, err := os.Stat(store); !os.IsNotExist(err) {
+		t.Errorf("tls store still exists after the last certificate was deleted: %v", err)
+	}
+}
+
+func TestDomainVerification(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	f := s.seed(alice)
+	domains := "/api/pods/" + f.pod.ID + "/domains"
+
+	// New domains are pending until checked, the lookup is not part of creating them
+	var local, missing model.PodDomain
+	s.create(alice, "POST", domains, map[string]any{"domain": "localhost"}, &local)
+	if local.Status != model.DomainStatusPending || local.VerifiedAt != nil || local.DNSRecord == nil {
+		t.Errorf("got %+v, want a pending domain with its record", local)
+	}
+
+	// The test server's public IP is 127.0.0.1, localhost resolves to it
+	var verified model.PodDomain
+	s.create(alice, "POST", domains+"/"+local.ID+"/verify", nil, &verified)
+	if verified.Status != model.DomainStatusVerified || verified.VerifiedAt == nil || verified.DNSRecord != nil {
+		t.Errorf("got %+v, want a verified domain", verified)
+	}
+
+	s.create(alice, "POST", domains, map[string]any{"domain": "deeploy-test.invalid"}, &missing)
+	s.create(alice, "POST", domains+"/"+missing.ID+"/verify", nil, &missing)
+	if missing.Status != model.DomainStatusFailed || missing.VerifiedAt != nil || missing.StatusMessage == "" {
+		t.Errorf("got %+v, want a failed domain with a reason", missing)
+	}
+	want := model.DNSRecord{Type: "A", Name: "deeploy-test.invalid", Value: "127.0.0.1"}
+	if missing.DNSRecord == nil || *missing.DNSRecord != want {
+		t.Errorf("got record %+v, want %+v", missing.DNSRecord, want)
+	}
+
+	// Server admins route domains behind a CDN without the check
+	var skipped model.PodDomain
+	s.create(alice, "POST", domains+"/"+missing.ID+"/skip-verification", nil, &skipped)
+	if skipped.Status != model.DomainStatusVerified || skipped.VerifiedAt == nil || skipped.DNSRecord != nil {
+		t.Errorf("got %+v, want the skipped domain verified", skipped)
+	}
+	events := s.auditEvents(alice, "?action=domain.skip_verification")
+	if len(events) != 1 || events[0].TargetID != missing.ID || events[0].TargetName != "deeploy-test.invalid" {
+		t.Errorf("got audit events %+v, want the skip with the domain", events)
+	}
+
+	// A new host is pending again, a path change keeps the status
+	var moved model.PodDomain
+	s.create(alice, "PUT", domains+"/"+missing.ID, map[string]any{"domain": "localhost", "path": "/api"}, &moved)
+	if moved.Status != model.DomainStatusPending {
+		t.Errorf("got %+v, want the moved domain pending", moved)
+	}
+	var repathed model.PodDomain
+	s.create(alice, "PUT", domains+"/"+local.ID, map[string]any{"domain": "localhost", "path": "/web"}, &repathed)
+	if repathed.Status != model.DomainStatusVerified {
+		t.Errorf("got %+v, want the domain still verified", repathed)
+	}
+
+	// Generated domains resolve to the IP they embed and need no check
+	var auto model.PodDomain
+	s.create(alice, "POST", domains+"/generate", map[string]any{"port": 3000}, &auto)
+	if auto.Status != model.DomainStatusVerified {
+		t.Errorf("got %+v, want a verified auto domain", auto)
+	}
+
+	var record model.DNSRecord
+	s.create(alice, "GET", domains+"/dns-record?domain=*.Example.com", nil, &record)
+	if record != (model.DNSRecord{Type: "A", Name: "*.example.com", Value: "127.0.0.1"}) {
+		t.Errorf("got record %+v, want an A record for *.example.com", record)
+	}
+
+	if rec := s.do(alice, "POST", "/api/pods/"+f.pod.ID+"/domains/unknown/verify", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown domain: got %d, want 404", rec.Code)
+	}
+}
+
+func TestDomainsBelongToOneProject(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	a := s.seed(alice)
+	b := s.seed(bob)
+	aliceDomains := "/api/pods/" + a.pod.ID + "/domains"
+	bobDomains := "/api/pods/" + b.pod.ID + "/domains"
+
+	var shop model.PodDomain
+	s.create(alice, "POST", aliceDomains, map[string]any{"domain": "shop.example.com", "port": 3000}, &shop)
+	s.create(alice, "POST", aliceDomains, map[string]any{"domain": "*.apps.example.com", "port": 3000}, &model.PodDomain{})
+
+	// Bob can't take the host, a path of it or an overlapping wildcard
+	for _, body := range []map[string]any{
+		{"domain": "shop.example.com", "port": 3000},
+		{"domain": "shop.example.com", "path": "/api", "port": 3000},
+		{"domain": "*.example.com", "port": 3000},
+		{"domain": "admin.apps.example.com", "port": 3000},
+		{"domain": "*.apps.example.com", "path": "/api", "port": 3000},
+	} {
+		if rec := s.do(bob, "POST", bobDomains, body); rec.Code != http.StatusConflict {
+			t.Errorf("bob adding %v: got %d %q, want 409", body, rec.Code, rec.Body.String())
+		}
+	}
+	var other model.PodDomain
+	s.create(bob, "POST", bobDomains, map[string]any{"domain": "blog.example.org", "port": 3000}, &other)
+	if rec := s.do(bob, "PUT", bobDomains+"/"+other.ID, map[string]any{"domain": "shop.example.com", "path": "/blog", "port": 3000}); rec.Code != http.StatusConflict {
+		t.Errorf("bob moving a domain to alice's host: got %d, want 409", rec.Code)
+	}
+
+	// Only server admins skip the DNS check, even on their own projects
+	if rec := s.do(bob, "POST", bobDomains+"/"+other.ID+"/skip-verification", nil); rec.Code != http.StatusForbidden {
+		t.Errorf("owner skipping the DNS check: got %d, want 403", rec.Code)
+	}
+
+	// Hosts a wildcard doesn't match stay free
+	s.create(bob, "POST", bobDomains, map[string]any{"domain": "example.com", "port": 3000}, &model.PodDomain{})
+	s.create(bob, "POST", bobDomains, map[string]any{"domain": "deep.admin.apps.example.com", "port": 3000}, &model.PodDomain{})
+
+	// Other pods of the project share the host
+	var pod model.Pod
+	s.create(alice, "POST", "/api/pods", map[string]string{"title": "api", "project_id": a.project.ID}, &pod)
+	s.create(alice, "POST", "/api/pods/"+pod.ID+"/domains", map[string]any{"domain": "shop.example.com", "path": "/api", "port": 3000}, &model.PodDomain{})
+}
@@ -16,15 +16,26 @@ func Setup(app *app.App) http.Handler {
 	mux := http.NewServeMux()
 
 	// Handlers
-	auth := mw.NewAuthMiddleware(app.UserService)
-	userHandler := handlers.NewUserHandler(app.UserService)
-	projectHandler := handlers.NewProjectHandler(app.ProjectService, app.PodService)
-	podHandler := handlers.NewPodHandler(app.PodService)
-	gitTokenHandler := handlers.NewGitTokenHandler(app.GitTokenService)
-	deployHandler := handlers.NewDeployHandler(app.DeployService)
-	podDomainHandler := handlers.NewPodDomainHandler(app.PodDomainService, app.PodService, app.Cfg.IsDevelopment())
-	podEnvVarHandler := handlers.NewPodEnvVarHandler(app.PodEnvVarService, app.PodService)
-	serverSettingsHandler := handlers.NewServerSettingsHandler(app.TraefikService)
+	auth := mw.NewAuthMiddleware(app.UserService, app.APITokenService, app.SessionService)
+	userHandler := handlers.NewUserHandler(app.UserService, app.MemberService, app.SessionService, app.TwoFactorService)
+	memberHandler := handlers.NewMemberHandler(app.MemberService, app.AuditService)
+	projectHandler := handlers.NewProjectHandler(app.ProjectService, app.PodService, app.DatabaseService, app.MemberService, app.AuditService)
+	podHandler := handlers.NewPodHandler(app.PodService, app.MemberService, app.GitTokenService, app.RegistryCredentialService, app.CertificateService, app.AuditService)
+	gitTokenHandler := handlers.NewGitTokenHandler(app.GitTokenService, app.AuditService)
+	apiTokenHandler := handlers.NewAPITokenHandler(app.APITokenService, app.AuditService)
+	sessionHandler := handlers.NewSessionHandler(app.SessionService, app.AuditService)
+	twoFactorHandler := handlers.NewTwoFactorHandler(app.TwoFactorService, app.AuditService)
+	registryCredentialHandler := handlers.NewRegistryCredentialHandler(app.RegistryCredentialService, app.AuditService)
+	deployHandler := handlers.NewDeployHandler(app.DeployService, app.MemberService, app.AuditService)
+	podDomainHandler := handlers.NewPodDomainHandler(app.PodDomainService, app.PodService, app.CertificateService, app.MemberService, app.AuditService, app.Cfg.IsDevelopment())
+	podVolumeHandler := handlers.NewPodVolumeHandler(app.PodVolumeService, app.MemberService, app.AuditService)
+	databaseHandler := handlers.NewDatabaseHandler(app.DatabaseService, app.PodService, app.MemberService, app.AuditService)
+	backupHandler := handlers.NewBackupHandler(app.BackupService, app.PodVolumeService, app.MemberService)
+	podEnvVarHandler := handlers.NewPodEnvVarHandler(app.PodEnvVarService, app.PodService, app.MemberService, app.AuditService)
+	webhookHandler := handlers.NewWebhookHandler(app.PodWebhookService, app.PodService, app.DeployService, app.MemberService, app.AuditService)
+	serverSettingsHandler := handlers.NewServerSettingsHandler(app.TraefikService, app.AuditService)
+	serverBackupHandler := handlers.NewServerBackupHandler(app.ServerBackupService, app.DatabaseService, app.CertificateService, app.TraefikService, app.AuditService)
+	auditHandler := handlers.NewAuditHandler(app.AuditService)
 
 	// Assets
 	setupAssets(mux, app.Cfg.IsDevelopment())
@@ -33,11 +44,18 @@ func Setup(app *app.App) http.Handler {
 	mux.HandleFunc("GET /", userHandler.LandingView)
 
 	// Auth
-	mux.HandleFunc("GET /auth", mw.RequireGuest(userHandler.AuthView))
+	mux.HandleFunc("GET /auth", auth.RequireGuest(userHandler.AuthView))
 	mux.HandleFunc("POST /login", userHandler.Login)
+	mux.HandleFunc("POST /login/two-factor", userHandler.LoginTwoFactor)
 	mux.HandleFunc("POST /register", userHandler.Register)
 	mux.HandleFunc("GET /logout", userHandler.Logout)
 	mux.HandleFunc("GET /api/auth/poll", userHandler.PollCLISession)
+	mux.HandleFunc("POST /api/auth/refresh", userHandler.Refresh)
+
+	// Account
+	mux.HandleFunc("GET /account/two-factor", auth.RequireAuth(twoFactorHandler.View))
+	mux.HandleFunc("POST /account/two-factor", auth.RequireAuth(twoFactorHandler.Enable))
+	mux.HandleFunc("POST /account/two-factor/disable", auth.RequireAuth(twoFactorHandler.Disable))
 
 	// Projects
 	mux.HandleFunc("POST /api/projects", auth.Auth(projectHandler.Create))
@@ -46,6 +64,13 @@ func Setup(app *app.App) http.Handler {
 	mux.HandleFunc("PUT /api/projects", auth.Auth(projectHandler.Update))
 	mux.HandleFunc("DELETE /api/projects/{id}", auth.Auth(projectHandler.Delete))
 
+	// Project Members
+	mux.HandleFunc("GET /api/projects/{id}/members", auth.Auth(memberHandler.List))
+	mux.HandleFunc("PUT /api/projects/{id}/members/{userId}", auth.Auth(memberHandler.UpdateRole))
+	mux.HandleFunc("DELETE /api/projects/{id}/members/{userId}", auth.Auth(memberHandler.Remove))
+	mux.HandleFunc("POST /api/projects/{id}/invites", auth.Auth(memberHandler.Invite))
+	mux.HandleFunc("DELETE /api/projects/{id}/invites/{inviteId}", auth.Auth(memberHandler.RevokeInvite))
+
 	// Pods
 	mux.HandleFunc("POST /api/pods", auth.Auth(podHandler.Create))
 	mux.HandleFunc("GET /api/pods", auth.Auth(podHandler.PodsByUser))
@@ -59,27 +84,93 @@ func Setup(app *app.App) http.Handler {
 	mux.HandleFunc("POST /api/pods/{id}/stop", auth.Auth(deployHandler.Stop))
 	mux.HandleFunc("POST /api/pods/{id}/restart", auth.Auth(deployHandler.Restart))
 	mux.HandleFunc("GET /api/pods/{id}/logs", auth.Auth(deployHandler.Logs))
+	mux.HandleFunc("GET /api/pods/{id}/logs/stream", auth.Auth(deployHandler.StreamLogs))
+	mux.HandleFunc("GET /api/pods/{id}/deployments", auth.Auth(deployHandler.Deployments))
+	mux.HandleFunc("GET /api/pods/{id}/deployments/{deploymentId}/logs", auth.Auth(deployHandler.DeploymentLogs))
+	mux.HandleFunc("POST /api/pods/{id}/deployments/{deploymentId}/rollback", auth.Auth(deployHandler.Rollback))
 
 	// Pod Domains
 	mux.HandleFunc("POST /api/pods/{id}/domains", auth.Auth(podDomainHandler.Create))
 	mux.HandleFunc("POST /api/pods/{id}/domains/generate", auth.Auth(podDomainHandler.Generate))
 	mux.HandleFunc("GET /api/pods/{id}/domains", auth.Auth(podDomainHandler.List))
+	mux.HandleFunc("GET /api/pods/{id}/domains/dns-record", auth.Auth(podDomainHandler.DNSRecord))
 	mux.HandleFunc("PUT /api/pods/{id}/domains/{domainId}", auth.Auth(podDomainHandler.Update))
 	mux.HandleFunc("DELETE /api/pods/{id}/domains/{domainId}", auth.Auth(podDomainHandler.Delete))
+	mux.HandleFunc("POST /api/pods/{id}/domains/{domainId}/verify", auth.Auth(podDomainHandler.Verify))
+	mux.HandleFunc("POST /api/pods/{id}/domains/{domainId}/skip-verification", auth.Auth(podDomainHandler.SkipVerification))
+	mux.HandleFunc("PUT /api/pods/{id}/domains/{domainId}/certificate", auth.Auth(podDomainHandler.UploadCertificate))
+	mux.HandleFunc("DELETE /api/pods/{id}/domains/{domainId}/certificate", auth.Auth(podDomainHandler.DeleteCertificate))
+
+	// Pod Volumes
+	mux.HandleFunc("POST /api/pods/{id}/volumes", auth.Auth(podVolumeHandler.Create))
+	mux.HandleFunc("GET /api/pods/{id}/volumes", auth.Auth(podVolumeHandler.List))
+	mux.HandleFunc("PUT /api/pods/{id}/volumes/{volumeId}", auth.Auth(podVolumeHandler.Update))
+	mux.HandleFunc("DELETE /api/pods/{id}/volumes/{volumeId}", auth.Auth(podVolumeHandler.Delete))
+
+	// Databases
+	mux.HandleFunc("POST /api/databases", auth.Auth(databaseHandler.Create))
+	mux.HandleFunc("GET /api/databases", auth.Auth(databaseHandler.DatabasesByUser))
+	mux.HandleFunc("GET /api/projects/{id}/databases", auth.Auth(databaseHandler.DatabasesByProject))
+	mux.HandleFunc("GET /api/databases/{id}", auth.Auth(databaseHandler.Database))
+	mux.HandleFunc("DELETE /api/databases/{id}", auth.Auth(databaseHandler.Delete))
+
+	// Pod Databases
+	mux.HandleFunc("GET /api/pods/{id}/databases", auth.Auth(databaseHandler.Links))
+	mux.HandleFunc("POST /api/pods/{id}/databases", auth.Auth(databaseHandler.Link))
+	mux.HandleFunc("DELETE /api/pods/{id}/databases/{databaseId}", auth.Auth(databaseHandler.Unlink))
+
+	// Backups
+	mux.HandleFunc("GET /api/databases/{id}/backups", auth.Auth(backupHandler.Backups))
+	mux.HandleFunc("POST /api/databases/{id}/backups", auth.Auth(backupHandler.Run))
+	mux.HandleFunc("PUT /api/databases/{id}/backups/schedule", auth.Auth(backupHandler.SaveSchedule))
+	mux.HandleFunc("GET /api/pods/{id}/volumes/{volumeId}/backups", auth.Auth(backupHandler.Backups))
+	mux.HandleFunc("POST /api/pods/{id}/volumes/{volumeId}/backups", auth.Auth(backupHandler.Run))
+	mux.HandleFunc("PUT /api/pods/{id}/volumes/{volumeId}/backups/schedule", auth.Auth(backupHandler.SaveSchedule))
+	mux.HandleFunc("POST /api/backups/{id}/restore", auth.Auth(backupHandler.Restore))
+	mux.HandleFunc("DELETE /api/backups/{id}", auth.Auth(backupHandler.Delete))
 
 	// Pod Env Vars
 	mux.HandleFunc("GET /api/pods/{id}/vars", auth.Auth(podEnvVarHandler.List))
 	mux.HandleFunc("PUT /api/pods/{id}/vars", auth.Auth(podEnvVarHandler.BulkUpdate))
 
+	// Pod Webhooks
+	mux.HandleFunc("GET /api/pods/{id}/webhook", auth.Auth(webhookHandler.Webhook))
+	mux.HandleFunc("POST /api/pods/{id}/webhook/regenerate", auth.Auth(webhookHandler.RegenerateSecret))
+
+	// Webhooks (public - authenticated by the pod webhook secret)
+	mux.HandleFunc("POST /api/webhooks/{podId}", webhookHandler.Receive)
+
 	// Git Tokens
 	mux.HandleFunc("POST /api/git-tokens", auth.Auth(gitTokenHandler.Create))
 	mux.HandleFunc("GET /api/git-tokens", auth.Auth(gitTokenHandler.List))
 	mux.HandleFunc("DELETE /api/git-tokens/{id}", auth.Auth(gitTokenHandler.Delete))
 
-	// Server Settings
-	mux.HandleFunc("GET /api/settings/domain", auth.Auth(serverSettingsHandler.GetServerDomain))
-	mux.HandleFunc("PUT /api/settings/domain", auth.Auth(serverSettingsHandler.SetServerDomain))
-	mux.HandleFunc("DELETE /api/settings/domain", auth.Auth(serverSettingsHandler.DeleteServerDomain))
+	// API Tokens
+	mux.HandleFunc("POST /api/tokens", auth.Auth(apiTokenHandler.Create))
+	mux.HandleFunc("GET /api/tokens", auth.Auth(apiTokenHandler.List))
+	mux.HandleFunc("DELETE /api/tokens/{id}", auth.Auth(apiTokenHandler.Delete))
+
+	// Sessions
+	mux.HandleFunc("GET /api/sessions", auth.Auth(sessionHandler.List))
+	mux.HandleFunc("DELETE /api/sessions/{id}", auth.Auth(sessionHandler.Delete))
+
+	// Registry Credentials
+	mux.HandleFunc("POST /api/registry-credentials", auth.Auth(registryCredentialHandler.Create))
+	mux.HandleFunc("GET /api/registry-credentials", auth.Auth(registryCredentialHandler.List))
+	mux.HandleFunc("DELETE /api/registry-credentials/{id}", auth.Auth(registryCredentialHandler.Delete))
+
+	// Server Settings (server admin only)
+	mux.HandleFunc("GET /api/settings/domain", auth.Admin(serverSettingsHandler.GetServerDomain))
+	mux.HandleFunc("PUT /api/settings/domain", auth.Admin(serverSettingsHandler.SetServerDomain))
+	mux.HandleFunc("DELETE /api/settings/domain", auth.Admin(serverSettingsHandler.DeleteServerDomain))
+	mux.HandleFunc("GET /api/settings/dns", auth.Admin(serverSettingsHandler.GetDNSSettings))
+	mux.HandleFunc("PUT /api/settings/dns", auth.Admin(serverSettingsHandler.SetDNSSettings))
+	mux.HandleFunc("DELETE /api/settings/dns", auth.Admin(serverSettingsHandler.DeleteDNSSettings))
+	mux.HandleFunc("GET /api/settings/backup", auth.Admin(serverBackupHandler.Backup))
+	mux.HandleFunc("POST /api/settings/restore", auth.Admin(serverBackupHandler.Restore))
+
+	// Audit log (admins see everything, others their own and their projects' events)
+	mux.HandleFunc("GET /api/audit", auth.Auth(auditHandler.List))
 
 	// Health (public - used by TUI for connection check + heartbeat)
 	mux.HandleFunc("GET /api/health", healthHandler)
@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+// refresh renews tokens the way the TUI does, without an access token.
+func (s *testServer) refresh(refreshToken string) (*model.AuthTokens, int) {
+	s.t.Helper()
+	rec := s.do("", "POST", "/api/auth/refresh", model.RefreshRequest{RefreshToken: refreshToken})
+	if rec.Code != http.StatusOK {
+		return nil, rec.Code
+	}
+	var tokens model.AuthTokens
+	err := json.NewDecoder(rec.Body).Decode(&tokens)
+	if err != nil {
+		s.t.Fatalf("decode tokens: %v", err)
+	}
+	return &tokens, rec.Code
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	s := newTestServer(t)
+	first := s.signIn("alice@example.com")
+
+	second, code := s.refresh(first.RefreshToken)
+	if code != http.StatusOK {
+		t.Fatalf("refresh: got %d, want 200", code)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Errorf("refresh token was not replaced")
+	}
+	if rec := s.do(second.Token, "GET", "/api/projects", nil); rec.Code != http.StatusOK {
+		t.Errorf("refreshed access token: got %d, want 200", rec.Code)
+	}
+
+	// Using a replaced refresh token again revokes the session
+	if _, code := s.refresh(first.RefreshToken); code != http.StatusUnauthorized {
+		t.Errorf("reused refresh token: got %d, want 401", code)
+	}
+	if _, code := s.refresh(second.RefreshToken); code != http.StatusUnauthorized {
+		t.Errorf("refresh after reuse: got %d, want 401", code)
+	}
+	if rec := s.do(second.Token, "GET", "/api/projects", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("access token after reuse: got %d, want 401", rec.Code)
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.signIn("alice@example.com")
+	bob := s.register("bob@example.com")
+
+	var sessions []model.Session
+	s.create(alice.Token, "GET", "/api/sessions", nil, &sessions)
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Fatalf("got sessions %+v, want the current one", sessions)
+	}
+	if sessions[0].Client != "TUI" || sessions[0].IP == "" {
+		t.Errorf("session has no client or ip: %+v", sessions[0])
+	}
+
+	rec := s.do(bob, "DELETE", "/api/sessions/"+sessions[0].ID, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("bob revokes alice's session: got %d, want 404", rec.Code)
+	}
+
+	rec = s.do(alice.Token, "DELETE", "/api/sessions/"+sessions[0].ID, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("revoke: got %d, want 204", rec.Code)
+	}
+	if rec := s.do(alice.Token, "GET", "/api/projects", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("revoked access token: got %d, want 401", rec.Code)
+	}
+	if _, code := s.refresh(alice.RefreshToken); code != http.StatusUnauthorized {
+		t.Errorf("revoked refresh token: got %d, want 401", code)
+	}
+}
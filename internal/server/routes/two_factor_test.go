@@ -0,0 +1,149 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/auth"
+	"github.com/pquerna/otp/totp"
+)
+
+var challengePattern = regexp.MustCompile(`name="challenge" value="([0-9a-f]+)"`)
+
+// form posts a web form like a browser does.
+func (s *testServer) form(path string, values url.Values) *httptest.ResponseRecorder {
+	s.t.Helper()
+	req := httptest.NewRequest("POST", path, strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// enableTwoFactor turns two-factor on for a user and returns its secret and recovery codes.
+func enableTwoFactor(t *testing.T, s *testServer, email string) (string, []string) {
+	t.Helper()
+	var userID string
+	err := s.app.DB.Get(&userID, `SELECT id FROM users WHERE email = $1`, email)
+	if err != nil {
+		t.Fatalf("user id: %v", err)
+	}
+
+	setup, err := s.app.TwoFactorService.Setup(userID)
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	// A code of the previous time step, the current one is left for signing in
+	code, err := totp.GenerateCode(setup.Secret, time.Now().Add(-30*time.Second))
+	if err != nil {
+		t.Fatalf("code: %v", err)
+	}
+	recoveryCodes, err := s.app.TwoFactorService.Enable(userID, code)
+	if err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+	return setup.Secret, recoveryCodes
+}
+
+// challenge signs in with the password and returns the challenge of the second step.
+func (s *testServer) challenge(query string) string {
+	s.t.Helper()
+	rec := s.form("/login"+query, url.Values{"email": {"alice@example.com"}, "password": {"password123"}})
+	if rec.Header().Get("Set-Cookie") != "" {
+		s.t.Fatalf("password alone started a session")
+	}
+	match := challengePattern.FindStringSubmatch(rec.Body.String())
+	if match == nil {
+		s.t.Fatalf("no two-factor step after the password: %s", rec.Body.String())
+	}
+	return match[1]
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	s := newTestServer(t)
+	s.register("alice@example.com")
+	secret, recoveryCodes := enableTwoFactor(t, s, "alice@example.com")
+
+	challenge := s.challenge("")
+	rec := s.form("/login/two-factor", url.Values{"challenge": {challenge}, "code": {"000000"}})
+	if !strings.Contains(rec.Body.String(), "Invalid code") || rec.Header().Get("Set-Cookie") != "" {
+		t.Errorf("wrong code: got %d without the error", rec.Code)
+	}
+
+	code, _ := totp.GenerateCode(secret, time.Now())
+	rec = s.form("/login/two-factor", url.Values{"challenge": {challenge}, "code": {code}})
+	if rec.Code != http.StatusSeeOther || !strings.Contains(rec.Header().Get("Set-Cookie"), "token=") {
+		t.Errorf("right code: got %d, want a session cookie", rec.Code)
+	}
+
+	// A challenge signs in once
+	rec = s.form("/login/two-factor", url.Values{"challenge": {challenge}, "code": {code}})
+	if rec.Header().Get("Set-Cookie") != "" {
+		t.Errorf("used challenge started another session")
+	}
+
+	// A code signs in once, also within its time window
+	rec = s.form("/login/two-factor", url.Values{"challenge": {s.challenge("")}, "code": {code}})
+	if !strings.Contains(rec.Body.String(), "Invalid code") || rec.Header().Get("Set-Cookie") != "" {
+		t.Errorf("replayed code started another session")
+	}
+	previous, _ := totp.GenerateCode(secret, time.Now().Add(-30*time.Second))
+	rec = s.form("/login/two-factor", url.Values{"challenge": {s.challenge("")}, "code": {previous}})
+	if !strings.Contains(rec.Body.String(), "Invalid code") {
+		t.Errorf("code older than the last used one was accepted")
+	}
+
+	// Recovery codes work once, with or without the dash
+	recovery := strings.ReplaceAll(recoveryCodes[0], "-", "")
+	rec = s.form("/login/two-factor", url.Values{"challenge": {s.challenge("")}, "code": {recovery}})
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("recovery code: got %d, want 303", rec.Code)
+	}
+	rec = s.form("/login/two-factor", url.Values{"challenge": {s.challenge("")}, "code": {recoveryCodes[0]}})
+	if !strings.Contains(rec.Body.String(), "Invalid code") {
+		t.Errorf("used recovery code was accepted again")
+	}
+}
+
+func TestTwoFactorCLILogin(t *testing.T) {
+	s := newTestServer(t)
+	s.register("alice@example.com")
+	secret, _ := enableTwoFactor(t, s, "alice@example.com")
+
+	query := "?cli=true&session=tui-session&invite="
+	challenge := s.challenge(query)
+	if _, ready := auth.GetSessionTokens("tui-session"); ready {
+		t.Fatalf("tui got tokens before the second factor")
+	}
+
+	code, _ := totp.GenerateCode(secret, time.Now())
+	s.form("/login/two-factor"+query, url.Values{"challenge": {challenge}, "code": {code}})
+	tokens, ready := auth.GetSessionTokens("tui-session")
+	if !ready || tokens.RefreshToken == "" {
+		t.Fatalf("tui got no tokens after the second factor")
+	}
+	if rec := s.do(tokens.Token, "GET", "/api/projects", nil); rec.Code != http.StatusOK {
+		t.Errorf("tui token: got %d, want 200", rec.Code)
+	}
+}
+
+func TestTwoFactorChallengeAttempts(t *testing.T) {
+	s := newTestServer(t)
+	s.register("alice@example.com")
+	secret, _ := enableTwoFactor(t, s, "alice@example.com")
+
+	challenge := s.challenge("")
+	for range 5 {
+		s.form("/login/two-factor", url.Values{"challenge": {challenge}, "code": {"000000"}})
+	}
+	code, _ := totp.GenerateCode(secret, time.Now())
+	rec := s.form("/login/two-factor", url.Values{"challenge": {challenge}, "code": {code}})
+	if rec.Header().Get("Set-Cookie") != "" {
+		t.Errorf("challenge accepted a code after 5 wrong ones")
+	}
+}
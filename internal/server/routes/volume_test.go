@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+func TestBindMountsNeedAdmin(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com") // first user, server admin
+	bob := s.register("bob@example.com")
+	a := s.seed(alice)
+	b := s.seed(bob)
+
+	bind := map[string]string{"type": model.VolumeTypeBind, "source": "/srv/app", "mount_path": "/srv"}
+	if rec := s.do(bob, "POST", "/api/pods/"+b.pod.ID+"/volumes", bind); rec.Code != http.StatusForbidden {
+		t.Errorf("owner without admin: got %d, want 403", rec.Code)
+	}
+	if rec := s.do(bob, "PUT", "/api/pods/"+b.pod.ID+"/volumes/"+b.volume.ID, bind); rec.Code != http.StatusForbidden {
+		t.Errorf("owner without admin turning a volume into a bind mount: got %d, want 403", rec.Code)
+	}
+
+	var volume model.PodVolume
+	s.create(alice, "POST", "/api/pods/"+a.pod.ID+"/volumes", bind, &volume)
+	if volume.Type != model.VolumeTypeBind || volume.Source != "/srv/app" {
+		t.Errorf("got %+v, want a bind mount of /srv/app", volume)
+	}
+
+	// Not even admins mount the host
+	for _, source := range []string{"/", "/etc", "/var/run/docker.sock", "/opt/deeploy/data/../traefik"} {
+		body := map[string]string{"type": model.VolumeTypeBind, "source": source, "mount_path": "/host"}
+		if rec := s.do(alice, "POST", "/api/pods/"+a.pod.ID+"/volumes", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("bind mount of %s: got %d, want 400", source, rec.Code)
+		}
+	}
+}
+
+func TestVolumesBelongToTheirPod(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	bob := s.register("bob@example.com")
+	a := s.seed(alice)
+	b := s.seed(bob)
+
+	// Both seeds name their volume uploads, each gets its own Docker volume
+	if a.volume.VolumeName != "deeploy-"+a.pod.ID+"-uploads" || b.volume.VolumeName != "deeploy-"+b.pod.ID+"-uploads" {
+		t.Errorf("got %q and %q, want volumes namespaced by pod", a.volume.VolumeName, b.volume.VolumeName)
+	}
+
+	// Names of other volumes only name a volume of the own pod
+	for _, source := range []string{a.volume.VolumeName, "deeploy-db-" + a.database.ID, "postgres_data", "letsencrypt_certs"} {
+		var volume model.PodVolume
+		s.create(bob, "POST", "/api/pods/"+b.pod.ID+"/volumes", map[string]string{"source": source, "mount_path": "/" + source}, &volume)
+		if volume.VolumeName != "deeploy-"+b.pod.ID+"-"+source {
+			t.Errorf("%s: got Docker volume %q, want one of bob's pod", source, volume.VolumeName)
+		}
+	}
+
+	// Renaming starts a new volume, other changes keep the data
+	var moved, renamed model.PodVolume
+	s.create(bob, "PUT", "/api/pods/"+b.pod.ID+"/volumes/"+b.volume.ID, map[string]any{"source": "uploads", "mount_path": "/moved", "read_only": true}, &moved)
+	if moved.VolumeName != b.volume.VolumeName {
+		t.Errorf("got %q, want the volume kept", moved.VolumeName)
+	}
+	s.create(bob, "PUT", "/api/pods/"+b.pod.ID+"/volumes/"+b.volume.ID, map[string]any{"source": a.volume.VolumeName, "mount_path": "/moved"}, &renamed)
+	if renamed.VolumeName != "deeploy-"+b.pod.ID+"-"+a.volume.VolumeName {
+		t.Errorf("got %q, want a volume of bob's pod", renamed.VolumeName)
+	}
+}
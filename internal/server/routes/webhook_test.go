@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+func TestWebhookSecret(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice@example.com")
+	f := s.seed(alice)
+	webhook := "/api/pods/" + f.pod.ID + "/webhook"
+
+	// Reading never creates a secret
+	for range 2 {
+		if rec := s.do(alice, "GET", webhook, nil); rec.Code != http.StatusNotFound {
+			t.Fatalf("get before enabling: got %d, want 404", rec.Code)
+		}
+	}
+
+	var enabled, loaded, rotated model.PodWebhook
+	s.create(alice, "POST", webhook+"/regenerate", nil, &enabled)
+	if enabled.Secret == "" || enabled.URL == "" {
+		t.Fatalf("got %+v, want a secret and URL", enabled)
+	}
+	s.create(alice, "GET", webhook, nil, &loaded)
+	if loaded.Secret != enabled.Secret {
+		t.Errorf("got secret %q, want %q", loaded.Secret, enabled.Secret)
+	}
+
+	s.create(alice, "POST", webhook+"/regenerate", nil, &rotated)
+	if rotated.Secret == enabled.Secret {
+		t.Errorf("regenerate kept the secret %q", rotated.Secret)
+	}
+}
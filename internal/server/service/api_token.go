@@ -0,0 +1,136 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+// lastUsedInterval limits how often the last use of a token is written.
+const lastUsedInterval = time.Minute
+
+type APITokenServiceInterface interface {
+	Create(userID string, req model.APITokenCreate) (*model.APIToken, error)
+	APIToken(id string) (*model.APIToken, error)
+	APITokensByUser(userID string) ([]model.APIToken, error)
+	Authenticate(token string) (*model.APIToken, error)
+	Delete(id string) error
+}
+
+// scopeRanks orders the API token scopes, a higher rank includes the lower ones.
+var scopeRanks = map[string]int{
+	model.APITokenScopeRead:   1,
+	model.APITokenScopeDeploy: 2,
+	model.APITokenScopeAdmin:  3,
+}
+
+func IsAPITokenScope(scope string) bool {
+	_, ok := scopeRanks[scope]
+	return ok
+}
+
+// ScopeAllows reports whether a token with scope may do what required needs.
+func ScopeAllows(scope, required string) bool {
+	return scopeRanks[scope] >= scopeRanks[required]
+}
+
+type APITokenService struct {
+	repo repo.APITokenRepoInterface
+}
+
+func NewAPITokenService(repo *repo.APITokenRepo) *APITokenService {
+	return &APITokenService{repo: repo}
+}
+
+// hashToken returns the stored form of an API or refresh token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create creates a token for a user. The returned token has Token set, it is not stored.
+func (s *APITokenService) Create(userID string, req model.APITokenCreate) (*model.APIToken, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required: %w", errs.ErrInvalidInput)
+	}
+	if !IsAPITokenScope(req.Scope) {
+		return nil, fmt.Errorf("scope must be read, deploy or admin: %w", errs.ErrInvalidInput)
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > 3650 {
+		return nil, fmt.Errorf("expiry must be between 0 and 3650 days: %w", errs.ErrInvalidInput)
+	}
+
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, err
+	}
+	secret := model.APITokenPrefix + hex.EncodeToString(b)
+
+	token := &model.APIToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      req.Name,
+		Scope:     req.Scope,
+		Prefix:    secret[:len(model.APITokenPrefix)+6],
+		TokenHash: hashToken(secret),
+		CreatedAt: time.Now().UTC(),
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := token.CreatedAt.Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour)
+		token.ExpiresAt = &expiresAt
+	}
+
+	err = s.repo.Create(token)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Token = secret
+	return token, nil
+}
+
+func (s *APITokenService) APIToken(id string) (*model.APIToken, error) {
+	return s.repo.APIToken(id)
+}
+
+func (s *APITokenService) APITokensByUser(userID string) ([]model.APIToken, error) {
+	return s.repo.APITokensByUser(userID)
+}
+
+// Authenticate returns the stored token for a token sent by a client.
+// Unknown and expired tokens return ErrUnauthorized.
+func (s *APITokenService) Authenticate(token string) (*model.APIToken, error) {
+	apiToken, err := s.repo.APITokenByHash(hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errs.ErrUnauthorized, err)
+	}
+	now := time.Now().UTC()
+	if apiToken.ExpiresAt != nil && now.After(*apiToken.ExpiresAt) {
+		return nil, fmt.Errorf("api token expired: %w", errs.ErrUnauthorized)
+	}
+
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) > lastUsedInterval {
+		err = s.repo.MarkUsed(apiToken.ID, now)
+		if err != nil {
+			slog.Warn("failed to mark api token as used", "tokenID", apiToken.ID, "error", err)
+		}
+		apiToken.LastUsedAt = &now
+	}
+
+	return apiToken, nil
+}
+
+func (s *APITokenService) Delete(id string) error {
+	return s.repo.Delete(id)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+// auditMaxLimit caps how many events one request returns.
+const auditMaxLimit = 500
+
+// auditSecretFields never show their values in the audit log.
+var auditSecretFields = map[string]bool{
+	"password":      true,
+	"token":         true,
+	"secret":        true,
+	"refresh_token": true,
+}
+
+// auditSecretTargets have a secret in every field, like env vars by key,
+// or fields that are only secret for them, like the connection URL of a database.
+var auditSecretTargets = map[string]map[string]bool{
+	"env_vars": nil,
+	"database": {"url": true},
+	"settings": {"credentials": true},
+}
+
+// auditIgnoredFields change on their own and are left out of the changes.
+var auditIgnoredFields = map[string]bool{
+	"created_at":      true,
+	"updated_at":      true,
+	"last_used_at":    true,
+	"container_state": true,
+}
+
+type AuditServiceInterface interface {
+	Record(event *model.AuditEvent, before, after any) error
+	PodEvent(action, podID string) model.AuditEvent
+	Events(filter model.AuditFilter, user *model.User) ([]model.AuditEvent, error)
+}
+
+type AuditService struct {
+	repo    repo.AuditRepoInterface
+	podRepo repo.PodRepoInterface
+}
+
+func NewAuditService(repo *repo.AuditRepo, podRepo *repo.PodRepo) *AuditService {
+	return &AuditService{repo: repo, podRepo: podRepo}
+}
+
+// Record stores an event with the fields that differ between before and
+// after, the target before and after the change. Either is nil when the
+// target was created or deleted.
+func (s *AuditService) Record(event *model.AuditEvent, before, after any) error {
+	changes, err := auditChanges(event.TargetType, before, after)
+	if err != nil {
+		return err
+	}
+
+	event.ID = uuid.New().String()
+	event.Changes = changes
+	event.CreatedAt = time.Now().UTC()
+	return s.repo.Create(event)
+}
+
+// PodEvent returns an event for action on a pod, in the project of the pod.
+// A pod that can't be loaded is only referenced by its ID.
+func (s *AuditService) PodEvent(action, podID string) model.AuditEvent {
+	event := model.AuditEvent{Action: action, TargetType: "pod", TargetID: podID}
+	pod, err := s.podRepo.Pod(podID)
+	if err == nil {
+		event.TargetName = pod.Title
+		event.ProjectID = pod.ProjectID
+	}
+	return event
+}
+
+// Events returns the newest events matching the filter. The server admin
+// sees every event, others their own and those of their projects.
+func (s *AuditService) Events(filter model.AuditFilter, user *model.User) ([]model.AuditEvent, error) {
+	if filter.Limit < 0 || filter.Limit > auditMaxLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d: %w", auditMaxLimit, errs.ErrInvalidInput)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 100
+	}
+
+	visibleTo := user.ID
+	if user.IsAdmin {
+		visibleTo = ""
+	}
+	return s.repo.Events(filter, visibleTo)
+}
+
+// auditFields turns a target into its JSON fields.
+func auditFields(v any) (map[string]any, error) {
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil() {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	err = json.Unmarshal(b, &fields)
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// auditChanges compares the fields of before and after with secrets redacted.
+func auditChanges(targetType string, before, after any) (model.AuditChanges, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	secretFields, allSecret := auditSecretTargets[targetType]
+	allSecret = allSecret && secretFields == nil
+	redact := func(field string, value any) any {
+		if value == nil {
+			return nil
+		}
+		if allSecret || auditSecretFields[field] || secretFields[field] {
+			return model.AuditRedacted
+		}
+		return value
+	}
+
+	changes := model.AuditChanges{}
+	add := func(field string) {
+		if auditIgnoredFields[field] {
+			return
+		}
+		b, a := beforeFields[field], afterFields[field]
+		if reflect.DeepEqual(b, a) {
+			return
+		}
+		changes[field] = model.AuditChange{Before: redact(field, b), After: redact(field, a)}
+	}
+	for field := range beforeFields {
+		add(field)
+	}
+	for field := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			add(field)
+		}
+	}
+
+	return changes, nil
+}
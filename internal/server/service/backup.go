@@ -0,0 +1,537 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/backup"
+	"github.com/deeploy-sh/deeploy/internal/server/docker"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+// backupSchedulerInterval is how often the scheduler looks for due backups.
+const backupSchedulerInterval = time.Minute
+
+type BackupServiceInterface interface {
+	Backups(targetType, targetID string) (*model.Backups, error)
+	SaveSchedule(schedule *model.BackupSchedule) error
+	Run(userID, targetType, targetID string) (*model.Backup, error)
+	Restore(id string) error
+	Delete(id string) error
+}
+
+type BackupService struct {
+	repo            repo.BackupRepoInterface
+	databaseService DatabaseServiceInterface
+	podRepo         repo.PodRepoInterface
+	podVolumeRepo   repo.PodVolumeRepoInterface
+	docker          *docker.DockerService
+
+	// Archives by destination, s3 is only set when configured
+	storages map[string]backup.Storage
+
+	// Prevent parallel backups and restores of the same target
+	busyMu sync.Mutex
+	busy   map[string]bool
+}
+
+func NewBackupService(
+	repo *repo.BackupRepo,
+	databaseService *DatabaseService,
+	podRepo *repo.PodRepo,
+	podVolumeRepo *repo.PodVolumeRepo,
+	docker *docker.DockerService,
+	storages map[string]backup.Storage,
+) *BackupService {
+	return &BackupService{
+		repo:            repo,
+		databaseService: databaseService,
+		podRepo:         podRepo,
+		podVolumeRepo:   podVolumeRepo,
+		docker:          docker,
+		storages:        storages,
+		busy:            make(map[string]bool),
+	}
+}
+
+// backupTarget is a database or pod volume resolved for a backup or restore.
+type backupTarget struct {
+	database    *model.Database // set for databases, with decrypted password
+	volume      string          // named volume copied with tar, "" for SQL dumps
+	containerID string          // container of the database or pod, "" if none
+}
+
+// dumped reports whether the target is backed up as a SQL dump.
+func (t *backupTarget) dumped() bool {
+	return t.database != nil && databaseEngines[t.database.Engine].dumpCmd != nil
+}
+
+// extension is the file extension of the target's archives.
+func (t *backupTarget) extension() string {
+	if t.dumped() {
+		return ".sql.gz"
+	}
+	return ".tar.gz"
+}
+
+func (s *BackupService) resolveTarget(targetType, targetID string) (*backupTarget, error) {
+	switch targetType {
+	case model.BackupTargetDatabase:
+		database, err := s.databaseService.Database(targetID)
+		if err != nil {
+			return nil, err
+		}
+		if database.ContainerID == nil || *database.ContainerID == "" {
+			return nil, fmt.Errorf("database %s has no container: %w", targetID, errs.ErrConflict)
+		}
+		target := &backupTarget{database: database, containerID: *database.ContainerID}
+		if !target.dumped() {
+			target.volume = databaseVolumeName(database.ID)
+		}
+		return target, nil
+
+	case model.BackupTargetVolume:
+		volume, err := s.podVolumeRepo.Volume(targetID)
+		if err != nil {
+			return nil, err
+		}
+		if volume.Type != model.VolumeTypeVolume {
+			return nil, fmt.Errorf("only named volumes can be backed up, back up host paths on the host: %w", errs.ErrInvalidInput)
+		}
+		pod, err := s.podRepo.Pod(volume.PodID)
+		if err != nil {
+			return nil, err
+		}
+		target := &backupTarget{volume: volume.VolumeName}
+		if pod.ContainerID != nil {
+			target.containerID = *pod.ContainerID
+		}
+		return target, nil
+	}
+
+	return nil, fmt.Errorf("unknown backup target %s: %w", targetType, errs.ErrInvalidInput)
+}
+
+func (s *BackupService) lockTarget(targetType, targetID string) bool {
+	s.busyMu.Lock()
+	defer s.busyMu.Unlock()
+	key := targetType + "/" + targetID
+	if s.busy[key] {
+		return false
+	}
+	s.busy[key] = true
+	return true
+}
+
+func (s *BackupService) unlockTarget(targetType, targetID string) {
+	s.busyMu.Lock()
+	defer s.busyMu.Unlock()
+	delete(s.busy, targetType+"/"+targetID)
+}
+
+func (s *BackupService) storage(destination string) (backup.Storage, error) {
+	storage, ok := s.storages[destination]
+	if !ok {
+		return nil, fmt.Errorf("backup destination %s is not configured on the server: %w", destination, errs.ErrInvalidInput)
+	}
+	return storage, nil
+}
+
+// schedule returns the schedule of a target, nil if it has none.
+func (s *BackupService) schedule(targetType, targetID string) (*model.BackupSchedule, error) {
+	schedule, err := s.repo.Schedule(targetType, targetID)
+	if errors.Is(err, errs.ErrNotFound) {
+		return nil, nil
+	}
+	return schedule, err
+}
+
+// Backups returns the schedule and the backups of a target, newest first.
+func (s *BackupService) Backups(targetType, targetID string) (*model.Backups, error) {
+	schedule, err := s.schedule(targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	backups, err := s.repo.BackupsByTarget(targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Backups{Schedule: schedule, Backups: backups}, nil
+}
+
+// SaveSchedule creates or replaces the schedule of a target.
+// Zero values are replaced with the defaults.
+func (s *BackupService) SaveSchedule(schedule *model.BackupSchedule) error {
+	_, err := s.resolveTarget(schedule.TargetType, schedule.TargetID)
+	if err != nil {
+		return err
+	}
+	if schedule.IntervalHours == 0 {
+		schedule.IntervalHours = model.DefaultBackupIntervalHours
+	}
+	if schedule.Retention == 0 {
+		schedule.Retention = model.DefaultBackupRetention
+	}
+	if schedule.Destination == "" {
+		schedule.Destination = model.BackupDestinationLocal
+	}
+	_, err = s.storage(schedule.Destination)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.schedule(schedule.TargetType, schedule.TargetID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		schedule.ID = uuid.New().String()
+		return s.repo.CreateSchedule(schedule)
+	}
+
+	schedule.ID = existing.ID
+	schedule.UserID = existing.UserID
+	schedule.LastRunAt = existing.LastRunAt
+	return s.repo.UpdateSchedule(*schedule)
+}
+
+// Run starts a backup of a target in the background and returns it
+// with status running. Destination and retention are taken from the
+// target's schedule, without one the backup is stored locally.
+func (s *BackupService) Run(userID, targetType, targetID string) (*model.Backup, error) {
+	target, err := s.resolveTarget(targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	destination := model.BackupDestinationLocal
+	retention := model.DefaultBackupRetention
+	schedule, err := s.schedule(targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule != nil {
+		destination = schedule.Destination
+		retention = schedule.Retention
+	}
+	storage, err := s.storage(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.lockTarget(targetType, targetID) {
+		return nil, fmt.Errorf("a backup or restore of this %s is already running: %w", targetType, errs.ErrConflict)
+	}
+
+	id := uuid.New().String()
+	b := &model.Backup{
+		ID:          id,
+		UserID:      userID,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Destination: destination,
+		StorageKey:  targetType + "/" + targetID + "/" + id + target.extension(),
+		Status:      model.BackupStatusRunning,
+		StartedAt:   time.Now(),
+	}
+	err = s.repo.Create(b)
+	if err != nil {
+		s.unlockTarget(targetType, targetID)
+		return nil, err
+	}
+
+	go s.run(*b, target, storage, retention)
+
+	return b, nil
+}
+
+func (s *BackupService) run(b model.Backup, target *backupTarget, storage backup.Storage, retention int) {
+	defer s.unlockTarget(b.TargetType, b.TargetID)
+	ctx := context.Background()
+
+	size, err := s.write(ctx, b.StorageKey, target, storage)
+	if err != nil {
+		slog.Error("backup failed", "backupID", b.ID, "target", b.TargetType, "targetID", b.TargetID, "error", err)
+		errMsg := err.Error()
+		s.repo.Finish(b.ID, model.BackupStatusFailed, 0, &errMsg)
+		return
+	}
+
+	err = s.repo.Finish(b.ID, model.BackupStatusSuccess, size, nil)
+	if err != nil {
+		slog.Error("failed to finish backup", "backupID", b.ID, "error", err)
+		return
+	}
+	slog.Info("backup finished", "backupID", b.ID, "target", b.TargetType, "targetID", b.TargetID, "size", size)
+
+	s.prune(ctx, b.TargetType, b.TargetID, retention)
+}
+
+// write compresses the target into a temporary file and stores it,
+// storages need the size before the upload starts.
+func (s *BackupService) write(ctx context.Context, key string, target *backupTarget, storage backup.Storage) (int64, error) {
+	f, err := os.CreateTemp("", "deeploy-backup-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	err = s.dump(ctx, target, gz)
+	if err != nil {
+		return 0, err
+	}
+	err = gz.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return 0, err
+	}
+
+	err = storage.Put(ctx, key, f, size)
+	if err != nil {
+		return 0, fmt.Errorf("failed to store backup: %w", err)
+	}
+	return size, nil
+}
+
+// dump writes a SQL dump of a database or a tar archive of a volume to w.
+func (s *BackupService) dump(ctx context.Context, target *backupTarget, w io.Writer) error {
+	if target.database == nil {
+		return s.docker.ExportVolume(ctx, target.volume, w)
+	}
+
+	engine := databaseEngines[target.database.Engine]
+	env := engine.clientEnv(target.database)
+	if engine.dumpCmd != nil {
+		return s.docker.Exec(ctx, target.containerID, docker.ExecOptions{
+			Cmd:    engine.dumpCmd(target.database),
+			Env:    env,
+			Stdout: w,
+		})
+	}
+
+	if engine.flushCmd != nil {
+		err := s.docker.Exec(ctx, target.containerID, docker.ExecOptions{Cmd: engine.flushCmd, Env: env})
+		if err != nil {
+			return err
+		}
+	}
+	return s.docker.ExportVolume(ctx, target.volume, w)
+}
+
+// prune deletes the oldest backups of a target beyond retention.
+// Failed backups are kept up to the same number for troubleshooting.
+func (s *BackupService) prune(ctx context.Context, targetType, targetID string, retention int) {
+	backups, err := s.repo.BackupsByTarget(targetType, targetID)
+	if err != nil {
+		slog.Warn("failed to list backups for retention", "target", targetType, "targetID", targetID, "error", err)
+		return
+	}
+
+	kept := map[string]int{}
+	for _, b := range backups {
+		if b.Status == model.BackupStatusRunning {
+			continue
+		}
+		kept[b.Status]++
+		if kept[b.Status] <= retention {
+			continue
+		}
+		err := s.delete(ctx, &b)
+		if err != nil {
+			slog.Warn("failed to delete old backup", "backupID", b.ID, "error", err)
+		}
+	}
+}
+
+// Restore replaces the data of a backup's target with the backup.
+// Containers using a restored volume are stopped while it is written.
+func (s *BackupService) Restore(id string) error {
+	b, err := s.repo.Backup(id)
+	if err != nil {
+		return err
+	}
+	if b.Status != model.BackupStatusSuccess {
+		return fmt.Errorf("only successful backups can be restored: %w", errs.ErrInvalidInput)
+	}
+	target, err := s.resolveTarget(b.TargetType, b.TargetID)
+	if err != nil {
+		return err
+	}
+	storage, err := s.storage(b.Destination)
+	if err != nil {
+		return err
+	}
+
+	if !s.lockTarget(b.TargetType, b.TargetID) {
+		return fmt.Errorf("a backup or restore of this %s is already running: %w", b.TargetType, errs.ErrConflict)
+	}
+	defer s.unlockTarget(b.TargetType, b.TargetID)
+
+	ctx := context.Background()
+	rc, err := storage.Get(ctx, b.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	gz, err := gzip.NewReader(rc)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	defer gz.Close()
+
+	if target.dumped() {
+		engine := databaseEngines[target.database.Engine]
+		err = s.docker.Exec(ctx, target.containerID, docker.ExecOptions{
+			Cmd:   engine.restoreCmd(target.database),
+			Env:   engine.clientEnv(target.database),
+			Stdin: gz,
+		})
+	} else {
+		err = s.restoreVolume(ctx, target, gz)
+	}
+	if err != nil {
+		slog.Error("restore failed", "backupID", b.ID, "target", b.TargetType, "targetID", b.TargetID, "error", err)
+		return err
+	}
+
+	slog.Info("backup restored", "backupID", b.ID, "target", b.TargetType, "targetID", b.TargetID)
+	return nil
+}
+
+// restoreVolume writes a volume archive while the container using the volume
+// is stopped. The container is started again even if the restore fails.
+func (s *BackupService) restoreVolume(ctx context.Context, target *backupTarget, r io.Reader) error {
+	running := false
+	if target.containerID != "" {
+		state, err := s.docker.GetContainerState(ctx, target.containerID)
+		running = err == nil && state == "running"
+	}
+
+	if running {
+		err := s.docker.StopContainer(ctx, target.containerID)
+		if err != nil {
+			return fmt.Errorf("failed to stop container: %w", err)
+		}
+		defer func() {
+			err := s.docker.StartContainer(ctx, target.containerID)
+			if err != nil {
+				slog.Error("failed to start container after restore", "containerID", target.containerID, "error", err)
+			}
+		}()
+	}
+
+	return s.docker.ImportVolume(ctx, target.volume, r)
+}
+
+// Delete removes a backup and its archive.
+func (s *BackupService) Delete(id string) error {
+	b, err := s.repo.Backup(id)
+	if err != nil {
+		return err
+	}
+	if b.Status == model.BackupStatusRunning {
+		return fmt.Errorf("backup is still running: %w", errs.ErrConflict)
+	}
+	return s.delete(context.Background(), b)
+}
+
+func (s *BackupService) delete(ctx context.Context, b *model.Backup) error {
+	// Failed backups have no archive
+	if b.Status == model.BackupStatusSuccess {
+		storage, err := s.storage(b.Destination)
+		if err != nil {
+			return err
+		}
+		err = storage.Delete(ctx, b.StorageKey)
+		if err != nil {
+			return err
+		}
+	}
+	return s.repo.Delete(b.ID)
+}
+
+// RunScheduler starts due backups every minute until ctx is done.
+// Backups interrupted by a restart are marked failed first.
+func (s *BackupService) RunScheduler(ctx context.Context) {
+	err := s.repo.FailRunning()
+	if err != nil {
+		slog.Error("failed to mark interrupted backups", "error", err)
+	}
+
+	ticker := time.NewTicker(backupSchedulerInterval)
+	defer ticker.Stop()
+	for {
+		s.runDue()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDue starts the backups of all schedules whose interval has passed.
+func (s *BackupService) runDue() {
+	schedules, err := s.repo.EnabledSchedules()
+	if err != nil {
+		slog.Error("failed to load backup schedules", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		interval := time.Duration(schedule.IntervalHours) * time.Hour
+		if schedule.LastRunAt != nil && now.Sub(*schedule.LastRunAt) < interval {
+			continue
+		}
+
+		_, err := s.Run(schedule.UserID, schedule.TargetType, schedule.TargetID)
+		if errors.Is(err, errs.ErrConflict) && s.isBusy(schedule.TargetType, schedule.TargetID) {
+			// Retried on the next tick
+			continue
+		}
+		if errors.Is(err, errs.ErrNotFound) {
+			// The database or volume is gone, keep its backups but stop scheduling
+			slog.Warn("backup target not found, disabling schedule", "target", schedule.TargetType, "targetID", schedule.TargetID)
+			schedule.Enabled = false
+			s.repo.UpdateSchedule(schedule)
+			continue
+		}
+		if err != nil {
+			slog.Error("scheduled backup failed", "target", schedule.TargetType, "targetID", schedule.TargetID, "error", err)
+		}
+
+		err = s.repo.MarkScheduleRun(schedule.ID)
+		if err != nil {
+			slog.Error("failed to mark backup schedule run", "scheduleID", schedule.ID, "error", err)
+		}
+	}
+}
+
+func (s *BackupService) isBusy(targetType, targetID string) bool {
+	s.busyMu.Lock()
+	defer s.busyMu.Unlock()
+	return s.busy[targetType+"/"+targetID]
+}
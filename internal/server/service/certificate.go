@@ -0,0 +1,134 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/crypto"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+type CertificateServiceInterface interface {
+	Upload(domain *model.PodDomain, upload model.CertificateUpload) (*model.Certificate, error)
+	CertificateByDomain(domainID string) (*model.Certificate, error)
+	CertificatesByPod(podID string) ([]model.Certificate, error)
+	Delete(domainID string) error
+	WriteConfig() error
+}
+
+// CertificateService manages uploaded certificates of pod domains. Traefik
+// reads them from a TLS store file and picks the one matching a request by SNI.
+type CertificateService struct {
+	repo      repo.CertificateRepoInterface
+	encryptor *crypto.Encryptor
+	traefik   *TraefikService
+}
+
+func NewCertificateService(repo *repo.CertificateRepo, encryptor *crypto.Encryptor, traefik *TraefikService) *CertificateService {
+	return &CertificateService{repo: repo, encryptor: encryptor, traefik: traefik}
+}
+
+// Upload checks that a certificate and key belong together and cover the
+// domain, then replaces the certificate of the domain.
+func (s *CertificateService) Upload(domain *model.PodDomain, upload model.CertificateUpload) (*model.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(upload.Certificate), []byte(upload.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("certificate and key don't match or aren't PEM: %w", errs.ErrInvalidInput)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", errs.ErrInvalidInput)
+	}
+
+	// A wildcard domain needs a wildcard certificate, check it with a sample host
+	host := domain.Domain
+	if suffix, ok := strings.CutPrefix(host, "*."); ok {
+		host = "deeploy." + suffix
+	}
+	if err := leaf.VerifyHostname(host); err != nil {
+		return nil, fmt.Errorf("certificate is not valid for %s: %w", domain.Domain, errs.ErrInvalidInput)
+	}
+	if time.Now().After(leaf.NotAfter) {
+		return nil, fmt.Errorf("certificate expired on %s: %w", leaf.NotAfter.Format(time.DateOnly), errs.ErrInvalidInput)
+	}
+
+	certificate := &model.Certificate{
+		ID:          uuid.New().String(),
+		PodDomainID: domain.ID,
+		Certificate: strings.TrimSpace(upload.Certificate),
+		PrivateKey:  strings.TrimSpace(upload.PrivateKey),
+		Issuer:      leaf.Issuer.CommonName,
+		ExpiresAt:   leaf.NotAfter,
+	}
+	if s.encryptor != nil {
+		encrypted, err := s.encryptor.Encrypt(certificate.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		certificate.PrivateKey = encrypted
+	}
+
+	err = s.repo.DeleteByDomain(domain.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.Create(certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.WriteConfig()
+	if err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+func (s *CertificateService) CertificateByDomain(domainID string) (*model.Certificate, error) {
+	return s.repo.CertificateByDomain(domainID)
+}
+
+// CertificatesByPod lists the certificates of the domains of a pod.
+// Private keys are never sent to clients, so they are not decrypted here.
+func (s *CertificateService) CertificatesByPod(podID string) ([]model.Certificate, error) {
+	return s.repo.CertificatesByPod(podID)
+}
+
+func (s *CertificateService) Delete(domainID string) error {
+	err := s.repo.DeleteByDomain(domainID)
+	if err != nil {
+		return err
+	}
+	return s.WriteConfig()
+}
+
+// WriteConfig writes all certificates to the Traefik TLS store, e.g. after
+// an upload, a restore or on startup to drop certificates of deleted domains.
+func (s *CertificateService) WriteConfig() error {
+	certificates, err := s.repo.Certificates()
+	if err != nil {
+		return err
+	}
+
+	for i := range certificates {
+		if s.encryptor != nil {
+			decrypted, err := s.encryptor.Decrypt(certificates[i].PrivateKey)
+			if err != nil {
+				return err
+			}
+			certificates[i].PrivateKey = decrypted
+		}
+	}
+
+	err = s.traefik.WriteCertificates(certificates)
+	if err != nil {
+		return fmt.Errorf("failed to write traefik certificates: %w", err)
+	}
+	return nil
+}
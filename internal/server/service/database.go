@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/deeploy-sh/deeploy/internal/server/crypto"
+	"github.com/deeploy-sh/deeploy/internal/server/docker"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+type DatabaseServiceInterface interface {
+	Create(database *model.Database) (*model.Database, error)
+	Database(id string) (*model.Database, error)
+	DatabasesByProject(id string) ([]model.Database, error)
+	DatabasesByUser(id string) ([]model.Database, error)
+	CountByProject(id string) (int, error)
+	CountLinks(id string) (int, error)
+	Delete(id string, removeVolume bool) error
+	Link(link *model.PodDatabase) error
+	LinksByPod(podID string) ([]model.PodDatabase, error)
+	Unlink(podID, databaseID string) error
+	LinkedEnvVars(podID string) (map[string]string, error)
+	ProvisionPending()
+}
+
+// databaseEngine describes how a database engine is run from its official image.
+type databaseEngine struct {
+	image          string // image name, tagged with the database version
+	defaultVersion string
+	port           int
+	dataDir        string // mount path of the data volume
+	username       string
+	databaseName   string
+	envVar         string // default env var of links
+	env            func(db *model.Database) map[string]string
+	cmd            func(db *model.Database) []string
+
+	// Backups run the engine's client tools inside the container.
+	// Engines without dumpCmd are backed up as a copy of their data volume.
+	clientEnv  func(db *model.Database) map[string]string
+	dumpCmd    func(db *model.Database) []string // writes a SQL dump to stdout
+	restoreCmd func(db *model.Database) []string // reads a SQL dump from stdin
+	flushCmd   []string                          // persists in-memory data before the volume is copied
+}
+
+var databaseEngines = map[string]databaseEngine{
+	model.DatabaseEnginePostgres: {
+		image:          "postgres",
+		defaultVersion: "17",
+		port:           5432,
+		dataDir:        "/var/lib/postgresql/data",
+		username:       "deeploy",
+		databaseName:   "app",
+		envVar:         "DATABASE_URL",
+		env: func(db *model.Database) map[string]string {
+			return map[string]string{
+				"POSTGRES_USER":     db.Username,
+				"POSTGRES_PASSWORD": db.Password,
+				"POSTGRES_DB":       db.DatabaseName,
+			}
+		},
+		clientEnv: func(db *model.Database) map[string]string {
+			return map[string]string{"PGPASSWORD": db.Password}
+		},
+		dumpCmd: func(db *model.Database) []string {
+			return []string{"pg_dump", "-U", db.Username, "-d", db.DatabaseName, "--clean", "--if-exists", "--no-owner"}
+		},
+		restoreCmd: func(db *model.Database) []string {
+			return []string{"psql", "-U", db.Username, "-d", db.DatabaseName, "-v", "ON_ERROR_STOP=1", "-q"}
+		},
+	},
+	model.DatabaseEngineMySQL: {
+		image:          "mysql",
+		defaultVersion: "8.4",
+		port:           3306,
+		dataDir:        "/var/lib/mysql",
+		username:       "deeploy",
+		databaseName:   "app",
+		envVar:         "DATABASE_URL",
+		env: func(db *model.Database) map[string]string {
+			return map[string]string{
+				"MYSQL_USER":                 db.Username,
+				"MYSQL_PASSWORD":             db.Password,
+				"MYSQL_DATABASE":             db.DatabaseName,
+				"MYSQL_RANDOM_ROOT_PASSWORD": "yes",
+			}
+		},
+		clientEnv: func(db *model.Database) map[string]string {
+			return map[string]string{"MYSQL_PWD": db.Password}
+		},
+		dumpCmd: func(db *model.Database) []string {
+			return []string{"mysqldump", "-u", db.Username, "--single-transaction", "--no-tablespaces", db.DatabaseName}
+		},
+		restoreCmd: func(db *model.Database) []string {
+			return []string{"mysql", "-u", db.Username, db.DatabaseName}
+		},
+	},
+	model.DatabaseEngineRedis: {
+		image:          "redis",
+		defaultVersion: "7",
+		port:           6379,
+		dataDir:        "/data",
+		username:       "default",
+		databaseName:   "0",
+		envVar:         "REDIS_URL",
+		cmd: func(db *model.Database) []string {
+			return []string{"redis-server", "--requirepass", db.Password, "--appendonly", "yes"}
+		},
+		clientEnv: func(db *model.Database) map[string]string {
+			return map[string]string{"REDISCLI_AUTH": db.Password}
+		},
+		flushCmd: []string{"redis-cli", "SAVE"},
+	},
+}
+
+// IsDatabaseEngine reports whether engine is a supported database engine.
+func IsDatabaseEngine(engine string) bool {
+	_, ok := databaseEngines[engine]
+	return ok
+}
+
+type DatabaseService struct {
+	repo      repo.DatabaseRepoInterface
+	encryptor *crypto.Encryptor
+	docker    *docker.DockerService
+}
+
+func NewDatabaseService(repo *repo.DatabaseRepo, encryptor *crypto.Encryptor, docker *docker.DockerService) *DatabaseService {
+	return &DatabaseService{repo: repo, encryptor: encryptor, docker: docker}
+}
+
+// databaseContainerName is also the hostname of a database on the deeploy network.
+func databaseContainerName(databaseID string) string {
+	return "deeploy-db-" + databaseID
+}
+
+// databaseVolumeName is the named volume holding the data of a database.
+func databaseVolumeName(databaseID string) string {
+	return "deeploy-db-" + databaseID
+}
+
+// generateDatabasePassword returns a random 24 byte hex password.
+// Hex needs no escaping in connection URLs.
+func generateDatabasePassword() (string, error) {
+	b := make([]byte, 24)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *DatabaseService) encrypt(password string) (string, error) {
+	if s.encryptor == nil {
+		return password, nil
+	}
+	return s.encryptor.Encrypt(password)
+}
+
+func (s *DatabaseService) decrypt(password string) (string, error) {
+	if s.encryptor == nil {
+		return password, nil
+	}
+	return s.encryptor.Decrypt(password)
+}
+
+// withConnection fills the connection fields of a database.
+// With withURL the connection URL is set too, the password must be decrypted.
+func withConnection(database *model.Database, withURL bool) {
+	engine := databaseEngines[database.Engine]
+	database.Host = databaseContainerName(database.ID)
+	database.Port = engine.port
+	if !withURL {
+		return
+	}
+	u := url.URL{
+		Scheme: database.Engine,
+		User:   url.UserPassword(database.Username, database.Password),
+		Host:   net.JoinHostPort(database.Host, strconv.Itoa(database.Port)),
+		Path:   "/" + database.DatabaseName,
+	}
+	database.URL = u.String()
+}
+
+// Create stores a database with generated credentials and provisions
+// its container in the background. Status is provisioning until the
+// container runs (or failed).
+func (s *DatabaseService) Create(database *model.Database) (*model.Database, error) {
+	engine, ok := databaseEngines[database.Engine]
+	if !ok {
+		return nil, fmt.Errorf("unknown engine %s: %w", database.Engine, errs.ErrInvalidInput)
+	}
+	if database.Version == "" {
+		database.Version = engine.defaultVersion
+	}
+	if database.DatabaseName == "" || database.Engine == model.DatabaseEngineRedis {
+		database.DatabaseName = engine.databaseName
+	}
+	database.Username = engine.username
+	database.Status = model.DatabaseStatusProvisioning
+
+	password, err := generateDatabasePassword()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encrypt(password)
+	if err != nil {
+		return nil, err
+	}
+	database.Password = encrypted
+
+	err = s.repo.Create(database)
+	if err != nil {
+		return nil, err
+	}
+
+	database.Password = password
+	go s.provision(*database)
+
+	withConnection(database, true)
+	return database, nil
+}
+
+// provision pulls the image and starts the container of a database.
+func (s *DatabaseService) provision(database model.Database) {
+	ctx := context.Background()
+	engine := databaseEngines[database.Engine]
+	imageName := engine.image + ":" + database.Version
+
+	fail := func(err error) {
+		slog.Error("failed to provision database", "databaseID", database.ID, "image", imageName, "error", err)
+		s.repo.UpdateContainer(database.ID, nil, model.DatabaseStatusFailed)
+	}
+
+	err := s.docker.PullImage(ctx, imageName, imageName, nil, nil)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	// Leftover of an interrupted provisioning or a restored server
+	s.docker.RemoveContainer(ctx, databaseContainerName(database.ID))
+
+	opts := docker.DatabaseContainerOptions{
+		ImageName:     imageName,
+		ContainerName: databaseContainerName(database.ID),
+		DatabaseID:    database.ID,
+		Volume: docker.VolumeMount{
+			Source: databaseVolumeName(database.ID),
+			Target: engine.dataDir,
+		},
+	}
+	if engine.env != nil {
+		opts.EnvVars = engine.env(&database)
+	}
+	if engine.cmd != nil {
+		opts.Cmd = engine.cmd(&database)
+	}
+
+	containerID, err := s.docker.RunDatabaseContainer(ctx, opts)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	err = s.repo.UpdateContainer(database.ID, &containerID, model.DatabaseStatusRunning)
+	if err != nil {
+		slog.Error("failed to store database container", "databaseID", database.ID, "error", err)
+	}
+}
+
+// ProvisionPending provisions databases without a container, e.g. after
+// a restart during provisioning or a server restore.
+func (s *DatabaseService) ProvisionPending() {
+	databases, err := s.repo.PendingDatabases()
+	if err != nil {
+		slog.Error("failed to load pending databases", "error", err)
+		return
+	}
+
+	for _, database := range databases {
+		password, err := s.decrypt(database.Password)
+		if err != nil {
+			slog.Error("failed to decrypt database password", "databaseID", database.ID, "error", err)
+			s.repo.UpdateContainer(database.ID, nil, model.DatabaseStatusFailed)
+			continue
+		}
+		database.Password = password
+		go s.provision(database)
+	}
+}
+
+// Database returns a database with its decrypted connection URL.
+func (s *DatabaseService) Database(id string) (*model.Database, error) {
+	database, err := s.repo.Database(id)
+	if err != nil {
+		return nil, err
+	}
+
+	database.Password, err = s.decrypt(database.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	withConnection(database, true)
+	s.enrichWithContainerState(database)
+	return database, nil
+}
+
+// DatabasesByProject lists the databases of a project without their passwords.
+func (s *DatabaseService) DatabasesByProject(id string) ([]model.Database, error) {
+	databases, err := s.repo.DatabasesByProject(id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range databases {
+		withConnection(&databases[i], false)
+	}
+	return databases, nil
+}
+
+// DatabasesByUser lists the databases of a user without their passwords.
+func (s *DatabaseService) DatabasesByUser(id string) ([]model.Database, error) {
+	databases, err := s.repo.DatabasesByUser(id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range databases {
+		withConnection(&databases[i], false)
+	}
+	return databases, nil
+}
+
+func (s *DatabaseService) CountByProject(id string) (int, error) {
+	return s.repo.CountByProject(id)
+}
+
+func (s *DatabaseService) CountLinks(id string) (int, error) {
+	return s.repo.CountLinks(id)
+}
+
+// enrichWithContainerState fetches the live Docker container state for a database.
+func (s *DatabaseService) enrichWithContainerState(database *model.Database) {
+	if database.ContainerID == nil || *database.ContainerID == "" {
+		return
+	}
+	state, err := s.docker.GetContainerState(context.Background(), *database.ContainerID)
+	if err != nil {
+		return
+	}
+	database.ContainerState = state
+}
+
+// Delete removes a database and its container.
+// The data volume is only removed with removeVolume.
+func (s *DatabaseService) Delete(id string, removeVolume bool) error {
+	database, err := s.repo.Database(id)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if database.ContainerID != nil && *database.ContainerID != "" {
+		s.docker.StopContainer(ctx, *database.ContainerID)
+		s.docker.RemoveContainer(ctx, *database.ContainerID)
+	}
+	if removeVolume {
+		s.docker.RemoveVolume(ctx, databaseVolumeName(id))
+	}
+
+	return s.repo.Delete(id)
+}
+
+// Link links a database to a pod. An empty EnvVar defaults to
+// DATABASE_URL (REDIS_URL for Redis).
+func (s *DatabaseService) Link(link *model.PodDatabase) error {
+	database, err := s.repo.Database(link.DatabaseID)
+	if err != nil {
+		return err
+	}
+	if link.EnvVar == "" {
+		link.EnvVar = databaseEngines[database.Engine].envVar
+	}
+
+	links, err := s.repo.LinksByPod(link.PodID)
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		if l.DatabaseID == link.DatabaseID {
+			return fmt.Errorf("database is already linked: %w", errs.ErrConflict)
+		}
+		if l.EnvVar == link.EnvVar {
+			return fmt.Errorf("%s is already used by another database: %w", link.EnvVar, errs.ErrConflict)
+		}
+	}
+
+	return s.repo.Link(*link)
+}
+
+func (s *DatabaseService) LinksByPod(podID string) ([]model.PodDatabase, error) {
+	return s.repo.LinksByPod(podID)
+}
+
+func (s *DatabaseService) Unlink(podID, databaseID string) error {
+	return s.repo.Unlink(podID, databaseID)
+}
+
+// LinkedEnvVars returns the connection URLs of the databases linked to a pod,
+// keyed by their env var.
+func (s *DatabaseService) LinkedEnvVars(podID string) (map[string]string, error) {
+	links, err := s.repo.LinksByPod(podID)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars := make(map[string]string, len(links))
+	for _, l := range links {
+		database, err := s.repo.Database(l.DatabaseID)
+		if err != nil {
+			return nil, err
+		}
+		database.Password, err = s.decrypt(database.Password)
+		if err != nil {
+			return nil, err
+		}
+		withConnection(database, true)
+		envVars[l.EnvVar] = database.URL
+	}
+	return envVars, nil
+}
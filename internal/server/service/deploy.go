@@ -2,196 +2,631 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/deeploy-sh/deeploy/internal/server/autobuild"
+	"github.com/deeploy-sh/deeploy/internal/server/buildlog"
 	"github.com/deeploy-sh/deeploy/internal/server/docker"
 	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/google/uuid"
 )
 
+// keepDeploymentImages is the number of deployment images kept per pod for rollbacks.
+const keepDeploymentImages = 5
+
 type DeployService struct {
-	podRepo          repo.PodRepoInterface
-	podDomainRepo    repo.PodDomainRepoInterface
-	podEnvVarService PodEnvVarServiceInterface
-	gitTokenService  GitTokenServiceInterface
-	docker           *docker.DockerService
+	podRepo                   repo.PodRepoInterface
+	deploymentRepo            repo.DeploymentRepoInterface
+	podDomainRepo             repo.PodDomainRepoInterface
+	certificateRepo           repo.CertificateRepoInterface
+	podVolumeRepo             repo.PodVolumeRepoInterface
+	podEnvVarService          PodEnvVarServiceInterface
+	databaseService           DatabaseServiceInterface
+	gitTokenService           GitTokenServiceInterface
+	registryCredentialService RegistryCredentialServiceInterface
+	traefikService            *TraefikService
+	docker                    *docker.DockerService
 
-	// Build logs storage (simple)
-	buildLogsMu sync.RWMutex
-	buildLogs   map[string][]string // podID -> log lines
+	// Build logs, persisted per deployment
+	buildLogs *buildlog.Store
 
-	// Prevent parallel deploys of the same pod
+	// Prevent parallel deploys of the same pod, queued holds the deploy
+	// to start once the running one finishes
 	deployingMu sync.Mutex
 	deploying   map[string]bool
+	queued      map[string]queuedDeploy
+}
+
+// queuedDeploy is a deploy waiting for the running one of its pod.
+type queuedDeploy struct {
+	req         model.DeployRequest
+	triggeredBy string
 }
 
 func NewDeployService(
 	podRepo *repo.PodRepo,
+	deploymentRepo *repo.DeploymentRepo,
 	podDomainRepo *repo.PodDomainRepo,
+	certificateRepo *repo.CertificateRepo,
+	podVolumeRepo *repo.PodVolumeRepo,
 	podEnvVarService PodEnvVarServiceInterface,
+	databaseService *DatabaseService,
 	gitTokenService *GitTokenService,
+	registryCredentialService *RegistryCredentialService,
+	traefikService *TraefikService,
 	docker *docker.DockerService,
+	buildLogs *buildlog.Store,
 ) *DeployService {
 	return &DeployService{
-		podRepo:          podRepo,
-		podDomainRepo:    podDomainRepo,
-		podEnvVarService: podEnvVarService,
-		gitTokenService:  gitTokenService,
-		docker:           docker,
-		buildLogs:        make(map[string][]string),
-		deploying:        make(map[string]bool),
+		podRepo:                   podRepo,
+		deploymentRepo:            deploymentRepo,
+		podDomainRepo:             podDomainRepo,
+		certificateRepo:           certificateRepo,
+		podVolumeRepo:             podVolumeRepo,
+		podEnvVarService:          podEnvVarService,
+		databaseService:           databaseService,
+		gitTokenService:           gitTokenService,
+		registryCredentialService: registryCredentialService,
+		traefikService:            traefikService,
+		docker:                    docker,
+		buildLogs:                 buildLogs,
+		deploying:                 make(map[string]bool),
+		queued:                    make(map[string]queuedDeploy),
 	}
 }
 
-// appendBuildLog adds a log line to the build logs for a pod.
-func (s *DeployService) appendBuildLog(podID, line string) {
-	s.buildLogsMu.Lock()
-	defer s.buildLogsMu.Unlock()
-	s.buildLogs[podID] = append(s.buildLogs[podID], line)
+// buildLogger returns a function that appends lines to the build log of a deployment.
+func (s *DeployService) buildLogger(podID, deploymentID string) func(string) {
+	return func(line string) {
+		err := s.buildLogs.Append(podID, deploymentID, line)
+		if err != nil {
+			slog.Warn("failed to write build log", "podID", podID, "deploymentID", deploymentID, "error", err)
+		}
+	}
 }
 
-// GetBuildLogs returns the current build logs for a pod.
-func (s *DeployService) GetBuildLogs(podID string) []string {
-	s.buildLogsMu.RLock()
-	defer s.buildLogsMu.RUnlock()
-	return s.buildLogs[podID]
+// lockPod marks a pod as busy so deploys, rollbacks and restarts of the
+// same pod never run in parallel. Returns false if the pod is already locked.
+func (s *DeployService) lockPod(podID string) bool {
+	s.deployingMu.Lock()
+	defer s.deployingMu.Unlock()
+	if s.deploying[podID] {
+		return false
+	}
+	s.deploying[podID] = true
+	return true
 }
 
-// clearBuildLogs removes build logs for a pod (called at start of new build).
-func (s *DeployService) clearBuildLogs(podID string) {
-	s.buildLogsMu.Lock()
-	defer s.buildLogsMu.Unlock()
-	s.buildLogs[podID] = nil
+// unlockPod releases a pod. A queued deploy takes the lock over and starts.
+func (s *DeployService) unlockPod(podID string) {
+	s.deployingMu.Lock()
+	defer s.deployingMu.Unlock()
+	next, ok := s.queued[podID]
+	if !ok {
+		delete(s.deploying, podID)
+		return
+	}
+	delete(s.queued, podID)
+	go s.runQueued(podID, next)
 }
 
-// Deploy builds and runs a container for a pod.
-func (s *DeployService) Deploy(ctx context.Context, podID string) error {
-	// Prevent parallel deploys of the same pod
+// QueueDeploy queues a deploy for when the running deploy, rollback or
+// restart of a pod finishes. A newer queued deploy replaces an older one,
+// only the latest request is deployed. Returns false if nothing is running,
+// the caller starts the deploy itself then.
+func (s *DeployService) QueueDeploy(podID string, req model.DeployRequest, triggeredBy string) bool {
 	s.deployingMu.Lock()
-	if s.deploying[podID] {
-		s.deployingMu.Unlock()
-		return fmt.Errorf("deploy already in progress for pod %s", podID)
+	defer s.deployingMu.Unlock()
+	if !s.deploying[podID] {
+		return false
 	}
-	s.deploying[podID] = true
-	s.deployingMu.Unlock()
+	s.queued[podID] = queuedDeploy{req: req, triggeredBy: triggeredBy}
+	return true
+}
 
-	defer func() {
-		s.deployingMu.Lock()
-		delete(s.deploying, podID)
-		s.deployingMu.Unlock()
-	}()
+// runQueued starts a queued deploy on a pod that is still locked for it.
+func (s *DeployService) runQueued(podID string, next queuedDeploy) {
+	deployment, err := s.startDeploy(podID, next.req, next.triggeredBy)
+	if err != nil {
+		s.unlockPod(podID)
+		slog.Error("queued deploy failed", "podID", podID, "error", err)
+		return
+	}
+	err = s.RunDeploy(context.Background(), deployment)
+	if err != nil {
+		slog.Error("queued deploy failed", "podID", podID, "error", err)
+	}
+}
+
+// gitRefPattern matches commit SHAs and tag names accepted as deploy ref.
+// Refs starting with "-" are rejected so they can never be read as git flags.
+var gitRefPattern = regexp.MustCompile(`^[A-Za-z0-9_.][A-Za-z0-9_./-]*$`)
+
+// errHealthCheckFailed is returned when a new container never becomes healthy.
+// The previous container (if any) keeps running in that case.
+var errHealthCheckFailed = errors.New("health check failed")
+
+// imageTag returns the immutable image tag for a deployment.
+// Every build gets its own tag so older builds stay available for rollbacks.
+func imageTag(podID, deploymentID string) string {
+	return fmt.Sprintf("deeploy-%s:%s", podID, deploymentID)
+}
+
+// Deploy builds and runs a container for a pod.
+// req.Ref is an optional commit SHA or tag, empty deploys the tip of the pod's branch.
+// triggeredBy identifies who started the deploy (stored in the deployment history).
+func (s *DeployService) Deploy(ctx context.Context, podID string, req model.DeployRequest, triggeredBy string) error {
+	deployment, err := s.StartDeploy(podID, req, triggeredBy)
+	if err != nil {
+		return err
+	}
+	return s.RunDeploy(ctx, deployment)
+}
+
+// StartDeploy validates the pod, records a new deployment and marks the pod as
+// building. The build itself runs in RunDeploy, which must be called afterwards:
+// the pod stays locked against parallel deploys until RunDeploy returns.
+func (s *DeployService) StartDeploy(podID string, req model.DeployRequest, triggeredBy string) (*model.Deployment, error) {
+	if req.Ref != "" && !gitRefPattern.MatchString(req.Ref) {
+		return nil, fmt.Errorf("invalid ref %q: %w", req.Ref, errs.ErrInvalidInput)
+	}
 
-	// Clear old build logs
-	s.clearBuildLogs(podID)
+	// Prevent parallel deploys of the same pod
+	if !s.lockPod(podID) {
+		return nil, fmt.Errorf("deploy already in progress for pod %s: %w", podID, errs.ErrConflict)
+	}
 
+	deployment, err := s.startDeploy(podID, req, triggeredBy)
+	if err != nil {
+		s.unlockPod(podID)
+		return nil, err
+	}
+	return deployment, nil
+}
+
+func (s *DeployService) startDeploy(podID string, req model.DeployRequest, triggeredBy string) (*model.Deployment, error) {
 	// 1. Get pod
 	pod, err := s.podRepo.Pod(podID)
 	if err != nil {
-		s.appendBuildLog(podID, fmt.Sprintf("ERROR: pod not found: %v", err))
-		return fmt.Errorf("pod not found: %w", err)
+		return nil, err
 	}
 
-	if pod.RepoURL == nil || *pod.RepoURL == "" {
-		s.appendBuildLog(podID, "ERROR: pod has no repo URL configured")
-		return fmt.Errorf("pod has no repo URL configured")
+	ref := req.Ref
+	branch := pod.Branch
+	if pod.SourceType == model.PodSourceImage {
+		if pod.Image == nil || *pod.Image == "" {
+			return nil, fmt.Errorf("pod has no image configured: %w", errs.ErrInvalidInput)
+		}
+		// For image pods the ref replaces the image tag, the full reference is recorded
+		ref = withImageTag(*pod.Image, ref)
+		branch = ""
+	} else if pod.RepoURL == nil || *pod.RepoURL == "" {
+		return nil, fmt.Errorf("pod has no repo URL configured: %w", errs.ErrInvalidInput)
 	}
 
 	// 2. Check domain exists BEFORE starting build (fail fast)
 	domains, _ := s.podDomainRepo.DomainsByPod(podID)
 	if len(domains) == 0 {
-		s.appendBuildLog(podID, "ERROR: no domain configured - add a domain first")
-		return fmt.Errorf("no domain configured for pod")
+		return nil, fmt.Errorf("no domain configured - add a domain first: %w", errs.ErrInvalidInput)
+	}
+	if len(verifiedDomains(domains)) == 0 {
+		return nil, fmt.Errorf("no verified domain - point its DNS record to the server and re-check it, or ask a server admin to skip the check for domains behind a CDN: %w", errs.ErrInvalidInput)
+	}
+
+	// 3. Record deployment
+	deploymentID := uuid.New().String()
+	deployment := &model.Deployment{
+		ID:          deploymentID,
+		PodID:       podID,
+		Branch:      branch,
+		ImageTag:    imageTag(podID, deploymentID),
+		Status:      model.DeploymentStatusBuilding,
+		TriggeredBy: triggeredBy,
+		NoCache:     req.NoCache,
+	}
+	if ref != "" {
+		deployment.Ref = &ref
+	}
+	err = s.deploymentRepo.Create(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	}
+
+	err = s.buildLogs.Open(podID, deploymentID)
+	if err != nil {
+		s.deploymentRepo.Finish(deploymentID, model.DeploymentStatusFailed)
+		return nil, fmt.Errorf("failed to create build log: %w", err)
 	}
 
-	// 3. Update status to building
+	// 4. Update status to building
 	pod.Status = "building"
 	err = s.podRepo.Update(*pod)
 	if err != nil {
-		return fmt.Errorf("failed to update pod status: %w", err)
+		s.deploymentRepo.Finish(deploymentID, model.DeploymentStatusFailed)
+		s.buildLogs.Close(podID, deploymentID)
+		return nil, fmt.Errorf("failed to update pod status: %w", err)
 	}
 
-	s.appendBuildLog(podID, "=== Starting deployment ===")
-	s.appendBuildLog(podID, fmt.Sprintf("Repo: %s @ %s", *pod.RepoURL, pod.Branch))
+	return deployment, nil
+}
+
+// RunDeploy builds the image of a deployment created by StartDeploy and swaps
+// the pod's container to it.
+func (s *DeployService) RunDeploy(ctx context.Context, deployment *model.Deployment) error {
+	podID := deployment.PodID
+	deploymentID := deployment.ID
+	defer s.unlockPod(podID)
+	defer s.buildLogs.Close(podID, deploymentID)
+
+	logf := s.buildLogger(podID, deploymentID)
 
-	// 4. Get git token if configured
+	pod, err := s.podRepo.Pod(podID)
+	if err != nil {
+		s.deploymentRepo.Finish(deploymentID, model.DeploymentStatusFailed)
+		logf(fmt.Sprintf("ERROR: pod not found: %v", err))
+		return fmt.Errorf("pod not found: %w", err)
+	}
+
+	// fail marks pod and deployment as failed and writes the reason to the build log.
+	// A pod whose previous container was kept after a failed health check stays running.
+	fail := func(err error) error {
+		pod.Status = "failed"
+		if errors.Is(err, errHealthCheckFailed) && pod.ContainerID != nil {
+			pod.Status = "running"
+		}
+		s.podRepo.Update(*pod)
+		s.deploymentRepo.Finish(deploymentID, model.DeploymentStatusFailed)
+		logf(fmt.Sprintf("ERROR: %v", err))
+		return err
+	}
+
+	logf("=== Starting deployment ===")
+
+	// 1. Build or pull the image
+	if pod.SourceType == model.PodSourceImage {
+		err = s.pullImage(ctx, pod, deployment, logf)
+	} else {
+		err = s.buildImage(ctx, pod, deployment, logf)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	// 2. Swap containers (zero-downtime)
+	err = s.swapContainer(ctx, pod, deployment.ImageTag, logf)
+	if err != nil {
+		return fail(err)
+	}
+
+	err = s.deploymentRepo.Finish(deploymentID, model.DeploymentStatusSuccess)
+	if err != nil {
+		return fmt.Errorf("failed to update deployment: %w", err)
+	}
+	s.podRepo.UpdateCommit(podID, deployment.CommitSHA, deployment.CommitMessage)
+
+	logf("")
+	logf("=== Deployment successful! ===")
+	domains, _ := s.podDomainRepo.DomainsByPod(podID)
+	logf(fmt.Sprintf("Your app is available at %d domain(s)", len(verifiedDomains(domains))))
+
+	// 3. Remove images of old deployments beyond the retention limit
+	s.pruneDeploymentImages(ctx, podID)
+
+	return nil
+}
+
+// buildImage clones the pod's repo and builds the deployment image from it.
+func (s *DeployService) buildImage(ctx context.Context, pod *model.Pod, deployment *model.Deployment, logf func(string)) error {
+	if pod.RepoURL == nil || *pod.RepoURL == "" {
+		return fmt.Errorf("pod has no repo URL configured")
+	}
+
+	logf(fmt.Sprintf("Repo: %s @ %s", *pod.RepoURL, pod.Branch))
+	ref := ""
+	if deployment.Ref != nil {
+		ref = *deployment.Ref
+		logf(fmt.Sprintf("Ref: %s", ref))
+	}
+
+	// Get git token if configured
 	var gitToken string
 	if pod.GitTokenID != nil {
 		token, err := s.gitTokenService.GitToken(*pod.GitTokenID)
 		if err != nil {
-			s.appendBuildLog(podID, fmt.Sprintf("ERROR: failed to get git token: %v", err))
 			return fmt.Errorf("failed to get git token: %w", err)
 		}
 		gitToken = token.Token
-		s.appendBuildLog(podID, "Using configured git token for private repo")
+		logf("Using configured git token for private repo")
 	}
 
-	// 5. Clone repo
-	s.appendBuildLog(podID, "Cloning repository...")
-	clonePath, err := s.docker.CloneRepo(*pod.RepoURL, pod.Branch, gitToken)
+	// Update the pod's persistent checkout, no-cache deploys start from a fresh clone
+	if deployment.NoCache {
+		logf("Cloning repository (no cache)...")
+	} else {
+		logf("Fetching repository...")
+	}
+	clonePath, err := s.docker.SyncRepo(pod.ID, *pod.RepoURL, pod.Branch, ref, gitToken, deployment.NoCache)
 	if err != nil {
-		pod.Status = "failed"
-		s.podRepo.Update(*pod)
-		s.appendBuildLog(podID, fmt.Sprintf("ERROR: failed to clone repo: %v", err))
 		return fmt.Errorf("failed to clone repo: %w", err)
 	}
-	defer s.docker.Cleanup(clonePath)
-	s.appendBuildLog(podID, "Repository cloned successfully")
+	logf("Repository checked out successfully")
+
+	commitSHA, commitMessage, err := s.docker.HeadCommit(clonePath)
+	if err == nil {
+		deployment.CommitSHA = &commitSHA
+		deployment.CommitMessage = &commitMessage
+		s.deploymentRepo.UpdateCommit(deployment.ID, commitSHA, commitMessage)
+		logf(fmt.Sprintf("Commit: %s %s", commitSHA, commitMessage))
+	}
+
+	// Build image
+	logf("")
+	logf("=== Building Docker image ===")
+
+	envVars, err := s.podEnvVarService.EnvVarsByPod(pod.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get env vars: %w", err)
+	}
+	buildArgs := make(map[string]string)
+	for _, ev := range envVars {
+		if ev.IsBuild() {
+			buildArgs[ev.Key] = ev.Value
+		}
+	}
+	if len(buildArgs) > 0 {
+		logf(fmt.Sprintf("Using %d build args", len(buildArgs)))
+	}
 
-	// 6. Build image
-	s.appendBuildLog(podID, "")
-	s.appendBuildLog(podID, "=== Building Docker image ===")
-	imageName := fmt.Sprintf("deeploy-%s:latest", podID)
+	dockerfilePath := pod.DockerfilePath
+	if pod.BuildStrategy == model.BuildStrategyAuto {
+		plan, err := autobuild.Write(clonePath)
+		if err != nil {
+			return fmt.Errorf("auto build failed: %w", err)
+		}
+		dockerfilePath = autobuild.DockerfileName
+		logf(fmt.Sprintf("Build strategy: auto (detected %s)", plan.Name))
+		logf("Generated Dockerfile:")
+		for _, line := range strings.Split(strings.TrimRight(plan.Dockerfile, "\n"), "\n") {
+			logf("  " + line)
+		}
+		logf("")
+	}
 
-	logCallback := func(line string) {
-		s.appendBuildLog(podID, line)
+	// The image of the previous build seeds the layer cache
+	var cacheFrom []string
+	if deployment.NoCache {
+		logf("Building without cache")
+	} else if previous := s.previousImage(ctx, pod.ID, deployment.ID); previous != "" {
+		cacheFrom = []string{previous}
 	}
-	_, err = s.docker.BuildImage(ctx, clonePath, pod.DockerfilePath, imageName, logCallback)
+
+	_, err = s.docker.BuildImage(ctx, docker.BuildImageOptions{
+		BuildPath:      clonePath,
+		DockerfilePath: dockerfilePath,
+		ImageName:      deployment.ImageTag,
+		BuildArgs:      buildArgs,
+		CacheFrom:      cacheFrom,
+		NoCache:        deployment.NoCache,
+	}, logf)
 	if err != nil {
-		pod.Status = "failed"
-		s.podRepo.Update(*pod)
-		s.appendBuildLog(podID, fmt.Sprintf("ERROR: failed to build image: %v", err))
 		return fmt.Errorf("failed to build image: %w", err)
 	}
-	s.appendBuildLog(podID, "")
-	s.appendBuildLog(podID, "=== Docker image built successfully ===")
+	logf("")
+	logf("=== Docker image built successfully ===")
+
+	return nil
+}
+
+// previousImage returns the image of the newest successful deployment of a pod
+// other than deploymentID, or "" if there is none (anymore).
+func (s *DeployService) previousImage(ctx context.Context, podID, deploymentID string) string {
+	deployments, err := s.deploymentRepo.DeploymentsByPod(podID)
+	if err != nil {
+		return ""
+	}
+	for _, d := range deployments {
+		if d.ID == deploymentID || d.Status != model.DeploymentStatusSuccess {
+			continue
+		}
+		if s.docker.ImageExists(ctx, d.ImageTag) {
+			return d.ImageTag
+		}
+		return ""
+	}
+	return ""
+}
+
+// pullImage pulls the deployment image of an image pod from its registry.
+func (s *DeployService) pullImage(ctx context.Context, pod *model.Pod, deployment *model.Deployment, logf func(string)) error {
+	if deployment.Ref == nil || *deployment.Ref == "" {
+		return fmt.Errorf("pod has no image configured")
+	}
+	ref := *deployment.Ref
+	logf(fmt.Sprintf("Image: %s", ref))
+
+	var auth *registry.AuthConfig
+	if pod.RegistryCredentialID != nil {
+		credential, err := s.registryCredentialService.RegistryCredential(*pod.RegistryCredentialID)
+		if err != nil {
+			return fmt.Errorf("failed to get registry credentials: %w", err)
+		}
+		auth = &registry.AuthConfig{
+			Username:      credential.Username,
+			Password:      credential.Password,
+			ServerAddress: credential.Registry,
+		}
+		logf(fmt.Sprintf("Using registry credentials %q", credential.Name))
+	}
+
+	logf("")
+	logf("=== Pulling Docker image ===")
+	err := s.docker.PullImage(ctx, ref, deployment.ImageTag, auth, logf)
+	if err != nil {
+		return fmt.Errorf("failed to pull image: %w", err)
+	}
+	logf("")
+	logf("=== Docker image pulled successfully ===")
+
+	return nil
+}
+
+// withImageTag replaces the tag (or digest) of an image reference.
+// An empty tag returns the reference unchanged.
+func withImageTag(image, tag string) string {
+	if tag == "" {
+		return image
+	}
+	name, _, _ := strings.Cut(image, "@")
+	// A colon after the last slash separates the tag - colons before it belong to a registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + ":" + tag
+}
+
+// verifiedDomains returns the domains Traefik may route. Unverified ones
+// don't reach the server and would only fail their certificate challenge.
+func verifiedDomains(domains []model.PodDomain) []model.PodDomain {
+	var verified []model.PodDomain
+	for _, d := range domains {
+		if d.Status == model.DomainStatusVerified {
+			verified = append(verified, d)
+		}
+	}
+	return verified
+}
+
+// swapContainer starts a new container for the pod from imageName and stops the
+// previous one afterwards (zero-downtime). Used by Deploy, Restart and Rollback.
+// logf receives progress lines (can be nil).
+func (s *DeployService) swapContainer(ctx context.Context, pod *model.Pod, imageName string, logf func(string)) error {
+	if logf == nil {
+		logf = func(string) {}
+	}
+	podID := pod.ID
+
+	// 1. Prepare domains, env vars and volumes
+	domains, _ := s.podDomainRepo.DomainsByPod(podID)
+	for _, d := range domains {
+		if d.Status != model.DomainStatusVerified {
+			logf(fmt.Sprintf("Skipping %s%s: DNS not verified, re-check it under Domains", d.Domain, d.Path))
+		}
+	}
+	domains = verifiedDomains(domains)
+	if len(domains) == 0 {
+		return fmt.Errorf("no verified domain configured for pod")
+	}
+
+	certificates, err := s.certificateRepo.CertificatesByPod(podID)
+	if err != nil {
+		return fmt.Errorf("failed to get certificates: %w", err)
+	}
+	hasCertificate := make(map[string]bool)
+	for _, c := range certificates {
+		hasCertificate[c.PodDomainID] = true
+	}
+
+	certResolver, err := s.traefikService.CertResolver()
+	if err != nil {
+		return fmt.Errorf("failed to get certificate resolver: %w", err)
+	}
 
-	// 7. Prepare domains and env vars
 	var domainConfigs []docker.DomainConfig
 	for _, d := range domains {
 		domainConfigs = append(domainConfigs, docker.DomainConfig{
-			Domain: d.Domain,
-			Port:   d.Port,
+			Domain:            d.Domain,
+			Path:              d.Path,
+			RedirectTo:        d.RedirectTo,
+			Port:              d.Port,
+			SSL:               d.SSLEnabled,
+			CustomCertificate: hasCertificate[d.ID],
 		})
-		s.appendBuildLog(podID, fmt.Sprintf("Domain: %s (port %d)", d.Domain, d.Port))
+		tlsInfo := ""
+		if !d.SSLEnabled {
+			tlsInfo = ", no SSL"
+		} else if hasCertificate[d.ID] {
+			tlsInfo = ", custom certificate"
+		}
+		if d.Type == model.DomainTypeWildcard && d.SSLEnabled && !hasCertificate[d.ID] && certResolver == docker.HTTPCertResolver {
+			logf(fmt.Sprintf("Warning: %s needs a DNS provider in the server settings for its certificate", d.Domain))
+		}
+		if d.RedirectTo != "" {
+			logf(fmt.Sprintf("Domain: %s (redirect to %s%s)", d.Domain, d.RedirectTo, tlsInfo))
+		} else {
+			logf(fmt.Sprintf("Domain: %s%s (port %d%s)", d.Domain, d.Path, d.Port, tlsInfo))
+		}
 	}
 
 	// Get env vars (decrypted via service)
 	envVars, err := s.podEnvVarService.EnvVarsByPod(podID)
 	if err != nil {
-		pod.Status = "failed"
-		s.podRepo.Update(*pod)
 		return fmt.Errorf("failed to get env vars: %w", err)
 	}
 
 	envMap := make(map[string]string)
 	for _, ev := range envVars {
-		envMap[ev.Key] = ev.Value
+		if ev.IsRuntime() {
+			envMap[ev.Key] = ev.Value
+		}
 	}
 	if len(envMap) > 0 {
-		s.appendBuildLog(podID, fmt.Sprintf("Loaded %d environment variables", len(envMap)))
+		logf(fmt.Sprintf("Loaded %d environment variables", len(envMap)))
+	}
+
+	// Connection URLs of linked databases - env vars set by the user take precedence
+	databaseEnv, err := s.databaseService.LinkedEnvVars(podID)
+	if err != nil {
+		return fmt.Errorf("failed to get linked databases: %w", err)
+	}
+	for key, value := range databaseEnv {
+		if _, ok := envMap[key]; ok {
+			logf(fmt.Sprintf("Linked database: %s is set by an env var, skipping", key))
+			continue
+		}
+		envMap[key] = value
+		logf(fmt.Sprintf("Linked database: %s", key))
 	}
 
-	// 8. Rename existing container to make room for new one (zero-downtime)
+	volumes, err := s.podVolumeRepo.VolumesByPod(podID)
+	if err != nil {
+		return fmt.Errorf("failed to get volumes: %w", err)
+	}
+	var mounts []docker.VolumeMount
+	for _, v := range volumes {
+		mount := docker.VolumeMount{
+			Bind:     v.Type == model.VolumeTypeBind,
+			Source:   v.Source,
+			Target:   v.MountPath,
+			ReadOnly: v.ReadOnly,
+		}
+		if !mount.Bind {
+			mount.Source = v.VolumeName
+			mount.Labels = map[string]string{"deeploy.pod": podID, "deeploy.volume": v.Source}
+		}
+		mounts = append(mounts, mount)
+		logf(fmt.Sprintf("Volume: %s -> %s", v.Source, v.MountPath))
+	}
+
+	// 2. Rename existing container to make room for new one (zero-downtime)
 	oldContainerID := ""
 	containerName := fmt.Sprintf("deeploy-%s", podID)
 	if pod.ContainerID != nil && *pod.ContainerID != "" {
 		oldContainerID = *pod.ContainerID
-		s.appendBuildLog(podID, "Preparing zero-downtime deployment...")
+		logf("Preparing zero-downtime deployment...")
 		err := s.docker.RenameContainer(ctx, oldContainerID, fmt.Sprintf("deeploy-%s-old", podID))
 		if err != nil {
 			// Container ID is stale - cleanup DB and orphaned container
-			s.appendBuildLog(podID, "Cleaning up stale container...")
+			logf("Cleaning up stale container...")
 			pod.ContainerID = nil
 			s.podRepo.Update(*pod)
 			s.docker.StopContainer(ctx, containerName)
@@ -200,46 +635,69 @@ func (s *DeployService) Deploy(ctx context.Context, podID string) error {
 		}
 	}
 
-	// 9. Clean up any orphaned container with target name (from previous failed deploys)
+	// 3. Clean up any orphaned container with target name (from previous failed deploys)
 	// This handles edge case where deploy failed after container creation but before DB update
 	s.docker.StopContainer(ctx, containerName)
 	s.docker.RemoveContainer(ctx, containerName)
 
-	// 10. Run new container (old still running for zero-downtime)
-	s.appendBuildLog(podID, "")
-	s.appendBuildLog(podID, "=== Starting new container ===")
+	healthCheck := docker.HealthCheckConfig{
+		Path:     pod.HealthCheckPath,
+		Status:   pod.HealthCheckStatus,
+		Interval: time.Duration(pod.HealthCheckInterval) * time.Second,
+		Timeout:  time.Duration(pod.HealthCheckTimeout) * time.Second,
+	}
+
+	// 4. Run new container (old still running for zero-downtime)
+	logf("")
+	logf("=== Starting new container ===")
 	containerID, err := s.docker.RunContainer(ctx, docker.RunContainerOptions{
 		ImageName:     imageName,
-		ContainerName: fmt.Sprintf("deeploy-%s", podID),
+		ContainerName: containerName,
 		PodID:         podID,
 		Domains:       domainConfigs,
+		CertResolver:  certResolver,
 		EnvVars:       envMap,
+		Volumes:       mounts,
+		HealthCheck:   healthCheck,
+		Resources: docker.ResourceLimits{
+			MemoryMB:            pod.MemoryLimit,
+			MemoryReservationMB: pod.MemoryReservation,
+			CPUs:                pod.CPULimit,
+			PIDs:                pod.PIDsLimit,
+		},
 	})
 	if err != nil {
 		// Rollback: rename old container back
 		if oldContainerID != "" {
-			s.docker.RenameContainer(ctx, oldContainerID, fmt.Sprintf("deeploy-%s", podID))
+			s.docker.RenameContainer(ctx, oldContainerID, containerName)
 		}
-		pod.Status = "failed"
-		s.podRepo.Update(*pod)
-		s.appendBuildLog(podID, fmt.Sprintf("ERROR: failed to run container: %v", err))
 		return fmt.Errorf("failed to run container: %w", err)
 	}
 
-	// 11. Stop old container (Traefik handles health checks and routing)
-	// Wait 5s before stopping old container for zero-downtime deployment:
-	// - Traefik health check interval is 2s
-	// - Gives new container time to be detected and marked healthy
-	// - Old container handles in-flight requests during transition
-	// TODO: For v0.2+, could query Traefik API to confirm routing instead of fixed delay
+	// 5. Wait until the new container passes its health check
+	err = s.waitHealthy(ctx, pod, containerID, docker.ServicePort(domainConfigs), logf)
+	if err != nil {
+		// Rollback: remove the new container, the old one keeps serving
+		s.docker.StopContainer(ctx, containerID)
+		s.docker.RemoveContainer(ctx, containerID)
+		if oldContainerID != "" {
+			s.docker.RenameContainer(ctx, oldContainerID, containerName)
+			logf("Kept the previous container running")
+		}
+		return err
+	}
+
+	// 6. Stop old container
+	// Traefik checks the new container on its own - wait one interval so it
+	// routes to it before the old one stops handling requests
 	if oldContainerID != "" {
-		time.Sleep(5 * time.Second)
-		s.appendBuildLog(podID, "Stopping old container...")
+		time.Sleep(healthCheck.Interval)
+		logf("Stopping old container...")
 		s.docker.StopContainer(ctx, oldContainerID)
 		s.docker.RemoveContainer(ctx, oldContainerID)
 	}
 
-	// 12. Update pod with container ID and status
+	// 7. Update pod with container ID and status
 	pod.ContainerID = &containerID
 	pod.Status = "running"
 	err = s.podRepo.Update(*pod)
@@ -247,10 +705,177 @@ func (s *DeployService) Deploy(ctx context.Context, podID string) error {
 		return fmt.Errorf("failed to update pod: %w", err)
 	}
 
-	s.appendBuildLog(podID, fmt.Sprintf("Container started: %s", containerID[:12]))
-	s.appendBuildLog(podID, "")
-	s.appendBuildLog(podID, "=== Deployment successful! ===")
-	s.appendBuildLog(podID, fmt.Sprintf("Your app is available at %d domain(s)", len(domains)))
+	logf(fmt.Sprintf("Container started: %s", containerID[:12]))
+
+	return nil
+}
+
+// waitHealthy polls the health check of a new container until it passes.
+// It fails if the container does not become healthy within the pod's grace
+// period or stops running.
+func (s *DeployService) waitHealthy(ctx context.Context, pod *model.Pod, containerID string, port int, logf func(string)) error {
+	interval := time.Duration(pod.HealthCheckInterval) * time.Second
+	timeout := time.Duration(pod.HealthCheckTimeout) * time.Second
+	// Pods saved before the limit may have a longer one
+	gracePeriod := time.Duration(min(pod.HealthCheckGracePeriod, model.MaxHealthCheckGracePeriod)) * time.Second
+	deadline := time.Now().Add(gracePeriod)
+
+	logf(fmt.Sprintf("Waiting for health check: GET %s on port %d (up to %s)", pod.HealthCheckPath, port, gracePeriod))
+	for {
+		status, err := s.docker.ProbeContainer(ctx, containerID, port, pod.HealthCheckPath, timeout)
+		if err == nil && healthyStatus(status, pod.HealthCheckStatus) {
+			logf(fmt.Sprintf("Health check passed (%d)", status))
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected status %d", status)
+		}
+		logf(fmt.Sprintf("Health check: %v", err))
+
+		// A stopped container will not recover
+		state, _ := s.docker.GetContainerState(ctx, containerID)
+		if state == "exited" || state == "dead" {
+			return fmt.Errorf("%w: container stopped: %v", errHealthCheckFailed, err)
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("%w: not healthy after %s: %v", errHealthCheckFailed, gracePeriod, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// healthyStatus reports whether a health check response status passes.
+// expected 0 accepts any 2xx/3xx, like Traefik does.
+func healthyStatus(status, expected int) bool {
+	if expected != 0 {
+		return status == expected
+	}
+	return status >= 200 && status < 400
+}
+
+// pruneDeploymentImages removes images of old deployments, keeping the images
+// of the newest keepDeploymentImages deployments available for rollbacks.
+func (s *DeployService) pruneDeploymentImages(ctx context.Context, podID string) {
+	deployments, err := s.deploymentRepo.DeploymentsByPod(podID)
+	if err != nil {
+		return
+	}
+
+	// Rollbacks reuse the image of an older deployment, so count distinct tags
+	keep := make(map[string]bool)
+	for _, d := range deployments {
+		if d.Status != model.DeploymentStatusSuccess || keep[d.ImageTag] {
+			continue
+		}
+		if len(keep) >= keepDeploymentImages {
+			break
+		}
+		keep[d.ImageTag] = true
+	}
+
+	removed := make(map[string]bool)
+	for _, d := range deployments {
+		if keep[d.ImageTag] || removed[d.ImageTag] {
+			continue
+		}
+		removed[d.ImageTag] = true
+		if s.docker.ImageExists(ctx, d.ImageTag) {
+			s.docker.RemoveImage(ctx, d.ImageTag)
+		}
+	}
+
+	// Layers of failed builds are left dangling, layers of kept images stay as build cache
+	s.docker.PruneDanglingImages(ctx)
+}
+
+// Deployments returns the deployment history of a pod, newest first.
+func (s *DeployService) Deployments(podID string) ([]model.Deployment, error) {
+	return s.deploymentRepo.DeploymentsByPod(podID)
+}
+
+// StartRollback checks the target of a rollback and records the rollback as
+// a new deployment. It redeploys the image of an earlier successful deployment
+// without rebuilding in RunRollback, which must be called afterwards: the pod
+// stays locked against parallel deploys until RunRollback returns.
+func (s *DeployService) StartRollback(ctx context.Context, podID, deploymentID, triggeredBy string) (*model.Deployment, error) {
+	if !s.lockPod(podID) {
+		return nil, fmt.Errorf("deploy already in progress for pod %s: %w", podID, errs.ErrConflict)
+	}
+
+	deployment, err := s.startRollback(ctx, podID, deploymentID, triggeredBy)
+	if err != nil {
+		s.unlockPod(podID)
+		return nil, err
+	}
+	return deployment, nil
+}
+
+func (s *DeployService) startRollback(ctx context.Context, podID, deploymentID, triggeredBy string) (*model.Deployment, error) {
+	target, err := s.deploymentRepo.Deployment(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if target.PodID != podID {
+		return nil, fmt.Errorf("deployment %s: %w", deploymentID, errs.ErrNotFound)
+	}
+	if target.Status != model.DeploymentStatusSuccess {
+		return nil, fmt.Errorf("only successful deployments can be rolled back to: %w", errs.ErrInvalidInput)
+	}
+	if !s.docker.ImageExists(ctx, target.ImageTag) {
+		return nil, fmt.Errorf("image %s is no longer available: %w", target.ImageTag, errs.ErrInvalidInput)
+	}
+
+	deployment := &model.Deployment{
+		ID:            uuid.New().String(),
+		PodID:         podID,
+		Ref:           target.Ref,
+		CommitSHA:     target.CommitSHA,
+		CommitMessage: target.CommitMessage,
+		Branch:        target.Branch,
+		ImageTag:      target.ImageTag,
+		Status:        model.DeploymentStatusBuilding,
+		TriggeredBy:   triggeredBy,
+		RollbackOf:    &target.ID,
+	}
+	err = s.deploymentRepo.Create(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	}
+	return deployment, nil
+}
+
+// RunRollback swaps the container of a pod to the image of a rollback
+// started by StartRollback and unlocks the pod when done.
+func (s *DeployService) RunRollback(ctx context.Context, deployment *model.Deployment) error {
+	podID := deployment.PodID
+	defer s.unlockPod(podID)
+	defer s.buildLogs.Close(podID, deployment.ID)
+	logf := s.buildLogger(podID, deployment.ID)
+	logf(fmt.Sprintf("=== Rolling back to deployment %s ===", *deployment.RollbackOf))
+	logf(fmt.Sprintf("Image: %s", deployment.ImageTag))
+
+	pod, err := s.podRepo.Pod(podID)
+	if err == nil {
+		err = s.swapContainer(ctx, pod, deployment.ImageTag, logf)
+	}
+	if err != nil {
+		s.deploymentRepo.Finish(deployment.ID, model.DeploymentStatusFailed)
+		logf(fmt.Sprintf("ERROR: %v", err))
+		return err
+	}
+	logf("")
+	logf("=== Rollback successful! ===")
+
+	err = s.deploymentRepo.Finish(deployment.ID, model.DeploymentStatusSuccess)
+	if err != nil {
+		return fmt.Errorf("failed to update deployment: %w", err)
+	}
+	s.podRepo.UpdateCommit(podID, deployment.CommitSHA, deployment.CommitMessage)
 
 	return nil
 }
@@ -280,22 +905,36 @@ func (s *DeployService) Stop(ctx context.Context, podID string) error {
 	return nil
 }
 
-// Restart restarts a running container with current config (zero-downtime).
-// Unlike Deploy, this does not rebuild the image - it reuses the existing one.
-func (s *DeployService) Restart(ctx context.Context, podID string) error {
+// StartRestart prepares restarting a running container with current config
+// (zero-downtime) and returns the image it runs. Unlike Deploy, a restart
+// does not rebuild the image - RunRestart reuses it and must be called
+// afterwards: the pod stays locked against parallel deploys until it returns.
+func (s *DeployService) StartRestart(ctx context.Context, podID string) (string, error) {
+	if !s.lockPod(podID) {
+		return "", fmt.Errorf("deploy already in progress for pod %s: %w", podID, errs.ErrConflict)
+	}
+
+	imageName, err := s.startRestart(ctx, podID)
+	if err != nil {
+		s.unlockPod(podID)
+		return "", err
+	}
+	return imageName, nil
+}
+
+func (s *DeployService) startRestart(ctx context.Context, podID string) (string, error) {
 	// 1. Load pod
 	pod, err := s.podRepo.Pod(podID)
 	if err != nil {
-		return fmt.Errorf("pod not found: %w", err)
+		return "", fmt.Errorf("pod not found: %w", err)
 	}
 
 	if pod.ContainerID == nil || *pod.ContainerID == "" {
-		return fmt.Errorf("pod has no running container")
+		return "", fmt.Errorf("pod has no running container")
 	}
-	oldContainerID := *pod.ContainerID
 
 	// 2. Get image from current container
-	imageName, err := s.docker.GetContainerImage(ctx, oldContainerID)
+	imageName, err := s.docker.GetContainerImage(ctx, *pod.ContainerID)
 	if err != nil {
 		// Container ID is stale - clear from DB
 		pod.ContainerID = nil
@@ -307,82 +946,246 @@ func (s *DeployService) Restart(ctx context.Context, podID string) error {
 		s.docker.StopContainer(ctx, containerName)
 		s.docker.RemoveContainer(ctx, containerName)
 
-		return fmt.Errorf("container not found - use deploy instead")
+		return "", fmt.Errorf("container not found - use deploy instead")
 	}
+	return imageName, nil
+}
 
-	// 3. Get domains
-	domains, _ := s.podDomainRepo.DomainsByPod(podID)
-	if len(domains) == 0 {
-		return fmt.Errorf("no domain configured for pod")
-	}
+// RunRestart starts a new container of a pod from imageName, stops the old
+// one and unlocks the pod when done.
+func (s *DeployService) RunRestart(ctx context.Context, podID, imageName string) error {
+	defer s.unlockPod(podID)
 
-	var domainConfigs []docker.DomainConfig
-	for _, d := range domains {
-		domainConfigs = append(domainConfigs, docker.DomainConfig{
-			Domain: d.Domain,
-			Port:   d.Port,
-		})
+	pod, err := s.podRepo.Pod(podID)
+	if err != nil {
+		return err
 	}
+	// 3. Start new container from the same image and stop the old one
+	return s.swapContainer(ctx, pod, imageName, nil)
+}
 
-	// 4. Get env vars (decrypted via service)
-	envVars, err := s.podEnvVarService.EnvVarsByPod(podID)
+// GetLogs returns build logs (if building) or container logs (if running).
+// Build logs are the last lines of the newest deployment's log.
+func (s *DeployService) GetLogs(ctx context.Context, podID string, lines int) ([]string, string, error) {
+	pod, err := s.podRepo.Pod(podID)
 	if err != nil {
-		return fmt.Errorf("failed to get env vars: %w", err)
+		return nil, "", fmt.Errorf("pod not found: %w", err)
 	}
 
-	envMap := make(map[string]string)
-	for _, ev := range envVars {
-		envMap[ev.Key] = ev.Value
+	// Return build logs if building or no container yet
+	if pod.Status == "building" || pod.ContainerID == nil || *pod.ContainerID == "" {
+		logs, err := s.latestBuildLog(podID, lines)
+		return logs, pod.Status, err
 	}
 
-	// 5. Rename old container (zero-downtime: keep running)
-	s.docker.RenameContainer(ctx, oldContainerID, fmt.Sprintf("deeploy-%s-old", podID))
+	// Return container logs
+	logs, err := s.docker.GetLogsLines(ctx, *pod.ContainerID, lines)
+	return logs, pod.Status, err
+}
 
-	// 6. Start new container
-	containerID, err := s.docker.RunContainer(ctx, docker.RunContainerOptions{
-		ImageName:     imageName,
-		ContainerName: fmt.Sprintf("deeploy-%s", podID),
-		PodID:         podID,
-		Domains:       domainConfigs,
-		EnvVars:       envMap,
-	})
+// latestBuildLog returns the last n lines of the newest deployment's build log.
+func (s *DeployService) latestBuildLog(podID string, n int) ([]string, error) {
+	deployments, err := s.deploymentRepo.DeploymentsByPod(podID)
+	if err != nil || len(deployments) == 0 {
+		return []string{}, err
+	}
+
+	logs, total, err := s.buildLogs.Read(podID, deployments[0].ID, 0, 0)
 	if err != nil {
-		// Rollback: rename old container back
-		s.docker.RenameContainer(ctx, oldContainerID, fmt.Sprintf("deeploy-%s", podID))
-		return fmt.Errorf("failed to run container: %w", err)
+		return nil, err
 	}
+	return logs[max(total-n, 0):], nil
+}
 
-	// 7. Stop old container (Traefik handles health checks and routing)
-	// Wait 5s for zero-downtime (see Deploy function for detailed comment)
-	time.Sleep(5 * time.Second)
-	s.docker.StopContainer(ctx, oldContainerID)
-	s.docker.RemoveContainer(ctx, oldContainerID)
+// StreamLogs sends the build log of a running deployment and then follows the
+// container logs of the pod. cursor is the ID of the last event the client
+// received (empty for a new stream): build cursors resume at a line offset,
+// container cursors at a Docker timestamp. Returns when there is nothing left
+// to follow, ctx is cancelled or send fails.
+func (s *DeployService) StreamLogs(ctx context.Context, podID, cursor string, send func(model.LogEvent) error) error {
+	pod, err := s.podRepo.Pod(podID)
+	if err != nil {
+		return err
+	}
 
-	// 8. Update pod
-	pod.ContainerID = &containerID
-	pod.Status = "running"
-	err = s.podRepo.Update(*pod)
+	err = send(model.LogEvent{Source: model.LogSourceStatus, Data: pod.Status})
 	if err != nil {
-		return fmt.Errorf("failed to update pod: %w", err)
+		return err
 	}
 
-	return nil
+	source, ref, offset := parseLogCursor(cursor)
+	lastBuildID := ""
+
+	for {
+		// 1. Build log: while building, if there is no container yet,
+		// or when resuming inside a build log
+		if source != model.LogSourceContainer {
+			deployments, err := s.deploymentRepo.DeploymentsByPod(podID)
+			if err != nil {
+				return err
+			}
+			if len(deployments) > 0 {
+				latest := deployments[0]
+				resuming := source == model.LogSourceBuild && ref == latest.ID
+				noContainer := pod.ContainerID == nil || *pod.ContainerID == ""
+				if latest.Status == model.DeploymentStatusBuilding || noContainer || resuming {
+					if !resuming {
+						offset = 0
+					}
+					err := s.streamBuildLog(ctx, podID, latest.ID, offset, send)
+					if err != nil {
+						return err
+					}
+					lastBuildID = latest.ID
+
+					// Build finished - report the resulting pod status
+					pod, err = s.podRepo.Pod(podID)
+					if err != nil {
+						return err
+					}
+					err = send(model.LogEvent{Source: model.LogSourceStatus, Data: pod.Status})
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if pod.ContainerID == nil || *pod.ContainerID == "" {
+			return nil
+		}
+
+		// 2. Container logs (resume after the last delivered timestamp)
+		since := ""
+		var sinceTime time.Time
+		if source == model.LogSourceContainer {
+			since = ref
+			sinceTime, _ = time.Parse(time.RFC3339Nano, ref)
+		}
+		err = s.docker.FollowLogs(ctx, *pod.ContainerID, since, 100, func(ts time.Time, line string) error {
+			// Docker's since is inclusive - skip lines the client already has
+			if !sinceTime.IsZero() && !ts.After(sinceTime) {
+				return nil
+			}
+			return send(model.LogEvent{
+				ID:     model.LogSourceContainer + ":" + ts.Format(time.RFC3339Nano),
+				Source: model.LogSourceContainer,
+				Data:   line,
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		// 3. Container stopped. A redeploy replaces the container - keep following
+		// the new build, otherwise report the final status and end the stream.
+		pod, err = s.podRepo.Pod(podID)
+		if err != nil {
+			return err
+		}
+		deployments, err := s.deploymentRepo.DeploymentsByPod(podID)
+		if err != nil {
+			return err
+		}
+		if len(deployments) > 0 && deployments[0].Status == model.DeploymentStatusBuilding && deployments[0].ID != lastBuildID {
+			source, ref, offset = "", "", 0
+			continue
+		}
+		return send(model.LogEvent{Source: model.LogSourceStatus, Data: pod.Status})
+	}
 }
 
-// GetLogs returns build logs (if building) or container logs (if running).
-func (s *DeployService) GetLogs(ctx context.Context, podID string, lines int) ([]string, string, error) {
-	pod, err := s.podRepo.Pod(podID)
+// streamBuildLog sends the build log of a deployment from offset and follows it
+// until the build finishes.
+func (s *DeployService) streamBuildLog(ctx context.Context, podID, deploymentID string, offset int, send func(model.LogEvent) error) error {
+	updates, cancel := s.buildLogs.Subscribe(deploymentID)
+	defer cancel()
+
+	// Later reads continue at the byte position of the last line sent
+	pos, offset, err := s.buildLogs.Seek(podID, deploymentID, offset)
 	if err != nil {
-		return nil, "", fmt.Errorf("pod not found: %w", err)
+		return err
 	}
 
-	// Return build logs if building or no container yet
-	if pod.Status == "building" || pod.ContainerID == nil || *pod.ContainerID == "" {
-		return s.GetBuildLogs(podID), pod.Status, nil
+	done := false
+	for {
+		lines, next, err := s.buildLogs.ReadFrom(podID, deploymentID, pos)
+		if err != nil {
+			return err
+		}
+		pos = next
+		for _, line := range lines {
+			offset++
+			err := send(model.LogEvent{
+				ID:     fmt.Sprintf("%s:%s:%d", model.LogSourceBuild, deploymentID, offset),
+				Source: model.LogSourceBuild,
+				Data:   line,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Lines written before the log was closed have been read - we're done
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-updates:
+			done = !ok
+		}
 	}
+}
 
-	// Return container logs
-	logs, err := s.docker.GetLogsLines(ctx, *pod.ContainerID, lines)
-	return logs, pod.Status, err
+// parseLogCursor splits a log stream cursor into source, reference and offset:
+//
+//	build:<deploymentID>:<line offset>
+//	container:<RFC3339Nano timestamp>
+func parseLogCursor(cursor string) (string, string, int) {
+	source, rest, _ := strings.Cut(cursor, ":")
+	switch source {
+	case model.LogSourceBuild:
+		i := strings.LastIndex(rest, ":")
+		if i < 0 {
+			return "", "", 0
+		}
+		offset, err := strconv.Atoi(rest[i+1:])
+		if err != nil || offset < 0 {
+			return "", "", 0
+		}
+		return source, rest[:i], offset
+	case model.LogSourceContainer:
+		_, err := time.Parse(time.RFC3339Nano, rest)
+		if err != nil {
+			return "", "", 0
+		}
+		return source, rest, 0
+	}
+	return "", "", 0
 }
 
+// DeploymentLogs returns a page of the persisted build log of a deployment.
+func (s *DeployService) DeploymentLogs(podID, deploymentID string, offset, limit int) (*model.DeploymentLogs, error) {
+	deployment, err := s.deploymentRepo.Deployment(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.PodID != podID {
+		return nil, fmt.Errorf("deployment %s: %w", deploymentID, errs.ErrNotFound)
+	}
+
+	lines, total, err := s.buildLogs.Read(podID, deploymentID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DeploymentLogs{
+		Lines:  lines,
+		Offset: offset,
+		Total:  total,
+		Status: deployment.Status,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+func TestParseLogCursor(t *testing.T) {
+	tests := []struct {
+		cursor string
+		source string
+		ref    string
+		offset int
+	}{
+		{"build:dep-1:42", "build", "dep-1", 42},
+		{"build:dep-1:0", "build", "dep-1", 0},
+		{"build:dep:with:colons:7", "build", "dep:with:colons", 7},
+		{"build:dep-1", "", "", 0},
+		{"build:dep-1:-3", "", "", 0},
+		{"build:dep-1:x", "", "", 0},
+		{"container:2026-01-02T15:04:05.123456789Z", "container", "2026-01-02T15:04:05.123456789Z", 0},
+		{"container:yesterday", "", "", 0},
+		{"status:1", "", "", 0},
+		{"", "", "", 0},
+	}
+	for _, tt := range tests {
+		source, ref, offset := parseLogCursor(tt.cursor)
+		if source != tt.source || ref != tt.ref || offset != tt.offset {
+			t.Errorf("parseLogCursor(%q) = %q, %q, %d, want %q, %q, %d", tt.cursor, source, ref, offset, tt.source, tt.ref, tt.offset)
+		}
+	}
+}
+
+func TestHealthyStatus(t *testing.T) {
+	tests := []struct {
+		status, expected int
+		want             bool
+	}{
+		{200, 0, true},
+		{204, 0, true},
+		{302, 0, true},
+		{399, 0, true},
+		{199, 0, false},
+		{404, 0, false},
+		{500, 0, false},
+		{401, 401, true},
+		{200, 401, false},
+		{204, 200, false},
+	}
+	for _, tt := range tests {
+		if got := healthyStatus(tt.status, tt.expected); got != tt.want {
+			t.Errorf("healthyStatus(%d, %d) = %v, want %v", tt.status, tt.expected, got, tt.want)
+		}
+	}
+}
+
+func TestQueueDeploy(t *testing.T) {
+	s := &DeployService{deploying: make(map[string]bool), queued: make(map[string]queuedDeploy)}
+
+	if s.QueueDeploy("pod", model.DeployRequest{Ref: "a"}, "webhook") {
+		t.Fatalf("queued a deploy while none is running")
+	}
+
+	s.lockPod("pod")
+	for _, ref := range []string{"a", "b"} {
+		if !s.QueueDeploy("pod", model.DeployRequest{Ref: ref}, "webhook") {
+			t.Fatalf("deploy of %s not queued while one is running", ref)
+		}
+	}
+	if next := s.queued["pod"]; next.req.Ref != "b" {
+		t.Errorf("queued ref = %q, want the latest push b", next.req.Ref)
+	}
+	if s.QueueDeploy("other", model.DeployRequest{Ref: "a"}, "webhook") {
+		t.Errorf("queued a deploy of a pod without a running deploy")
+	}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/shared/utils"
+	"github.com/google/uuid"
+)
+
+type MemberServiceInterface interface {
+	Role(projectID, userID string) (string, error)
+	Authorize(projectID, userID, role string) error
+	AuthorizePod(podID, userID, role string) error
+	AuthorizeDatabase(databaseID, userID, role string) error
+	AuthorizeBackup(backupID, userID, role string) error
+	Members(projectID string) (*model.Members, error)
+	Invite(invite *model.ProjectInvite) error
+	InviteByToken(token string) (*model.ProjectInvite, error)
+	AcceptInvite(token, email string) error
+	RevokeInvite(projectID, inviteID string) error
+	UpdateRole(projectID, userID, role string) error
+	RemoveMember(projectID, userID string) error
+}
+
+// roleRanks orders the project roles, a higher rank includes the lower ones.
+var roleRanks = map[string]int{
+	model.ProjectRoleViewer:    1,
+	model.ProjectRoleDeveloper: 2,
+	model.ProjectRoleOwner:     3,
+}
+
+func IsProjectRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// MemberService manages project members and decides what they may do.
+type MemberService struct {
+	repo          repo.MemberRepoInterface
+	userRepo      repo.UserRepoInterface
+	podRepo       repo.PodRepoInterface
+	podVolumeRepo repo.PodVolumeRepoInterface
+	databaseRepo  repo.DatabaseRepoInterface
+	backupRepo    repo.BackupRepoInterface
+}
+
+func NewMemberService(
+	repo *repo.MemberRepo,
+	userRepo *repo.UserRepo,
+	podRepo *repo.PodRepo,
+	podVolumeRepo *repo.PodVolumeRepo,
+	databaseRepo *repo.DatabaseRepo,
+	backupRepo *repo.BackupRepo,
+) *MemberService {
+	return &MemberService{
+		repo:          repo,
+		userRepo:      userRepo,
+		podRepo:       podRepo,
+		podVolumeRepo: podVolumeRepo,
+		databaseRepo:  databaseRepo,
+		backupRepo:    backupRepo,
+	}
+}
+
+// Role returns the role of a user in a project, ErrNotFound for non-members.
+func (s *MemberService) Role(projectID, userID string) (string, error) {
+	return s.repo.Role(projectID, userID)
+}
+
+// Authorize checks that a user has at least role in a project.
+// Non-members get ErrNotFound, so projects of others stay invisible.
+func (s *MemberService) Authorize(projectID, userID, role string) error {
+	current, err := s.repo.Role(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if roleRanks[current] < roleRanks[role] {
+		return fmt.Errorf("requires the %s role in this project: %w", role, errs.ErrForbidden)
+	}
+	return nil
+}
+
+// AuthorizePod checks that a user has at least role in the project of a pod.
+func (s *MemberService) AuthorizePod(podID, userID, role string) error {
+	pod, err := s.podRepo.Pod(podID)
+	if err != nil {
+		return err
+	}
+	err = s.Authorize(pod.ProjectID, userID, role)
+	if errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("pod %s: %w", podID, errs.ErrNotFound)
+	}
+	return err
+}
+
+// AuthorizeDatabase checks that a user has at least role in the project of a database.
+func (s *MemberService) AuthorizeDatabase(databaseID, userID, role string) error {
+	database, err := s.databaseRepo.Database(databaseID)
+	if err != nil {
+		return err
+	}
+	err = s.Authorize(database.ProjectID, userID, role)
+	if errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("database %s: %w", databaseID, errs.ErrNotFound)
+	}
+	return err
+}
+
+// AuthorizeBackup checks that a user has at least role in the project
+// of the database or pod volume a backup was taken of.
+func (s *MemberService) AuthorizeBackup(backupID, userID, role string) error {
+	b, err := s.backupRepo.Backup(backupID)
+	if err != nil {
+		return err
+	}
+
+	switch b.TargetType {
+	case model.BackupTargetDatabase:
+		err = s.AuthorizeDatabase(b.TargetID, userID, role)
+	case model.BackupTargetVolume:
+		var volume *model.PodVolume
+		volume, err = s.podVolumeRepo.Volume(b.TargetID)
+		if err == nil {
+			err = s.AuthorizePod(volume.PodID, userID, role)
+		}
+	default:
+		err = fmt.Errorf("unknown backup target %s: %w", b.TargetType, errs.ErrNotFound)
+	}
+	if errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("backup %s: %w", backupID, errs.ErrNotFound)
+	}
+	return err
+}
+
+// Members returns the members and pending invites of a project.
+func (s *MemberService) Members(projectID string) (*model.Members, error) {
+	members, err := s.repo.Members(projectID)
+	if err != nil {
+		return nil, err
+	}
+	invites, err := s.repo.Invites(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Members{Members: members, Invites: invites}, nil
+}
+
+// hashInviteToken returns the stored form of an invite token.
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Invite creates an invite with a new token, set on the invite and only returned once.
+func (s *MemberService) Invite(invite *model.ProjectInvite) error {
+	invite.Email = strings.TrimSpace(invite.Email)
+	if !utils.IsEmailValid(invite.Email) {
+		return fmt.Errorf("not a valid email: %w", errs.ErrInvalidInput)
+	}
+	if !IsProjectRole(invite.Role) {
+		return fmt.Errorf("unknown role %s: %w", invite.Role, errs.ErrInvalidInput)
+	}
+
+	user, err := s.userRepo.GetUserByEmail(invite.Email)
+	if err != nil {
+		return err
+	}
+	if user != nil {
+		_, err := s.repo.Role(invite.ProjectID, user.ID)
+		if err == nil {
+			return fmt.Errorf("%s is already a member: %w", invite.Email, errs.ErrConflict)
+		}
+		if !errors.Is(err, errs.ErrNotFound) {
+			return err
+		}
+	}
+
+	b := make([]byte, 32)
+	_, err = rand.Read(b)
+	if err != nil {
+		return err
+	}
+	invite.ID = uuid.New().String()
+	invite.Token = hex.EncodeToString(b)
+	invite.TokenHash = hashInviteToken(invite.Token)
+	invite.ExpiresAt = time.Now().UTC().Add(model.DefaultInviteDays * 24 * time.Hour)
+	invite.CreatedAt = time.Now().UTC()
+
+	return s.repo.CreateInvite(invite)
+}
+
+// InviteByToken returns a pending invite, ErrNotFound if it is unknown or expired.
+func (s *MemberService) InviteByToken(token string) (*model.ProjectInvite, error) {
+	invite, err := s.repo.InviteByTokenHash(hashInviteToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(invite.ExpiresAt) {
+		return nil, fmt.Errorf("invite expired: %w", errs.ErrNotFound)
+	}
+	return invite, nil
+}
+
+// AcceptInvite adds the user with email to the project of an invite for that email.
+// Members keep their current role.
+func (s *MemberService) AcceptInvite(token, email string) error {
+	invite, err := s.InviteByToken(token)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(invite.Email, email) {
+		return fmt.Errorf("invite is for another email: %w", errs.ErrInvalidInput)
+	}
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("user %s: %w", email, errs.ErrNotFound)
+	}
+
+	_, err = s.repo.Role(invite.ProjectID, user.ID)
+	if errors.Is(err, errs.ErrNotFound) {
+		err = s.repo.AddMember(model.ProjectMember{
+			ProjectID: invite.ProjectID,
+			UserID:    user.ID,
+			Role:      invite.Role,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteInvite(invite.ID)
+}
+
+func (s *MemberService) RevokeInvite(projectID, inviteID string) error {
+	invites, err := s.repo.Invites(projectID)
+	if err != nil {
+		return err
+	}
+	for _, invite := range invites {
+		if invite.ID == inviteID {
+			return s.repo.DeleteInvite(inviteID)
+		}
+	}
+	return fmt.Errorf("invite %s: %w", inviteID, errs.ErrNotFound)
+}
+
+// UpdateRole changes the role of a member. A project always keeps an owner.
+func (s *MemberService) UpdateRole(projectID, userID, role string) error {
+	if !IsProjectRole(role) {
+		return fmt.Errorf("unknown role %s: %w", role, errs.ErrInvalidInput)
+	}
+	if role != model.ProjectRoleOwner {
+		err := s.keepOwner(projectID, userID)
+		if err != nil {
+			return err
+		}
+	}
+	return s.repo.UpdateRole(projectID, userID, role)
+}
+
+// RemoveMember removes a member from a project. A project always keeps an owner.
+func (s *MemberService) RemoveMember(projectID, userID string) error {
+	err := s.keepOwner(projectID, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.RemoveMember(projectID, userID)
+}
+
+// keepOwner rejects changes that would take the last owner of a project.
+func (s *MemberService) keepOwner(projectID, userID string) error {
+	role, err := s.repo.Role(projectID, userID)
+	if errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("member %s: %w", userID, errs.ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if role != model.ProjectRoleOwner {
+		return nil
+	}
+	owners, err := s.repo.CountOwners(projectID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return fmt.Errorf("a project needs at least one owner: %w", errs.ErrConflict)
+	}
+	return nil
+}
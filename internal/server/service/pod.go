@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
+	"github.com/deeploy-sh/deeploy/internal/server/buildlog"
 	"github.com/deeploy-sh/deeploy/internal/server/docker"
 	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
@@ -16,16 +19,18 @@ type PodServiceInterface interface {
 	PodsByUser(id string) ([]model.Pod, error)
 	CountByProject(id string) (int, error)
 	Update(pod model.Pod) error
-	Delete(id string) error
+	Delete(id string, removeVolumes bool) error
 }
 
 type PodService struct {
-	repo   repo.PodRepoInterface
-	docker *docker.DockerService
+	repo       repo.PodRepoInterface
+	volumeRepo repo.PodVolumeRepoInterface
+	docker     *docker.DockerService
+	buildLogs  *buildlog.Store
 }
 
-func NewPodService(repo *repo.PodRepo, docker *docker.DockerService) *PodService {
-	return &PodService{repo: repo, docker: docker}
+func NewPodService(repo *repo.PodRepo, volumeRepo *repo.PodVolumeRepo, docker *docker.DockerService, buildLogs *buildlog.Store) *PodService {
+	return &PodService{repo: repo, volumeRepo: volumeRepo, docker: docker, buildLogs: buildLogs}
 }
 
 // enrichWithContainerState fetches the live Docker container state for a pod.
@@ -42,8 +47,32 @@ func (s *PodService) enrichWithContainerState(pod *model.Pod) {
 	pod.ContainerState = state
 }
 
+// validateResources rejects resource limits exceeding the host's resources.
+// If Docker is unreachable the limits are accepted, Docker validates them on deploy.
+func (s *PodService) validateResources(pod model.Pod) error {
+	if pod.MemoryLimit == 0 && pod.MemoryReservation == 0 && pod.CPULimit == 0 {
+		return nil
+	}
+	cpus, memoryMB, err := s.docker.HostResources(context.Background())
+	if err != nil {
+		slog.Warn("skipping resource limit validation", "error", err)
+		return nil
+	}
+	if pod.CPULimit > float64(cpus) {
+		return fmt.Errorf("CPU limit %g exceeds the host's %d CPUs: %w", pod.CPULimit, cpus, errs.ErrInvalidInput)
+	}
+	if pod.MemoryLimit > memoryMB || pod.MemoryReservation > memoryMB {
+		return fmt.Errorf("memory limit exceeds the host's %d MB: %w", memoryMB, errs.ErrInvalidInput)
+	}
+	return nil
+}
+
 func (s *PodService) Create(pod *model.Pod) (*model.Pod, error) {
-	err := s.repo.Create(pod)
+	err := s.validateResources(*pod)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.Create(pod)
 	if err != nil {
 		return nil, err
 	}
@@ -80,20 +109,39 @@ func (s *PodService) CountByProject(id string) (int, error) {
 }
 
 func (s *PodService) Update(pod model.Pod) error {
-	err := s.repo.Update(pod)
+	err := s.validateResources(pod)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(pod)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *PodService) Delete(id string) error {
+// Delete removes a pod with its container, images and build logs.
+// Named volumes are only removed with removeVolumes, host paths are never touched.
+func (s *PodService) Delete(id string, removeVolumes bool) error {
 	pod, err := s.repo.Pod(id)
 	if err != nil {
 		return err
 	}
 
+	// Volume rows are deleted together with the pod, read them first
+	volumes, err := s.volumeRepo.VolumesByPod(id)
+	if err != nil {
+		return err
+	}
+
 	s.cleanupDocker(id, pod.ContainerID)
+	if removeVolumes {
+		for _, name := range ownedVolumes(id, volumes) {
+			s.docker.RemoveVolume(context.Background(), name)
+		}
+	}
+	s.buildLogs.RemovePod(id)
+	s.docker.RemoveSource(id)
 
 	return s.repo.Delete(id)
 }
@@ -106,5 +154,6 @@ func (s *PodService) cleanupDocker(podID string, containerID *string) {
 		s.docker.RemoveContainer(ctx, *containerID)
 	}
 
-	s.docker.RemoveImage(ctx, fmt.Sprintf("deeploy-%s:latest", podID))
+	// Remove images of all deployments (one tag per deployment)
+	s.docker.RemovePodImages(ctx, podID)
 }
@@ -1,7 +1,19 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
@@ -10,27 +22,122 @@ type PodDomainServiceInterface interface {
 	Domain(id string) (*model.PodDomain, error)
 	DomainByName(domain string) (*model.PodDomain, error)
 	DomainsByPod(podID string) ([]model.PodDomain, error)
-	Update(domain model.PodDomain) error
+	Update(domain *model.PodDomain) error
+	Verify(domain *model.PodDomain) error
+	SkipVerification(domain *model.PodDomain) error
+	DNSRecord(domain string) *model.DNSRecord
+	PublicIP() string
 	Delete(id string) error
 	DeleteByPod(podID string) error
 }
 
 type PodDomainService struct {
-	repo repo.PodDomainRepoInterface
+	repo       repo.PodDomainRepoInterface
+	podRepo    repo.PodRepoInterface
+	resolver   *net.Resolver
+	isDev      bool
+	publicIP   string
+	publicIPMu sync.Mutex
+}
+
+// NewPodDomainService creates the domain service. publicIP is the address
+// domains must resolve to, empty = detected via ipify.org on first use.
+func NewPodDomainService(repo *repo.PodDomainRepo, podRepo *repo.PodRepo, publicIP string, isDev bool) *PodDomainService {
+	return &PodDomainService{
+		repo:     repo,
+		podRepo:  podRepo,
+		resolver: net.DefaultResolver,
+		isDev:    isDev,
+		publicIP: publicIP,
+	}
+}
+
+// checkRoute fails with a conflict if another domain routes the same host
+// and path. A host belongs to the project routing it first, other projects
+// can't add it, other paths of it or a wildcard overlapping it.
+func (s *PodDomainService) checkRoute(domain *model.PodDomain) error {
+	existing, err := s.repo.DomainByRoute(domain.Domain, domain.Path)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return err
+	}
+	if err == nil && existing.ID != domain.ID {
+		return fmt.Errorf("%s%s is already in use: %w", domain.Domain, domain.Path, errs.ErrConflict)
+	}
+
+	pod, err := s.podRepo.Pod(domain.PodID)
+	if err != nil {
+		return err
+	}
+	nearby, err := s.repo.DomainsNearHost(domain.Domain)
+	if err != nil {
+		return err
+	}
+	for _, d := range nearby {
+		if d.ID == domain.ID || !hostsOverlap(d.Domain, domain.Domain) {
+			continue
+		}
+		other, err := s.podRepo.Pod(d.PodID)
+		if err != nil {
+			return err
+		}
+		if other.ProjectID != pod.ProjectID {
+			return fmt.Errorf("%s is used by another project: %w", d.Domain, errs.ErrConflict)
+		}
+	}
+	return nil
 }
 
-func NewPodDomainService(repo *repo.PodDomainRepo) *PodDomainService {
-	return &PodDomainService{repo: repo}
+// hostsOverlap reports whether two hosts can match the same request. A
+// wildcard matches one subdomain level, like its certificate.
+func hostsOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+	return wildcardMatches(a, b) || wildcardMatches(b, a)
 }
 
+func wildcardMatches(wildcard, host string) bool {
+	suffix, ok := strings.CutPrefix(wildcard, "*.")
+	if !ok {
+		return false
+	}
+	label, ok := strings.CutSuffix(host, "."+suffix)
+	return ok && label != "" && !strings.Contains(label, ".")
+}
+
+// Create saves a new domain. Domains that need a DNS lookup stay pending
+// until Verify checks them, the lookup can take a while.
 func (s *PodDomainService) Create(domain *model.PodDomain) (*model.PodDomain, error) {
-	err := s.repo.Create(domain)
+	err := s.checkRoute(domain)
+	if err != nil {
+		return nil, err
+	}
+	s.setInitialStatus(domain)
+	err = s.repo.Create(domain)
 	if err != nil {
 		return nil, err
 	}
 	return domain, nil
 }
 
+// setInitialStatus marks a new or moved domain as pending, or verified if
+// it needs no DNS lookup.
+func (s *PodDomainService) setInitialStatus(domain *model.PodDomain) {
+	domain.Status = model.DomainStatusPending
+	domain.VerifiedAt = nil
+	if !s.needsLookup(domain) {
+		now := time.Now()
+		domain.Status = model.DomainStatusVerified
+		domain.VerifiedAt = &now
+	}
+}
+
+// needsLookup reports whether a domain is checked against DNS. Development
+// serves every host locally, generated hosts resolve to the IP they embed.
+func (s *PodDomainService) needsLookup(domain *model.PodDomain) bool {
+	return !s.isDev && domain.Type != model.DomainTypeAuto
+}
+
 func (s *PodDomainService) Domain(id string) (*model.PodDomain, error) {
 	domain, err := s.repo.Domain(id)
 	if err != nil {
@@ -55,12 +162,135 @@ func (s *PodDomainService) DomainsByPod(podID string) ([]model.PodDomain, error)
 	return domains, nil
 }
 
-func (s *PodDomainService) Update(domain model.PodDomain) error {
-	err := s.repo.Update(domain)
+// Update saves a domain. A changed host is pending and no longer routed
+// until Verify checks it again.
+func (s *PodDomainService) Update(domain *model.PodDomain) error {
+	err := s.checkRoute(domain)
 	if err != nil {
 		return err
 	}
-	return nil
+	existing, err := s.repo.Domain(domain.ID)
+	if err != nil {
+		return err
+	}
+	domain.Status = existing.Status
+	domain.VerifiedAt = existing.VerifiedAt
+	if domain.Domain != existing.Domain {
+		s.setInitialStatus(domain)
+	}
+	return s.repo.Update(*domain)
+}
+
+// Verify checks that the domain resolves to the server's public IP and
+// stores the result. The reason of a failed check is in StatusMessage.
+func (s *PodDomainService) Verify(domain *model.PodDomain) error {
+	domain.Status, domain.StatusMessage = s.check(domain)
+	domain.VerifiedAt = nil
+	if domain.Status == model.DomainStatusVerified {
+		now := time.Now()
+		domain.VerifiedAt = &now
+	}
+	return s.repo.UpdateStatus(domain.ID, domain.Status, domain.VerifiedAt)
+}
+
+// SkipVerification marks a domain as verified without checking its DNS,
+// for domains behind a CDN or NAT that never resolve to the public IP.
+// The host must not be routed by another project.
+func (s *PodDomainService) SkipVerification(domain *model.PodDomain) error {
+	err := s.checkRoute(domain)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	domain.Status = model.DomainStatusVerified
+	domain.StatusMessage = ""
+	domain.VerifiedAt = &now
+	return s.repo.UpdateStatus(domain.ID, domain.Status, domain.VerifiedAt)
+}
+
+// check resolves the A, AAAA and CNAME records of a domain. Wildcards are
+// checked with a sample subdomain.
+func (s *PodDomainService) check(domain *model.PodDomain) (string, string) {
+	if !s.needsLookup(domain) {
+		return model.DomainStatusVerified, ""
+	}
+
+	ip := net.ParseIP(s.PublicIP())
+	if ip == nil {
+		return model.DomainStatusFailed, "Public IP of the server is unknown, set PUBLIC_IP"
+	}
+
+	host := domain.Domain
+	if suffix, ok := strings.CutPrefix(host, "*."); ok {
+		host = "deeploy-check." + suffix
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Lookups follow CNAMEs, the target only shows up in the message
+	name := host
+	cname, err := s.resolver.LookupCNAME(ctx, host)
+	if target := strings.TrimSuffix(cname, "."); err == nil && target != host {
+		name = fmt.Sprintf("%s (CNAME %s)", host, target)
+	}
+
+	addrs, err := s.resolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return model.DomainStatusFailed, fmt.Sprintf("%s has no DNS record yet", name)
+	}
+	var found []string
+	for _, addr := range addrs {
+		if addr.IP.Equal(ip) {
+			return model.DomainStatusVerified, ""
+		}
+		found = append(found, addr.IP.String())
+	}
+	return model.DomainStatusFailed, fmt.Sprintf("%s points to %s instead of %s", name, strings.Join(found, ", "), ip)
+}
+
+// DNSRecord returns the record a domain needs, nil while the public IP is unknown.
+func (s *PodDomainService) DNSRecord(domain string) *model.DNSRecord {
+	ip := net.ParseIP(s.PublicIP())
+	if ip == nil {
+		return nil
+	}
+	recordType := "A"
+	if ip.To4() == nil {
+		recordType = "AAAA"
+	}
+	return &model.DNSRecord{Type: recordType, Name: domain, Value: ip.String()}
+}
+
+// PublicIP returns the configured public IP or fetches it via ipify.org,
+// empty if that fails. A detected IP is cached, failures are retried.
+func (s *PodDomainService) PublicIP() string {
+	if s.isDev {
+		return "127.0.0.1"
+	}
+	s.publicIPMu.Lock()
+	defer s.publicIPMu.Unlock()
+	if s.publicIP != "" {
+		return s.publicIP
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("https://api.ipify.org")
+	if err != nil {
+		slog.Warn("failed to get public IP", "error", err)
+		return ""
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		slog.Warn("failed to read public IP response", "error", err)
+		return ""
+	}
+
+	s.publicIP = strings.TrimSpace(string(body))
+	slog.Info("detected public IP", "ip", s.publicIP)
+	return s.publicIP
 }
 
 func (s *PodDomainService) Delete(id string) error {
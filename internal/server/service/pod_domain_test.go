@@ -0,0 +1,26 @@
+package service
+
+import "testing"
+
+func TestHostsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"example.com", "example.com", true},
+		{"example.com", "www.example.com", false},
+		{"*.example.com", "www.example.com", true},
+		{"www.example.com", "*.example.com", true},
+		{"*.example.com", "*.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "a.b.example.com", false},
+		{"*.example.com", "*.b.example.com", false},
+		{"*.example.com", "www.example.org", false},
+		{"*.example.com", "wwwexample.com", false},
+	}
+	for _, tt := range tests {
+		if got := hostsOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("hostsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
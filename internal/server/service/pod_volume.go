@@ -0,0 +1,81 @@
+package service
+
+import (
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+type PodVolumeServiceInterface interface {
+	Create(volume *model.PodVolume) (*model.PodVolume, error)
+	Volume(id string) (*model.PodVolume, error)
+	VolumesByPod(podID string) ([]model.PodVolume, error)
+	Update(volume *model.PodVolume) error
+	Delete(id string) error
+}
+
+type PodVolumeService struct {
+	repo repo.PodVolumeRepoInterface
+}
+
+func NewPodVolumeService(repo *repo.PodVolumeRepo) *PodVolumeService {
+	return &PodVolumeService{repo: repo}
+}
+
+// podVolumeName is the Docker volume holding a named volume of a pod. The
+// pod ID keeps pods from reaching volumes of other pods, databases or deeploy.
+func podVolumeName(podID, name string) string {
+	return "deeploy-" + podID + "-" + name
+}
+
+// ownedVolumes returns the Docker volumes that belong to a pod. Volumes
+// created before names were namespaced may be shared and are left out.
+func ownedVolumes(podID string, volumes []model.PodVolume) []string {
+	var names []string
+	for _, v := range volumes {
+		if v.Type == model.VolumeTypeVolume && v.VolumeName == podVolumeName(podID, v.Source) {
+			names = append(names, v.VolumeName)
+		}
+	}
+	return names
+}
+
+func (s *PodVolumeService) Create(volume *model.PodVolume) (*model.PodVolume, error) {
+	volume.VolumeName = ""
+	if volume.Type == model.VolumeTypeVolume {
+		volume.VolumeName = podVolumeName(volume.PodID, volume.Source)
+	}
+	err := s.repo.Create(volume)
+	if err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+func (s *PodVolumeService) Volume(id string) (*model.PodVolume, error) {
+	return s.repo.Volume(id)
+}
+
+func (s *PodVolumeService) VolumesByPod(podID string) ([]model.PodVolume, error) {
+	return s.repo.VolumesByPod(podID)
+}
+
+// Update saves a volume. A renamed volume is a new, empty Docker volume,
+// volumes keeping their name keep their data.
+func (s *PodVolumeService) Update(volume *model.PodVolume) error {
+	existing, err := s.repo.Volume(volume.ID)
+	if err != nil {
+		return err
+	}
+	volume.VolumeName = ""
+	if volume.Type == model.VolumeTypeVolume {
+		volume.VolumeName = podVolumeName(volume.PodID, volume.Source)
+		if existing.Type == volume.Type && existing.Source == volume.Source {
+			volume.VolumeName = existing.VolumeName
+		}
+	}
+	return s.repo.Update(*volume)
+}
+
+func (s *PodVolumeService) Delete(id string) error {
+	return s.repo.Delete(id)
+}
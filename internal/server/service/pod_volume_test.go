@@ -0,0 +1,21 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+func TestOwnedVolumes(t *testing.T) {
+	volumes := []model.PodVolume{
+		{Type: model.VolumeTypeVolume, Source: "uploads", VolumeName: "deeploy-pod-uploads"},
+		{Type: model.VolumeTypeVolume, Source: "cache", VolumeName: "cache"},
+		{Type: model.VolumeTypeVolume, Source: "data", VolumeName: "deeploy-other-data"},
+		{Type: model.VolumeTypeBind, Source: "/srv/app"},
+	}
+	got := ownedVolumes("pod", volumes)
+	if !slices.Equal(got, []string{"deeploy-pod-uploads"}) {
+		t.Errorf("ownedVolumes = %v, want only the volume namespaced by the pod", got)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/deeploy-sh/deeploy/internal/server/crypto"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+type PodWebhookServiceInterface interface {
+	Webhook(podID string) (*model.PodWebhook, error)
+	RegenerateSecret(podID string) (*model.PodWebhook, error)
+}
+
+type PodWebhookService struct {
+	repo      repo.PodWebhookRepoInterface
+	encryptor *crypto.Encryptor
+}
+
+func NewPodWebhookService(repo *repo.PodWebhookRepo, encryptor *crypto.Encryptor) *PodWebhookService {
+	return &PodWebhookService{repo: repo, encryptor: encryptor}
+}
+
+// generateWebhookSecret returns a random 32 byte hex secret.
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *PodWebhookService) encrypt(secret string) (string, error) {
+	if s.encryptor == nil {
+		return secret, nil
+	}
+	return s.encryptor.Encrypt(secret)
+}
+
+// Webhook returns the webhook of a pod with its decrypted secret.
+// Returns ErrNotFound if the pod has no webhook yet.
+func (s *PodWebhookService) Webhook(podID string) (*model.PodWebhook, error) {
+	webhook, err := s.repo.WebhookByPod(podID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.encryptor != nil {
+		decrypted, err := s.encryptor.Decrypt(webhook.Secret)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = decrypted
+	}
+
+	return webhook, nil
+}
+
+// RegenerateSecret sets a new webhook secret for a pod, enabling its webhook
+// on first use. Providers configured with an old secret are rejected afterwards.
+func (s *PodWebhookService) RegenerateSecret(podID string) (*model.PodWebhook, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.WebhookByPod(podID)
+	if errors.Is(err, errs.ErrNotFound) {
+		err = s.repo.Create(&model.PodWebhook{
+			ID:     uuid.New().String(),
+			PodID:  podID,
+			Secret: encrypted,
+		})
+	} else if err == nil {
+		err = s.repo.UpdateSecret(podID, encrypted)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Webhook(podID)
+}
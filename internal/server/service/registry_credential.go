@@ -0,0 +1,70 @@
+package service
+
+import (
+	"github.com/deeploy-sh/deeploy/internal/server/crypto"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+)
+
+type RegistryCredentialServiceInterface interface {
+	Create(credential *model.RegistryCredential) (*model.RegistryCredential, error)
+	RegistryCredential(id string) (*model.RegistryCredential, error)
+	RegistryCredentialsByUser(userID string) ([]model.RegistryCredential, error)
+	Delete(id string) error
+}
+
+type RegistryCredentialService struct {
+	repo      repo.RegistryCredentialRepoInterface
+	encryptor *crypto.Encryptor
+}
+
+func NewRegistryCredentialService(repo *repo.RegistryCredentialRepo, encryptor *crypto.Encryptor) *RegistryCredentialService {
+	return &RegistryCredentialService{repo: repo, encryptor: encryptor}
+}
+
+func (s *RegistryCredentialService) Create(credential *model.RegistryCredential) (*model.RegistryCredential, error) {
+	if s.encryptor != nil {
+		encrypted, err := s.encryptor.Encrypt(credential.Password)
+		if err != nil {
+			return nil, err
+		}
+		credential.Password = encrypted
+	}
+
+	err := s.repo.Create(credential)
+	if err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+func (s *RegistryCredentialService) RegistryCredential(id string) (*model.RegistryCredential, error) {
+	credential, err := s.repo.RegistryCredential(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.encryptor != nil {
+		decrypted, err := s.encryptor.Decrypt(credential.Password)
+		if err != nil {
+			return nil, err
+		}
+		credential.Password = decrypted
+	}
+
+	return credential, nil
+}
+
+// RegistryCredentialsByUser lists the credentials of a user.
+// Passwords are never sent to clients, so they are not decrypted here.
+func (s *RegistryCredentialService) RegistryCredentialsByUser(userID string) ([]model.RegistryCredential, error) {
+	return s.repo.RegistryCredentialsByUser(userID)
+}
+
+func (s *RegistryCredentialService) Delete(id string) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
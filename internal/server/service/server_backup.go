@@ -0,0 +1,447 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/crypto"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/shared/version"
+)
+
+// serverBackupTables are the tables of a server backup, in an order that
+// satisfies their foreign keys. Deployments are left out, their images
+// only exist on the server that built them.
+var serverBackupTables = []string{
+	"users",
+	"recovery_codes",
+	"projects",
+	"project_members",
+	"project_invites",
+	"api_tokens",
+	"git_tokens",
+	"registry_credentials",
+	"pods",
+	"pod_env_vars",
+	"pod_domains",
+	"certificates",
+	"pod_volumes",
+	"pod_webhooks",
+	"databases",
+	"pod_databases",
+	"backup_schedules",
+	"backups",
+	"server_settings",
+	"dns_settings",
+}
+
+// encryptedColumns hold secrets encrypted with the ENCRYPTION_KEY.
+var encryptedColumns = map[string][]string{
+	"users":                {"totp_secret"},
+	"pod_env_vars":         {"value"},
+	"git_tokens":           {"token"},
+	"registry_credentials": {"password"},
+	"pod_webhooks":         {"secret"},
+	"databases":            {"password"},
+	"certificates":         {"private_key"},
+	"dns_settings":         {"credentials"},
+}
+
+// serverBackupKeyCheck is encrypted into the manifest to recognize the key of an archive.
+const serverBackupKeyCheck = "deeploy"
+
+// Archive layout
+const (
+	serverBackupManifestFile = "manifest.json"
+	serverBackupTablesDir    = "tables/"
+	serverBackupTraefikDir   = "traefik/"
+)
+
+type ServerBackupServiceInterface interface {
+	Backup(w io.Writer) (*model.ServerBackupManifest, error)
+	Restore(r io.Reader, sourceKey string) (*model.ServerBackupManifest, error)
+}
+
+// ServerBackupService exports the configuration of the server (database
+// rows and Traefik files) into a single archive and imports it again.
+// Containers, images and volume data are not part of it.
+type ServerBackupService struct {
+	repo             repo.ServerBackupRepoInterface
+	encryptor        *crypto.Encryptor
+	driver           string
+	traefikConfigDir string
+}
+
+func NewServerBackupService(repo *repo.ServerBackupRepo, encryptor *crypto.Encryptor, driver, traefikConfigDir string) *ServerBackupService {
+	return &ServerBackupService{
+		repo:             repo,
+		encryptor:        encryptor,
+		driver:           driver,
+		traefikConfigDir: traefikConfigDir,
+	}
+}
+
+// Backup writes a gzipped tar archive of the server configuration to w:
+//
+//	manifest.json
+//	tables/<table>.json
+//	traefik/<file>
+func (s *ServerBackupService) Backup(w io.Writer) (*model.ServerBackupManifest, error) {
+	schemaVersion, err := s.repo.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &model.ServerBackupManifest{
+		FormatVersion:  model.ServerBackupFormatVersion,
+		DeeployVersion: version.Version,
+		SchemaVersion:  schemaVersion,
+		Driver:         s.driver,
+		CreatedAt:      time.Now().UTC(),
+		Tables:         map[string]int{},
+	}
+	if s.encryptor != nil {
+		manifest.KeyCheck, err = s.encryptor.Encrypt(serverBackupKeyCheck)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, table := range serverBackupTables {
+		rows, err := s.repo.Rows(table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		for _, row := range rows {
+			for column, value := range row {
+				row[column] = exportValue(value)
+			}
+		}
+		data, err := json.Marshal(rows)
+		if err != nil {
+			return nil, err
+		}
+		files[serverBackupTablesDir+table+".json"] = data
+		manifest.Tables[table] = len(rows)
+	}
+
+	entries, err := os.ReadDir(s.traefikConfigDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read traefik config: %w", err)
+	}
+	for _, e := range entries {
+		// Certificates are restored from their table, with encrypted keys
+		if !e.Type().IsRegular() || e.Name() == CertificatesFile {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.traefikConfigDir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read traefik config: %w", err)
+		}
+		files[serverBackupTraefikDir+e.Name()] = data
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	// Manifest first, so it can be inspected with "tar -xzOf backup.tar.gz manifest.json"
+	err = writeTarFile(tw, serverBackupManifestFile, data)
+	if err != nil {
+		return nil, err
+	}
+	for name, data := range files {
+		err = writeTarFile(tw, name, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = tw.Close()
+	if err != nil {
+		return nil, err
+	}
+	err = gz.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// exportValue converts driver values to portable JSON values.
+// Timestamps use the format of CURRENT_TIMESTAMP, which every driver parses.
+func exportValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.DateTime)
+	case []byte:
+		return string(v)
+	}
+	return value
+}
+
+// importValue converts JSON numbers back to integers where possible.
+func importValue(value any) any {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	i, err := n.Int64()
+	if err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// Restore replaces the server configuration with an archive written by Backup.
+// Secrets of archives from a server with a different ENCRYPTION_KEY are
+// re-encrypted, which requires that key as sourceKey.
+//
+// Pods are restored stopped and need a deploy. Databases are restored
+// provisioning, see DatabaseService.ProvisionPending.
+func (s *ServerBackupService) Restore(r io.Reader, sourceKey string) (*model.ServerBackupManifest, error) {
+	files, err := readServerBackup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := files[serverBackupManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("not a deeploy server backup, %s is missing: %w", serverBackupManifestFile, errs.ErrInvalidInput)
+	}
+	var manifest model.ServerBackupManifest
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", errs.ErrInvalidInput)
+	}
+
+	err = s.checkCompatible(&manifest)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.sourceEncryptor(&manifest, sourceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := map[string][]map[string]any{}
+	for _, table := range serverBackupTables {
+		data, ok := files[serverBackupTablesDir+table+".json"]
+		if !ok {
+			// Table did not exist in the exporting version
+			continue
+		}
+		var tableRows []map[string]any
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err := dec.Decode(&tableRows)
+		if err != nil {
+			return nil, fmt.Errorf("invalid table %s: %w", table, errs.ErrInvalidInput)
+		}
+
+		for _, row := range tableRows {
+			for column, value := range row {
+				row[column] = importValue(value)
+			}
+			err := s.reencrypt(table, row, source)
+			if err != nil {
+				return nil, err
+			}
+			resetRuntimeState(table, row)
+		}
+		rows[table] = tableRows
+	}
+
+	err = s.repo.Replace(serverBackupTables, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.restoreTraefikConfig(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// readServerBackup reads all files of an archive. Server backups hold
+// configuration only and fit into memory.
+func readServerBackup(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a gzip archive: %w", errs.ErrInvalidInput)
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", errs.ErrInvalidInput)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[header.Name] = data
+	}
+	return files, nil
+}
+
+// checkCompatible rejects archives this server can't import.
+func (s *ServerBackupService) checkCompatible(manifest *model.ServerBackupManifest) error {
+	if manifest.FormatVersion > model.ServerBackupFormatVersion {
+		return fmt.Errorf("backup format %d is newer than supported (%d), update deeploy first: %w",
+			manifest.FormatVersion, model.ServerBackupFormatVersion, errs.ErrInvalidInput)
+	}
+
+	schemaVersion, err := s.repo.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if manifest.SchemaVersion > schemaVersion {
+		return fmt.Errorf("backup is from a newer deeploy (%s), update deeploy first: %w", manifest.DeeployVersion, errs.ErrInvalidInput)
+	}
+
+	// Column types differ, e.g. booleans are integers in SQLite
+	if manifest.Driver != s.driver {
+		return fmt.Errorf("backup was created with DB_DRIVER=%s, this server uses %s: %w", manifest.Driver, s.driver, errs.ErrInvalidInput)
+	}
+	return nil
+}
+
+// sourceEncryptor returns the encryptor of the archive's secrets,
+// nil if they can be restored as they are.
+func (s *ServerBackupService) sourceEncryptor(manifest *model.ServerBackupManifest, sourceKey string) (*crypto.Encryptor, error) {
+	if manifest.KeyCheck == "" {
+		// Exported without encryption, secrets are plain text
+		if s.encryptor == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("backup has unencrypted secrets, restore it on a server without ENCRYPTION_KEY: %w", errs.ErrInvalidInput)
+	}
+	if keyMatches(s.encryptor, manifest.KeyCheck) {
+		return nil, nil
+	}
+
+	if sourceKey == "" {
+		return nil, fmt.Errorf("backup was created with a different ENCRYPTION_KEY, provide the old key to re-encrypt its secrets: %w", errs.ErrInvalidInput)
+	}
+	source, err := crypto.NewEncryptor(sourceKey)
+	if err != nil {
+		return nil, fmt.Errorf("old key: %w: %w", err, errs.ErrInvalidInput)
+	}
+	if !keyMatches(source, manifest.KeyCheck) {
+		return nil, fmt.Errorf("old key does not match the backup: %w", errs.ErrInvalidInput)
+	}
+	return source, nil
+}
+
+func keyMatches(encryptor *crypto.Encryptor, keyCheck string) bool {
+	if encryptor == nil {
+		return false
+	}
+	value, err := encryptor.Decrypt(keyCheck)
+	return err == nil && value == serverBackupKeyCheck
+}
+
+// reencrypt re-encrypts the secrets of a row from the source key to the server's key.
+func (s *ServerBackupService) reencrypt(table string, row map[string]any, source *crypto.Encryptor) error {
+	if source == nil {
+		return nil
+	}
+	for _, column := range encryptedColumns[table] {
+		value, ok := row[column].(string)
+		if !ok || value == "" {
+			continue
+		}
+		plain, err := source.Decrypt(value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s.%s: %w", table, column, err)
+		}
+		if s.encryptor == nil {
+			row[column] = plain
+			continue
+		}
+		row[column], err = s.encryptor.Encrypt(plain)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetRuntimeState drops references to containers of the exporting server.
+func resetRuntimeState(table string, row map[string]any) {
+	switch table {
+	case "pods":
+		row["container_id"] = nil
+		row["status"] = "stopped"
+	case "databases":
+		row["container_id"] = nil
+		row["status"] = model.DatabaseStatusProvisioning
+	}
+}
+
+// restoreTraefikConfig writes the Traefik files of an archive.
+func (s *ServerBackupService) restoreTraefikConfig(files map[string][]byte) error {
+	for name, data := range files {
+		if !strings.HasPrefix(name, serverBackupTraefikDir) {
+			continue
+		}
+		// Only plain file names, never paths out of the config directory
+		base := path.Base(name)
+		if base != strings.TrimPrefix(name, serverBackupTraefikDir) || base == "." || base == ".." {
+			continue
+		}
+
+		err := os.MkdirAll(s.traefikConfigDir, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to restore traefik config: %w", err)
+		}
+		err = os.WriteFile(filepath.Join(s.traefikConfigDir, base), data, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to restore traefik config: %w", err)
+		}
+	}
+	return nil
+}
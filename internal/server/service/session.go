@@ -0,0 +1,168 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/jwt"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+)
+
+// sessionTTL is how long a session lasts without being refreshed.
+const sessionTTL = 30 * 24 * time.Hour
+
+type SessionServiceInterface interface {
+	Create(userID, client, ip string) (*model.AuthTokens, error)
+	Refresh(refreshToken, ip string) (*model.AuthTokens, error)
+	Authenticate(sessionID, userID string) (*model.Session, error)
+	Revoke(refreshToken string) error
+	Session(id string) (*model.Session, error)
+	SessionsByUser(userID string) ([]model.Session, error)
+	Delete(id string) error
+}
+
+type SessionService struct {
+	repo repo.SessionRepoInterface
+}
+
+func NewSessionService(repo *repo.SessionRepo) *SessionService {
+	return &SessionService{repo: repo}
+}
+
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Create signs a user in and returns the tokens of the new session.
+func (s *SessionService) Create(userID, client, ip string) (*model.AuthTokens, error) {
+	now := time.Now().UTC()
+	err := s.repo.DeleteExpired(now)
+	if err != nil {
+		slog.Warn("failed to delete expired sessions", "error", err)
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	session := &model.Session{
+		ID:               uuid.New().String(),
+		UserID:           userID,
+		RefreshTokenHash: hashToken(refreshToken),
+		Client:           client,
+		IP:               ip,
+		ExpiresAt:        now.Add(sessionTTL),
+		LastSeenAt:       now,
+	}
+	err = s.repo.Create(session)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.CreateToken(userID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AuthTokens{Token: token, RefreshToken: refreshToken}, nil
+}
+
+// Refresh replaces a refresh token with a new one and a new access token.
+// A refresh token that was already replaced means it was copied, the
+// session is revoked so neither copy works anymore.
+func (s *SessionService) Refresh(refreshToken, ip string) (*model.AuthTokens, error) {
+	tokenHash := hashToken(refreshToken)
+	session, err := s.repo.SessionByRefreshHash(tokenHash)
+	if errors.Is(err, errs.ErrNotFound) {
+		reused, prevErr := s.repo.SessionByPreviousHash(tokenHash)
+		if prevErr == nil {
+			slog.Warn("refresh token reused, revoking session", "sessionID", reused.ID, "userID", reused.UserID)
+			err = s.repo.Delete(reused.ID)
+			if err != nil {
+				slog.Error("failed to revoke session", "sessionID", reused.ID, "error", err)
+			}
+		}
+		return nil, fmt.Errorf("refresh token: %w", errs.ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if now.After(session.ExpiresAt) {
+		return nil, fmt.Errorf("session expired: %w", errs.ErrUnauthorized)
+	}
+
+	newToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.Rotate(session.ID, tokenHash, hashToken(newToken), ip, now.Add(sessionTTL), now)
+	if errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("refresh token: %w", errs.ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.CreateToken(session.UserID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AuthTokens{Token: token, RefreshToken: newToken}, nil
+}
+
+// Authenticate returns the session of an access token.
+// Revoked and expired sessions return ErrUnauthorized.
+func (s *SessionService) Authenticate(sessionID, userID string) (*model.Session, error) {
+	session, err := s.repo.Session(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errs.ErrUnauthorized, err)
+	}
+	now := time.Now().UTC()
+	if session.UserID != userID || now.After(session.ExpiresAt) {
+		return nil, fmt.Errorf("session %s: %w", sessionID, errs.ErrUnauthorized)
+	}
+
+	if now.Sub(session.LastSeenAt) > lastUsedInterval {
+		err = s.repo.MarkSeen(session.ID, now)
+		if err != nil {
+			slog.Warn("failed to mark session as seen", "sessionID", session.ID, "error", err)
+		}
+		session.LastSeenAt = now
+	}
+
+	return session, nil
+}
+
+// Revoke ends the session of a refresh token, for signing out.
+func (s *SessionService) Revoke(refreshToken string) error {
+	session, err := s.repo.SessionByRefreshHash(hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(session.ID)
+}
+
+func (s *SessionService) Session(id string) (*model.Session, error) {
+	return s.repo.Session(id)
+}
+
+func (s *SessionService) SessionsByUser(userID string) ([]model.Session, error) {
+	return s.repo.SessionsByUser(userID)
+}
+
+func (s *SessionService) Delete(id string) error {
+	return s.repo.Delete(id)
+}
@@ -1,24 +1,48 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/deeploy-sh/deeploy/internal/server/crypto"
+	"github.com/deeploy-sh/deeploy/internal/server/docker"
 	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 )
 
 const settingKeyServerDomain = "server_domain"
 
+// CertificatesFile is the Traefik TLS store of uploaded certificates. It holds
+// private keys in plain text, so it is left out of server backups.
+const CertificatesFile = "certificates.yml"
+
+// dnsCredentialsDir holds one file per DNS credential, Traefik reads them
+// through the <KEY>_FILE variables in docker-compose.yml. The file provider
+// ignores them, they aren't YAML.
+const dnsCredentialsDir = "dns"
+
 type TraefikService struct {
 	settingsRepo *repo.ServerSettingsRepo
+	dnsRepo      repo.DNSSettingsRepoInterface
+	encryptor    *crypto.Encryptor
+	docker       *docker.DockerService
 	configDir    string
 	isDev        bool
 }
 
-func NewTraefikService(settingsRepo *repo.ServerSettingsRepo, configDir string, isDev bool) *TraefikService {
+func NewTraefikService(settingsRepo *repo.ServerSettingsRepo, dnsRepo *repo.DNSSettingsRepo, encryptor *crypto.Encryptor, docker *docker.DockerService, configDir string, isDev bool) *TraefikService {
 	return &TraefikService{
 		settingsRepo: settingsRepo,
+		dnsRepo:      dnsRepo,
+		encryptor:    encryptor,
+		docker:       docker,
 		configDir:    configDir,
 		isDev:        isDev,
 	}
@@ -63,11 +87,16 @@ func (s *TraefikService) writeServerConfig(domain string) error {
 		return err
 	}
 
+	certResolver, err := s.CertResolver()
+	if err != nil {
+		return err
+	}
+
 	// Determine entrypoint based on environment
 	entrypoint := "websecure"
 	tlsConfig := `
       tls:
-        certResolver: letsencrypt`
+        certResolver: ` + certResolver
 	if s.isDev {
 		entrypoint = "web"
 		tlsConfig = "" // No TLS in development
@@ -98,3 +127,225 @@ http:
 	configPath := filepath.Join(s.configDir, "server.yml")
 	return os.WriteFile(configPath, []byte(config), 0644)
 }
+
+// WriteCertificates writes the TLS store with the given certificates, the
+// PEM content inlined. Without certificates the file is removed.
+func (s *TraefikService) WriteCertificates(certificates []model.Certificate) error {
+	configPath := filepath.Join(s.configDir, CertificatesFile)
+	if len(certificates) == 0 {
+		if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(s.configDir, 0755); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString(`# Deeploy Custom Certificates
+# Auto-generated - do not edit manually
+# Traefik serves these instead of Let's Encrypt for matching domains
+
+tls:
+  certificates:
+`)
+	for _, c := range certificates {
+		b.WriteString("    - certFile: |\n" + indentPEM(c.Certificate))
+		b.WriteString("      keyFile: |\n" + indentPEM(c.PrivateKey))
+	}
+
+	return os.WriteFile(configPath, []byte(b.String()), 0600)
+}
+
+// indentPEM indents PEM content as a YAML block scalar of a certificate entry.
+func indentPEM(pem string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(pem), "\n") {
+		b.WriteString("        " + strings.TrimSpace(line) + "\n")
+	}
+	return b.String()
+}
+
+// CertResolver returns the Traefik certificate resolver of Let's Encrypt
+// certificates, the DNS challenge once a DNS provider is configured.
+func (s *TraefikService) CertResolver() (string, error) {
+	settings, err := s.dnsRepo.Get()
+	if errors.Is(err, errs.ErrNotFound) {
+		return docker.HTTPCertResolver, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return docker.DNSCertResolver(settings.Provider), nil
+}
+
+// DNSSettings returns the DNS challenge settings without secret credentials.
+func (s *TraefikService) DNSSettings() (*model.DNSSettings, error) {
+	settings, err := s.dnsSettings()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range model.DNSProviderCredentials[settings.Provider] {
+		if c.Secret {
+			delete(settings.Credentials, c.Key)
+		}
+	}
+	return settings, nil
+}
+
+// dnsSettings returns the DNS challenge settings with decrypted credentials.
+func (s *TraefikService) dnsSettings() (*model.DNSSettings, error) {
+	settings, err := s.dnsRepo.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	data := settings.CredentialsData
+	if s.encryptor != nil {
+		data, err = s.encryptor.Decrypt(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = json.Unmarshal([]byte(data), &settings.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetDNSSettings validates and saves the DNS provider, then switches
+// Let's Encrypt to the DNS challenge. Empty secrets keep the saved ones
+// if the provider stays the same.
+func (s *TraefikService) SetDNSSettings(settings model.DNSSettings) (*model.DNSSettings, error) {
+	credentials, ok := model.DNSProviderCredentials[settings.Provider]
+	if !ok {
+		return nil, fmt.Errorf("provider must be one of %s: %w", strings.Join(model.DNSProviders, ", "), errs.ErrInvalidInput)
+	}
+
+	existing, err := s.dnsSettings()
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, c := range credentials {
+		value := strings.TrimSpace(settings.Credentials[c.Key])
+		if value == "" && c.Secret && existing != nil && existing.Provider == settings.Provider {
+			value = existing.Credentials[c.Key]
+		}
+		if value == "" && !c.Optional {
+			return nil, fmt.Errorf("%s is required: %w", c.Label, errs.ErrInvalidInput)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("%s must be a single line: %w", c.Label, errs.ErrInvalidInput)
+		}
+		if value != "" {
+			values[c.Key] = value
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	settings.Credentials = values
+	settings.CredentialsData = string(data)
+	if s.encryptor != nil {
+		settings.CredentialsData, err = s.encryptor.Encrypt(settings.CredentialsData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.dnsRepo.Save(&settings)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.ApplyDNSSettings()
+	if err != nil {
+		return nil, err
+	}
+	return s.DNSSettings()
+}
+
+// DeleteDNSSettings removes the DNS provider, Let's Encrypt goes back to
+// the HTTP challenge.
+func (s *TraefikService) DeleteDNSSettings() error {
+	err := s.dnsRepo.Delete()
+	if err != nil {
+		return err
+	}
+	return s.ApplyDNSSettings()
+}
+
+// ApplyDNSSettings writes the credentials, moves the server domain to the
+// new resolver and restarts Traefik, it only reads credentials on startup.
+// Pods pick up the resolver on their next deploy.
+func (s *TraefikService) ApplyDNSSettings() error {
+	err := s.WriteDNSCredentials()
+	if err != nil {
+		return err
+	}
+
+	domain, err := s.GetServerDomain()
+	if err != nil {
+		return err
+	}
+	if domain != "" {
+		err = s.writeServerConfig(domain)
+		if err != nil {
+			return fmt.Errorf("failed to write traefik config: %w", err)
+		}
+	}
+
+	if !s.isDev {
+		err = s.docker.RestartContainer(context.Background(), docker.TraefikContainerName)
+		if err != nil {
+			slog.Warn("failed to restart traefik, restart it to use the new dns settings", "error", err)
+		}
+	}
+	return nil
+}
+
+// WriteDNSCredentials writes the credentials of the DNS provider into one
+// file each, e.g. on startup or after a restore. Files of other providers
+// are removed.
+func (s *TraefikService) WriteDNSCredentials() error {
+	dir := filepath.Join(s.configDir, dnsCredentialsDir)
+
+	settings, err := s.dnsSettings()
+	if errors.Is(err, errs.ErrNotFound) {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove dns credentials: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to write dns credentials: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to write dns credentials: %w", err)
+	}
+	for _, e := range entries {
+		if _, ok := settings.Credentials[e.Name()]; !ok {
+			os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+
+	for key, value := range settings.Credentials {
+		err := os.WriteFile(filepath.Join(dir, key), []byte(value), 0600)
+		if err != nil {
+			return fmt.Errorf("failed to write dns credentials: %w", err)
+		}
+	}
+	return nil
+}
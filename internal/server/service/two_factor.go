@@ -0,0 +1,246 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/deeploy-sh/deeploy/internal/server/crypto"
+	"github.com/deeploy-sh/deeploy/internal/server/repo"
+	"github.com/deeploy-sh/deeploy/internal/shared/errs"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+)
+
+// recoveryCodeCount is how many recovery codes a user gets when enabling two-factor.
+const recoveryCodeCount = 10
+
+// totpPeriod is the length of a TOTP time step in seconds, codes of the
+// previous and next step are accepted too for clock drift.
+const totpPeriod = 30
+
+type TwoFactorServiceInterface interface {
+	Setup(userID string) (*model.TwoFactorSetup, error)
+	Enable(userID, code string) ([]string, error)
+	Disable(userID, code string) error
+	Verify(userID, code string) error
+}
+
+// TwoFactorService enrolls users in TOTP and checks the second factor at login.
+type TwoFactorService struct {
+	userRepo         repo.UserRepoInterface
+	recoveryCodeRepo repo.RecoveryCodeRepoInterface
+	encryptor        *crypto.Encryptor
+}
+
+func NewTwoFactorService(userRepo *repo.UserRepo, recoveryCodeRepo *repo.RecoveryCodeRepo, encryptor *crypto.Encryptor) *TwoFactorService {
+	return &TwoFactorService{userRepo: userRepo, recoveryCodeRepo: recoveryCodeRepo, encryptor: encryptor}
+}
+
+func (s *TwoFactorService) user(userID string) (*model.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %s: %w", userID, errs.ErrNotFound)
+	}
+	return user, nil
+}
+
+func (s *TwoFactorService) secret(user *model.User) (string, error) {
+	if s.encryptor == nil {
+		return user.TOTPSecret, nil
+	}
+	return s.encryptor.Decrypt(user.TOTPSecret)
+}
+
+// Setup returns the secret to enroll for a user that has not enabled
+// two-factor yet, a new one on the first call. It only takes effect once
+// Enable confirms a code of it.
+func (s *TwoFactorService) Setup(userID string) (*model.TwoFactorSetup, error) {
+	user, err := s.user(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, fmt.Errorf("two-factor authentication is already enabled: %w", errs.ErrConflict)
+	}
+
+	opts := totp.GenerateOpts{Issuer: "deeploy", AccountName: user.Email}
+	if user.TOTPSecret != "" {
+		// Keep the pending secret, the user may have scanned it already
+		secret, err := s.secret(user)
+		if err != nil {
+			return nil, err
+		}
+		opts.Secret, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	key, err := totp.Generate(opts)
+	if err != nil {
+		return nil, err
+	}
+	img, err := key.Image(200, 200)
+	if err != nil {
+		return nil, err
+	}
+	var qr bytes.Buffer
+	err = png.Encode(&qr, img)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := key.Secret()
+	if s.encryptor != nil {
+		secret, err = s.encryptor.Encrypt(secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.userRepo.UpdateTOTP(userID, secret, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorSetup{
+		Secret: key.Secret(),
+		URL:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(qr.Bytes()),
+	}, nil
+}
+
+// Enable turns two-factor on after the user typed a code of the new secret.
+// It returns the recovery codes, they are not shown again.
+func (s *TwoFactorService) Enable(userID, code string) ([]string, error) {
+	user, err := s.user(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, fmt.Errorf("two-factor authentication is already enabled: %w", errs.ErrConflict)
+	}
+	if user.TOTPSecret == "" {
+		return nil, fmt.Errorf("two-factor setup was not started: %w", errs.ErrInvalidInput)
+	}
+	secret, err := s.secret(user)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totpStep(strings.TrimSpace(code), secret, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("invalid code: %w", errs.ErrInvalidInput)
+	}
+	// The code confirming the secret can't sign in afterwards
+	err = s.userRepo.UseTOTPStep(userID, step)
+	if errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("code was used already: %w", errs.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	stored := make([]model.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(b)
+		codes[i] = code[:5] + "-" + code[5:]
+		stored[i] = model.RecoveryCode{ID: uuid.New().String(), CodeHash: hashToken(code)}
+	}
+	err = s.recoveryCodeRepo.Replace(userID, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userRepo.UpdateTOTP(userID, user.TOTPSecret, true)
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable turns two-factor off, it takes a code like signing in does.
+func (s *TwoFactorService) Disable(userID, code string) error {
+	err := s.Verify(userID, code)
+	if err != nil {
+		return err
+	}
+
+	err = s.recoveryCodeRepo.DeleteByUser(userID)
+	if err != nil {
+		return err
+	}
+	return s.userRepo.UpdateTOTP(userID, "", false)
+}
+
+// Verify checks the second factor of a user, a code of the authenticator app
+// or an unused recovery code. Wrong codes return ErrInvalidCredentials.
+func (s *TwoFactorService) Verify(userID, code string) error {
+	user, err := s.user(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return fmt.Errorf("two-factor authentication is not enabled: %w", errs.ErrInvalidInput)
+	}
+
+	code = strings.ToLower(strings.Join(strings.Fields(code), ""))
+	if len(code) == 6 {
+		secret, err := s.secret(user)
+		if err != nil {
+			return err
+		}
+		step, ok := totpStep(code, secret, time.Now())
+		if !ok {
+			return errs.ErrInvalidCredentials
+		}
+		// A code works once, also within its time window
+		err = s.userRepo.UseTOTPStep(userID, step)
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrInvalidCredentials
+		}
+		return err
+	}
+
+	// Recovery codes are shown with a dash, it is optional when typing them
+	err = s.recoveryCodeRepo.Use(userID, hashToken(strings.ReplaceAll(code, "-", "")), time.Now().UTC())
+	if errors.Is(err, errs.ErrNotFound) {
+		return errs.ErrInvalidCredentials
+	}
+	return err
+}
+
+// totpStep returns the time step a code belongs to, checking the steps
+// around now like totp.Validate does.
+func totpStep(code, secret string, now time.Time) (int64, bool) {
+	if len(code) != 6 {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for _, step := range []int64{current - 1, current, current + 1} {
+		want, err := totp.GenerateCode(secret, time.Unix(step*totpPeriod, 0))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(code), []byte(want)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"github.com/deeploy-sh/deeploy/internal/server/auth"
 	"github.com/deeploy-sh/deeploy/internal/server/forms"
-	"github.com/deeploy-sh/deeploy/internal/server/jwt"
 	"github.com/deeploy-sh/deeploy/internal/server/repo"
 	"github.com/deeploy-sh/deeploy/internal/shared/errs"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
@@ -11,10 +10,11 @@ import (
 )
 
 type UserServiceInterface interface {
-	Register(form forms.RegisterForm) (string, error)
-	Login(email, password string) (string, error)
+	Register(form forms.RegisterForm) (*model.User, error)
+	Login(email, password string) (*model.User, error)
 	GetUserByID(id string) (*model.User, error)
 	HasUser() (bool, error)
+	HasEmail(email string) (bool, error)
 }
 
 type UserService struct {
@@ -34,50 +34,57 @@ func (s *UserService) HasUser() (bool, error) {
 	return hasUser, nil
 }
 
-func (s *UserService) Register(form forms.RegisterForm) (string, error) {
+// HasEmail reports whether an account with the email exists.
+func (s *UserService) HasEmail(email string) (bool, error) {
+	user, err := s.repo.GetUserByEmail(email)
+	if err != nil {
+		return false, err
+	}
+	return user != nil, nil
+}
+
+func (s *UserService) Register(form forms.RegisterForm) (*model.User, error) {
 	foundUser, err := s.repo.GetUserByEmail(form.Email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if foundUser != nil {
-		return "", errs.ErrDuplicateEmail
+		return nil, errs.ErrDuplicateEmail
 	}
 	hashedPwd, err := auth.HashPassword(form.Password)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	// The first user set up the server and administers it
+	count, err := s.repo.CountUsers()
+	if err != nil {
+		return nil, err
 	}
 	user := &model.User{
 		ID:       uuid.New().String(),
 		Email:    form.Email,
 		Password: hashedPwd,
+		IsAdmin:  count == 0,
 	}
 	err = s.repo.CreateUser(user)
 	if err != nil {
-		return "", err
-	}
-	token, err := jwt.CreateToken(user.ID)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return token, nil
+	return user, nil
 }
 
-func (s *UserService) Login(email, password string) (string, error) {
+func (s *UserService) Login(email, password string) (*model.User, error) {
 	user, err := s.repo.GetUserByEmail(email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
-		return "", errs.ErrInvalidCredentials
+		return nil, errs.ErrInvalidCredentials
 	}
 	if !auth.ComparePassword(user.Password, password) {
-		return "", errs.ErrInvalidCredentials
+		return nil, errs.ErrInvalidCredentials
 	}
-	token, err := jwt.CreateToken(user.ID)
-	if err != nil {
-		return "", err
-	}
-	return token, nil
+	return user, nil
 }
 
 func (s *UserService) GetUserByID(id string) (*model.User, error) {
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// APITokenPrefix starts every API token, to tell them apart from session JWTs.
+const APITokenPrefix = "dpl_"
+
+// API token scopes, a higher scope includes the lower ones
+const (
+	APITokenScopeRead   = "read"   // GET requests
+	APITokenScopeDeploy = "deploy" // also deploy, stop, restart and roll back pods
+	APITokenScopeAdmin  = "admin"  // everything the user can do
+)
+
+// APIToken is a personal access token for scripts and CI.
+// Token is only set once, in the response that created it.
+type APIToken struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id,omitempty" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Scope      string     `json:"scope" db:"scope"`
+	Prefix     string     `json:"prefix" db:"prefix"` // first characters of the token, to recognize it
+	TokenHash  string     `json:"-" db:"token_hash"`
+	ExpiresAt  *time.Time `json:"expires_at" db:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	Token      string     `json:"token,omitempty" db:"-"`
+}
+
+type APITokenCreate struct {
+	Name          string `json:"name"`
+	Scope         string `json:"scope"`
+	ExpiresInDays int    `json:"expires_in_days"` // 0 = never expires
+}
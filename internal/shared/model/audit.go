@@ -0,0 +1,65 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AuditRedacted replaces secrets in the changes of an audit event.
+const AuditRedacted = "[redacted]"
+
+// AuditEvent records a change made by a user or a webhook.
+type AuditEvent struct {
+	ID         string       `json:"id" db:"id"`
+	ActorID    string       `json:"actor_id" db:"actor_id"` // empty for webhooks
+	ActorEmail string       `json:"actor_email" db:"actor_email"`
+	Action     string       `json:"action" db:"action"` // e.g. pod.deploy
+	TargetType string       `json:"target_type" db:"target_type"`
+	TargetID   string       `json:"target_id" db:"target_id"`
+	TargetName string       `json:"target_name" db:"target_name"`
+	ProjectID  string       `json:"project_id,omitempty" db:"project_id"` // empty outside projects
+	Changes    AuditChanges `json:"changes" db:"changes"`
+	IP         string       `json:"ip" db:"ip"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+}
+
+// AuditChange is a field before and after a change, nil when it didn't exist.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditChanges are the changed fields of an audit event by name.
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+func (c *AuditChanges) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case []byte:
+		return json.Unmarshal(v, c)
+	case nil:
+		*c = nil
+		return nil
+	}
+	return fmt.Errorf("audit changes: unsupported type %T", src)
+}
+
+// AuditFilter narrows the audit log, empty fields match everything.
+type AuditFilter struct {
+	Action     string // exact action, or a prefix ending in "." like "pod."
+	Actor      string // ID or email
+	TargetType string
+	TargetID   string
+	ProjectID  string
+	Since      time.Time
+	Until      time.Time
+	Limit      int
+}
@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// Backup targets
+const (
+	BackupTargetDatabase = "database" // managed database, dumped with the engine's tools
+	BackupTargetVolume   = "volume"   // named pod volume, archived with tar
+)
+
+// Backup destinations
+const (
+	BackupDestinationLocal = "local" // backup directory of the server
+	BackupDestinationS3    = "s3"    // S3-compatible bucket, configured on the server
+)
+
+// Backup statuses
+const (
+	BackupStatusRunning = "running"
+	BackupStatusSuccess = "success"
+	BackupStatusFailed  = "failed"
+)
+
+// Backup schedule defaults
+const (
+	DefaultBackupIntervalHours = 24
+	DefaultBackupRetention     = 7
+)
+
+// BackupSchedule backs up a database or pod volume every IntervalHours.
+// Only the newest Retention successful backups of the target are kept.
+type BackupSchedule struct {
+	ID            string     `json:"id" db:"id"`
+	UserID        string     `json:"user_id" db:"user_id"`
+	TargetType    string     `json:"target_type" db:"target_type"`
+	TargetID      string     `json:"target_id" db:"target_id"`
+	IntervalHours int        `json:"interval_hours" db:"interval_hours"`
+	Retention     int        `json:"retention" db:"retention"`
+	Destination   string     `json:"destination" db:"destination"`
+	Enabled       bool       `json:"enabled" db:"enabled"`
+	LastRunAt     *time.Time `json:"last_run_at" db:"last_run_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Backup is a compressed archive of a database or pod volume.
+// StorageKey is the path of the archive within its destination.
+type Backup struct {
+	ID          string     `json:"id" db:"id"`
+	UserID      string     `json:"user_id" db:"user_id"`
+	TargetType  string     `json:"target_type" db:"target_type"`
+	TargetID    string     `json:"target_id" db:"target_id"`
+	Destination string     `json:"destination" db:"destination"`
+	StorageKey  string     `json:"storage_key" db:"storage_key"`
+	Size        int64      `json:"size" db:"size"`
+	Status      string     `json:"status" db:"status"`
+	Error       *string    `json:"error" db:"error"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at" db:"finished_at"`
+}
+
+// Backups is the backup overview of a target.
+// Schedule is nil until one is saved.
+type Backups struct {
+	Schedule *BackupSchedule `json:"schedule"`
+	Backups  []Backup        `json:"backups"`
+}
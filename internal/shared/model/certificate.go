@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Certificate is an uploaded TLS certificate of a pod domain,
+// served instead of one from Let's Encrypt.
+type Certificate struct {
+	ID          string    `json:"id" db:"id"`
+	PodDomainID string    `json:"pod_domain_id" db:"pod_domain_id"`
+	Certificate string    `json:"-" db:"certificate"` // PEM, leaf first, then intermediates
+	PrivateKey  string    `json:"-" db:"private_key"` // PEM, encrypted at rest
+	Issuer      string    `json:"issuer" db:"issuer"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CertificateUpload struct {
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"private_key"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// Database engines
+const (
+	DatabaseEnginePostgres = "postgres"
+	DatabaseEngineMySQL    = "mysql"
+	DatabaseEngineRedis    = "redis"
+)
+
+// Database statuses
+const (
+	DatabaseStatusProvisioning = "provisioning" // pulling the image and starting the container
+	DatabaseStatusRunning      = "running"
+	DatabaseStatusFailed       = "failed"
+)
+
+// Database is a managed database container of a project.
+// It runs on the deeploy network and is reachable by pods under Host.
+type Database struct {
+	ID           string    `json:"id" db:"id"`
+	UserID       string    `json:"user_id" db:"user_id"`
+	ProjectID    string    `json:"project_id" db:"project_id"`
+	Title        string    `json:"title" db:"title"`
+	Engine       string    `json:"engine" db:"engine"`
+	Version      string    `json:"version" db:"version"`
+	Username     string    `json:"username" db:"username"`
+	Password     string    `json:"-" db:"password"`
+	DatabaseName string    `json:"database_name" db:"database_name"`
+	ContainerID  *string   `json:"container_id" db:"container_id"`
+	Status       string    `json:"status" db:"status"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+
+	// Not stored in DB
+	Host           string `json:"host" db:"-"`
+	Port           int    `json:"port" db:"-"`
+	URL            string `json:"url,omitempty" db:"-"` // connection URL incl. password, only set for a single database
+	ContainerState string `json:"container_state" db:"-"`
+}
+
+// PodDatabase links a database to a pod. The connection URL of the
+// database is passed to the pod's container as EnvVar.
+type PodDatabase struct {
+	PodID      string    `json:"pod_id" db:"pod_id"`
+	DatabaseID string    `json:"database_id" db:"database_id"`
+	EnvVar     string    `json:"env_var" db:"env_var"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// Deployment status values
+const (
+	DeploymentStatusBuilding = "building"
+	DeploymentStatusSuccess  = "success"
+	DeploymentStatusFailed   = "failed"
+)
+
+type Deployment struct {
+	ID            string     `json:"id" db:"id"`
+	PodID         string     `json:"pod_id" db:"pod_id"`
+	Ref           *string    `json:"ref" db:"ref"` // requested commit SHA or tag, nil = branch tip
+	CommitSHA     *string    `json:"commit_sha" db:"commit_sha"`
+	CommitMessage *string    `json:"commit_message" db:"commit_message"`
+	Branch        string     `json:"branch" db:"branch"`
+	ImageTag      string     `json:"image_tag" db:"image_tag"`
+	Status        string     `json:"status" db:"status"`
+	TriggeredBy   string     `json:"triggered_by" db:"triggered_by"`
+	RollbackOf    *string    `json:"rollback_of" db:"rollback_of"`
+	NoCache       bool       `json:"no_cache" db:"no_cache"` // built without layer cache and from a fresh clone
+	StartedAt     time.Time  `json:"started_at" db:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at" db:"finished_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// DeployRequest is the optional body of a deploy request.
+// Ref is a commit SHA or tag to deploy instead of the branch tip.
+// NoCache rebuilds from a fresh clone without reusing cached layers.
+type DeployRequest struct {
+	Ref     string `json:"ref"`
+	NoCache bool   `json:"no_cache"`
+}
+
+// DeploymentLogs is a page of a deployment's build log.
+type DeploymentLogs struct {
+	Lines  []string `json:"lines"`
+	Offset int      `json:"offset"`
+	Total  int      `json:"total"`
+	Status string   `json:"status"`
+}
+
+// Log stream event sources
+const (
+	LogSourceBuild     = "build"
+	LogSourceContainer = "container"
+	LogSourceStatus    = "status"
+)
+
+// LogEvent is a single event of the pod log stream.
+// ID is the resume cursor (sent back as Last-Event-ID), empty for status events.
+type LogEvent struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Data   string `json:"data"`
+}
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// DNS providers of the ACME DNS challenge, named like their Traefik (lego) provider.
+const (
+	DNSProviderCloudflare = "cloudflare"
+	DNSProviderHetzner    = "hetzner"
+	DNSProviderRoute53    = "route53"
+	DNSProviderRFC2136    = "rfc2136"
+)
+
+// DNSProviders lists the supported DNS providers in display order.
+var DNSProviders = []string{DNSProviderCloudflare, DNSProviderHetzner, DNSProviderRoute53, DNSProviderRFC2136}
+
+// DNSCredential is a setting of a DNS provider. Key is the environment
+// variable Traefik reads it from.
+type DNSCredential struct {
+	Key      string
+	Label    string
+	Secret   bool // never sent back to clients
+	Optional bool
+}
+
+// DNSProviderCredentials are the credentials of each DNS provider.
+var DNSProviderCredentials = map[string][]DNSCredential{
+	DNSProviderCloudflare: {
+		{Key: "CF_DNS_API_TOKEN", Label: "API token (Zone:DNS:Edit)", Secret: true},
+	},
+	DNSProviderHetzner: {
+		{Key: "HETZNER_API_KEY", Label: "DNS API token", Secret: true},
+	},
+	DNSProviderRoute53: {
+		{Key: "AWS_ACCESS_KEY_ID", Label: "Access key ID"},
+		{Key: "AWS_SECRET_ACCESS_KEY", Label: "Secret access key", Secret: true},
+		{Key: "AWS_REGION", Label: "Region"},
+		{Key: "AWS_HOSTED_ZONE_ID", Label: "Hosted zone ID", Optional: true},
+	},
+	DNSProviderRFC2136: {
+		{Key: "RFC2136_NAMESERVER", Label: "Nameserver (host:port)"},
+		{Key: "RFC2136_TSIG_KEY", Label: "TSIG key name"},
+		{Key: "RFC2136_TSIG_SECRET", Label: "TSIG secret", Secret: true},
+		{Key: "RFC2136_TSIG_ALGORITHM", Label: "TSIG algorithm (hmac-sha256.)", Optional: true},
+	},
+}
+
+// DNSSettings select the DNS provider Let's Encrypt certificates are
+// issued with. Without them Traefik uses the HTTP challenge.
+type DNSSettings struct {
+	Provider string `json:"provider" db:"provider"`
+	// Credentials by key. Responses leave out secrets, on updates an
+	// empty secret keeps the saved one.
+	Credentials     map[string]string `json:"credentials" db:"-"`
+	CredentialsData string            `json:"-" db:"credentials"` // Credentials as encrypted JSON
+	UpdatedAt       time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// DNSRecord is the record a domain needs to point at the server.
+type DNSRecord struct {
+	Type  string `json:"type"` // A or AAAA
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Project roles, each includes the permissions of the ones below it.
+const (
+	ProjectRoleOwner     = "owner"     // manages the project and its members
+	ProjectRoleDeveloper = "developer" // changes and deploys pods
+	ProjectRoleViewer    = "viewer"    // reads pods, domains and logs
+)
+
+// DefaultInviteDays is how long an invitation can be accepted.
+const DefaultInviteDays = 7
+
+type ProjectMember struct {
+	ProjectID string    `json:"project_id" db:"project_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Email     string    `json:"email" db:"email"`
+	Role      string    `json:"role" db:"role"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type ProjectInvite struct {
+	ID        string    `json:"id" db:"id"`
+	ProjectID string    `json:"project_id" db:"project_id"`
+	Email     string    `json:"email" db:"email"`
+	Role      string    `json:"role" db:"role"`
+	InvitedBy string    `json:"invited_by" db:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Only set when the invite is created, the link is /auth?invite=<token>
+	Token     string `json:"token,omitempty" db:"-"`
+	TokenHash string `json:"-" db:"token_hash"`
+}
+
+// Members is the response of a project's member list.
+type Members struct {
+	Members []ProjectMember `json:"members"`
+	Invites []ProjectInvite `json:"invites"`
+}
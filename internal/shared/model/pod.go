@@ -2,46 +2,136 @@ package model
 
 import "time"
 
+// Build strategies
+const (
+	BuildStrategyDockerfile = "dockerfile" // use the Dockerfile from the repo
+	BuildStrategyAuto       = "auto"       // detect the app type and generate a Dockerfile
+)
+
+// Health check defaults, durations in seconds
+const (
+	DefaultHealthCheckPath        = "/"
+	DefaultHealthCheckInterval    = 2
+	DefaultHealthCheckTimeout     = 5
+	DefaultHealthCheckGracePeriod = 60
+	MaxHealthCheckGracePeriod     = 600 // deploys, restarts and rollbacks wait at most this long
+)
+
+// Pod sources
+const (
+	PodSourceGit   = "git"   // clone RepoURL and build
+	PodSourceImage = "image" // pull Image from a registry
+)
+
+// Domain types
+const (
+	DomainTypeCustom   = "custom"   // exact host, optionally limited to a path prefix
+	DomainTypeAuto     = "auto"     // generated sslip.io host
+	DomainTypeWildcard = "wildcard" // *.example.com, certificate via DNS challenge
+	DomainTypeRedirect = "redirect" // permanently redirects to RedirectTo, e.g. www to apex
+)
+
+// Domain verification states, only verified domains are routed
+const (
+	DomainStatusPending  = "pending"  // DNS not checked yet
+	DomainStatusVerified = "verified" // resolves to the server
+	DomainStatusFailed   = "failed"   // no DNS record or one pointing elsewhere
+)
+
 type Pod struct {
-	ID             string    `json:"id" db:"id"`
-	UserID         string    `json:"user_id" db:"user_id"`
-	ProjectID      string    `json:"project_id" db:"project_id"`
-	Title          string    `json:"title" db:"title"`
-	RepoURL        *string   `json:"repo_url" db:"repo_url"`
-	Branch         string    `json:"branch" db:"branch"`
-	DockerfilePath string    `json:"dockerfile_path" db:"dockerfile_path"`
-	GitTokenID     *string   `json:"git_token_id" db:"git_token_id"`
-	ContainerID    *string   `json:"container_id" db:"container_id"`
-	Status         string    `json:"status" db:"status"`
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+	ID             string  `json:"id" db:"id"`
+	UserID         string  `json:"user_id" db:"user_id"`
+	ProjectID      string  `json:"project_id" db:"project_id"`
+	Title          string  `json:"title" db:"title"`
+	SourceType     string  `json:"source_type" db:"source_type"`
+	RepoURL        *string `json:"repo_url" db:"repo_url"`
+	Branch         string  `json:"branch" db:"branch"`
+	DockerfilePath string  `json:"dockerfile_path" db:"dockerfile_path"`
+	BuildStrategy  string  `json:"build_strategy" db:"build_strategy"`
+	GitTokenID     *string `json:"git_token_id" db:"git_token_id"`
+
+	// Image source: image reference (e.g. ghcr.io/org/app:1.4.2) and optional registry login
+	Image                *string `json:"image" db:"image"`
+	RegistryCredentialID *string `json:"registry_credential_id" db:"registry_credential_id"`
+
+	// Health check - a new container must pass it before the old one is stopped.
+	// Status 0 accepts any 2xx/3xx response, durations are in seconds.
+	HealthCheckPath        string `json:"health_check_path" db:"health_check_path"`
+	HealthCheckStatus      int    `json:"health_check_status" db:"health_check_status"`
+	HealthCheckInterval    int    `json:"health_check_interval" db:"health_check_interval"`
+	HealthCheckTimeout     int    `json:"health_check_timeout" db:"health_check_timeout"`
+	HealthCheckGracePeriod int    `json:"health_check_grace_period" db:"health_check_grace_period"`
+
+	// Resource limits of the container, 0 = unlimited. Memory in MB, CPU in cores.
+	MemoryLimit       int     `json:"memory_limit" db:"memory_limit"`
+	MemoryReservation int     `json:"memory_reservation" db:"memory_reservation"`
+	CPULimit          float64 `json:"cpu_limit" db:"cpu_limit"`
+	PIDsLimit         int     `json:"pids_limit" db:"pids_limit"`
+
+	ContainerID *string   `json:"container_id" db:"container_id"`
+	Status      string    `json:"status" db:"status"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Live commit - set by successful deploys and rollbacks, not by Update
+	CommitSHA     *string `json:"commit_sha" db:"commit_sha"`
+	CommitMessage *string `json:"commit_message" db:"commit_message"`
 
 	// ContainerState is the live Docker container state (running, exited, etc.)
 	// Not stored in DB - fetched on demand from Docker
 	ContainerState string `json:"container_state" db:"-"`
 }
 
+// Env var scopes
+const (
+	EnvVarScopeRuntime = "runtime" // container environment
+	EnvVarScopeBuild   = "build"   // docker build arg
+	EnvVarScopeBoth    = "both"
+)
+
 type PodEnvVar struct {
 	ID        string    `json:"id" db:"id"`
 	PodID     string    `json:"pod_id" db:"pod_id"`
 	Key       string    `json:"key" db:"key"`
 	Value     string    `json:"value" db:"value"`
+	Scope     string    `json:"scope" db:"scope"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// IsRuntime reports whether the var is set in the container environment.
+func (v PodEnvVar) IsRuntime() bool {
+	return v.Scope == "" || v.Scope == EnvVarScopeRuntime || v.Scope == EnvVarScopeBoth
+}
+
+// IsBuild reports whether the var is passed as docker build arg.
+func (v PodEnvVar) IsBuild() bool {
+	return v.Scope == EnvVarScopeBuild || v.Scope == EnvVarScopeBoth
+}
+
 type PodEnvVarBulkUpdate struct {
 	Vars []PodEnvVar `json:"vars"`
 }
 
 type PodDomain struct {
-	ID         string    `json:"id" db:"id"`
-	PodID      string    `json:"pod_id" db:"pod_id"`
-	Domain     string    `json:"domain" db:"domain"`
-	Type       string    `json:"type" db:"type"`
-	Port       int       `json:"port" db:"port"`
-	SSLEnabled bool      `json:"ssl_enabled" db:"ssl_enabled"`
-	URL        string    `json:"url" db:"-"` // computed, not stored
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	ID         string     `json:"id" db:"id"`
+	PodID      string     `json:"pod_id" db:"pod_id"`
+	Domain     string     `json:"domain" db:"domain"`
+	Path       string     `json:"path" db:"path"`               // path prefix like /api, empty = all paths
+	RedirectTo string     `json:"redirect_to" db:"redirect_to"` // target host of redirect domains
+	Type       string     `json:"type" db:"type"`
+	Port       int        `json:"port" db:"port"`
+	SSLEnabled bool       `json:"ssl_enabled" db:"ssl_enabled"`
+	Status     string     `json:"status" db:"status"`
+	VerifiedAt *time.Time `json:"verified_at" db:"verified_at"`
+	URL        string     `json:"url" db:"-"` // computed, not stored
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Result of the last DNS check and the record the domain needs (not stored)
+	StatusMessage string     `json:"status_message,omitempty" db:"-"`
+	DNSRecord     *DNSRecord `json:"dns_record,omitempty" db:"-"`
+
+	// Uploaded certificate, nil = Let's Encrypt (not stored)
+	Certificate *Certificate `json:"certificate,omitempty" db:"-"`
 }
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Volume types
+const (
+	VolumeTypeVolume = "volume" // named Docker volume, created on first use
+	VolumeTypeBind   = "bind"   // directory on the host
+)
+
+// PodVolume is persistent storage mounted into the container of a pod.
+// Source is the name of a volume or the absolute host path. Named volumes
+// are stored in the Docker volume VolumeName, which belongs to the pod.
+type PodVolume struct {
+	ID         string    `json:"id" db:"id"`
+	PodID      string    `json:"pod_id" db:"pod_id"`
+	Type       string    `json:"type" db:"type"`
+	Source     string    `json:"source" db:"source"`
+	VolumeName string    `json:"volume_name,omitempty" db:"volume_name"`
+	MountPath  string    `json:"mount_path" db:"mount_path"`
+	ReadOnly   bool      `json:"read_only" db:"read_only"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
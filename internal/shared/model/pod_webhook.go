@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type PodWebhook struct {
+	ID        string    `json:"id" db:"id"`
+	PodID     string    `json:"pod_id" db:"pod_id"`
+	Secret    string    `json:"secret" db:"secret"`
+	URL       string    `json:"url" db:"-"` // computed, not stored
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -6,6 +6,7 @@ type Project struct {
 	ID        string    `json:"id" db:"id"`
 	UserID    string    `json:"user_id" db:"user_id"`
 	Title     string    `json:"title" db:"title"`
+	Role      string    `json:"role,omitempty" db:"role"` // role of the requesting user
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type RegistryCredential struct {
+	ID        string    `json:"id" db:"id"`
+	UserID    string    `json:"user_id,omitempty" db:"user_id"`
+	Name      string    `json:"name" db:"name"`
+	Registry  string    `json:"registry" db:"registry"`
+	Username  string    `json:"username" db:"username"`
+	Password  string    `json:"-" db:"password"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type RegistryCredentialCreate struct {
+	Name     string `json:"name"`
+	Registry string `json:"registry"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
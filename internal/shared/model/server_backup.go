@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// ServerBackupFormatVersion is the version of the server backup archive format.
+// Archives with a newer format are rejected on restore.
+const ServerBackupFormatVersion = 1
+
+// ServerBackupManifest describes a server backup archive.
+// KeyCheck is a known value encrypted with the ENCRYPTION_KEY of the exporting
+// server, it tells on restore whether secrets need to be re-encrypted.
+type ServerBackupManifest struct {
+	FormatVersion  int            `json:"format_version"`
+	DeeployVersion string         `json:"deeploy_version"`
+	SchemaVersion  int64          `json:"schema_version"`
+	Driver         string         `json:"driver"`
+	KeyCheck       string         `json:"key_check"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Tables         map[string]int `json:"tables"` // rows per table
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Session is a sign-in of a user in the TUI or a browser.
+// Its refresh token is replaced every time it is used.
+type Session struct {
+	ID                string    `json:"id" db:"id"`
+	UserID            string    `json:"user_id,omitempty" db:"user_id"`
+	RefreshTokenHash  string    `json:"-" db:"refresh_token_hash"`
+	PreviousTokenHash *string   `json:"-" db:"previous_token_hash"`
+	Client            string    `json:"client" db:"client"`
+	IP                string    `json:"ip" db:"ip"`
+	ExpiresAt         time.Time `json:"expires_at" db:"expires_at"`
+	LastSeenAt        time.Time `json:"last_seen_at" db:"last_seen_at"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	Current           bool      `json:"current" db:"-"` // the session of the request
+}
+
+// AuthTokens is a short lived access token and the refresh token to renew it.
+type AuthTokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
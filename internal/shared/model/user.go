@@ -3,14 +3,32 @@ package model
 import "time"
 
 type User struct {
-	ID        string    `json:"id" db:"id"`
-	Email     string    `json:"email" db:"email"`
-	Password  string    `json:"-" db:"password"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID          string    `json:"id" db:"id"`
+	Email       string    `json:"email" db:"email"`
+	Password    string    `json:"-" db:"password"`
+	IsAdmin     bool      `json:"is_admin" db:"is_admin"` // administers the server, the first registered user
+	TOTPSecret  string    `json:"-" db:"totp_secret"`     // encrypted, set while enrolling
+	TOTPEnabled bool      `json:"totp_enabled" db:"totp_enabled"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TwoFactorSetup is shown while enrolling an authenticator app.
+type TwoFactorSetup struct {
+	Secret string // for typing it in
+	URL    string // otpauth:// URL
+	QRCode string // PNG data URL of URL
 }
 
 type UserCreate struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
+
+// RecoveryCode signs in once without the authenticator app, only its hash is stored.
+type RecoveryCode struct {
+	ID       string     `db:"id"`
+	UserID   string     `db:"user_id"`
+	CodeHash string     `db:"code_hash"`
+	UsedAt   *time.Time `db:"used_at"`
+}
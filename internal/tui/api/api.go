@@ -1,11 +1,17 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/deeploy-sh/deeploy/internal/shared/errs"
@@ -21,6 +27,19 @@ func getConfig() (*config.Config, error) {
 }
 
 // checkResponse checks the HTTP response for errors and returns an error if the status code indicates failure.
+// apiError is a failed request with the server's message.
+// It matches errs.ErrNotFound for 404 responses.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e apiError) Error() string { return e.message }
+
+func (e apiError) Is(target error) bool {
+	return target == errs.ErrNotFound && e.status == http.StatusNotFound
+}
+
 func checkResponse(resp *http.Response) error {
 	if resp.StatusCode == http.StatusUnauthorized {
 		return errs.ErrUnauthorized
@@ -31,85 +50,108 @@ func checkResponse(resp *http.Response) error {
 			Error string `json:"error"`
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error != "" {
-			return errors.New(apiErr.Error)
+			return apiError{status: resp.StatusCode, message: apiErr.Error}
 		}
-		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+		return apiError{status: resp.StatusCode, message: fmt.Sprintf("request failed with status %d", resp.StatusCode)}
 	}
 	return nil
 }
 
-func get(path string) (*http.Response, error) {
-	cfg, err := getConfig()
-	if err != nil {
-		return nil, err
-	}
+// refreshMu makes concurrent requests share one refresh, a refresh token only works once.
+var refreshMu sync.Mutex
 
-	req, err := http.NewRequest("GET", cfg.Server+"/api"+path, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", "Bearer "+cfg.Token)
-	req.Header.Set("Content-Type", "application/json")
+// refresh replaces the expired access token in the config. failedToken is
+// the token that was rejected; when the config has a newer one, another
+// request refreshed already.
+func refresh(failedToken string) (*config.Config, error) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
 
-	resp, err := http.DefaultClient.Do(req)
+	cfg, err := getConfig()
 	if err != nil {
 		return nil, err
 	}
-	err = checkResponse(resp)
-	if err != nil {
-		return nil, err
+	if cfg.Token != failedToken {
+		return cfg, nil
+	}
+	if cfg.RefreshToken == "" {
+		return nil, errs.ErrUnauthorized
 	}
-	return resp, nil
-}
 
-func post(path string, data any) (*http.Response, error) {
-	cfg, err := getConfig()
+	jsonData, err := json.Marshal(model.RefreshRequest{RefreshToken: cfg.RefreshToken})
 	if err != nil {
 		return nil, err
 	}
-
-	jsonData, err := json.Marshal(data)
+	resp, err := http.Post(cfg.Server+"/api/auth/refresh", "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", cfg.Server+"/api"+path, bytes.NewBuffer(jsonData))
+	defer resp.Body.Close()
+	err = checkResponse(resp)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+cfg.Token)
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	var tokens model.AuthTokens
+	err = json.NewDecoder(resp.Body).Decode(&tokens)
 	if err != nil {
 		return nil, err
 	}
-	err = checkResponse(resp)
+	cfg.Token = tokens.Token
+	cfg.RefreshToken = tokens.RefreshToken
+	err = config.Save(cfg)
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return cfg, nil
 }
 
-func put(path string, data any) (*http.Response, error) {
+// do sends the request built by newRequest with the access token. On 401
+// the token is refreshed and the request is built and sent once more.
+// The response is not checked for other errors.
+func do(newRequest func(cfg *config.Config) (*http.Request, error)) (*http.Response, error) {
 	cfg, err := getConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	jsonData, err := json.Marshal(data)
+	resp, err := send(cfg, newRequest)
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		cfg, err = refresh(cfg.Token)
+		if err != nil {
+			return nil, err
+		}
+		return send(cfg, newRequest)
+	}
+	return resp, nil
+}
 
-	req, err := http.NewRequest("PUT", cfg.Server+"/api"+path, bytes.NewBuffer(jsonData))
+func send(cfg *config.Config, newRequest func(cfg *config.Config) (*http.Request, error)) (*http.Response, error) {
+	req, err := newRequest(cfg)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+cfg.Token)
-	req.Header.Set("Content-Type", "application/json")
+	return http.DefaultClient.Do(req)
+}
 
-	resp, err := http.DefaultClient.Do(req)
+func request(method, path string, jsonData []byte) (*http.Response, error) {
+	resp, err := do(func(cfg *config.Config) (*http.Request, error) {
+		var body io.Reader
+		if jsonData != nil {
+			body = bytes.NewReader(jsonData)
+		}
+		req, err := http.NewRequest(method, cfg.Server+"/api"+path, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -120,28 +162,28 @@ func put(path string, data any) (*http.Response, error) {
 	return resp, nil
 }
 
-func del(path string) (*http.Response, error) {
-	cfg, err := getConfig()
-	if err != nil {
-		return nil, err
-	}
+func get(path string) (*http.Response, error) {
+	return request("GET", path, nil)
+}
 
-	req, err := http.NewRequest("DELETE", cfg.Server+"/api"+path, nil)
+func post(path string, data any) (*http.Response, error) {
+	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+cfg.Token)
-	req.Header.Set("Content-Type", "application/json")
+	return request("POST", path, jsonData)
+}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	err = checkResponse(resp)
+func put(path string, data any) (*http.Response, error) {
+	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return request("PUT", path, jsonData)
+}
+
+func del(path string) (*http.Response, error) {
+	return request("DELETE", path, nil)
 }
 
 // --- Load All Data ---
@@ -151,6 +193,8 @@ func LoadData() tea.Cmd {
 		projects, errP := fetchProjects()
 		pods, errPod := fetchPods()
 		gitTokens, errT := fetchGitTokens()
+		registryCredentials, errR := fetchRegistryCredentials()
+		databases, errD := fetchDatabases()
 
 		if errP != nil {
 			return msg.Error{Err: errP}
@@ -161,6 +205,12 @@ func LoadData() tea.Cmd {
 		if errT != nil {
 			return msg.Error{Err: errT}
 		}
+		if errR != nil {
+			return msg.Error{Err: errR}
+		}
+		if errD != nil {
+			return msg.Error{Err: errD}
+		}
 
 		// Load all domains and env vars for all pods
 		var podDomains []model.PodDomain
@@ -173,11 +223,13 @@ func LoadData() tea.Cmd {
 		}
 
 		return msg.DataLoaded{
-			Projects:   projects,
-			Pods:       pods,
-			GitTokens:  gitTokens,
-			PodDomains: podDomains,
-			PodEnvVars: podEnvVars,
+			Projects:            projects,
+			Pods:                pods,
+			GitTokens:           gitTokens,
+			RegistryCredentials: registryCredentials,
+			PodDomains:          podDomains,
+			PodEnvVars:          podEnvVars,
+			Databases:           databases,
 		}
 	}
 }
@@ -249,6 +301,93 @@ func DeleteProject(id string) tea.Cmd {
 	}
 }
 
+// --- Project Members ---
+
+func FetchMembers(projectID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/projects/" + projectID + "/members")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var members model.Members
+		err = json.NewDecoder(resp.Body).Decode(&members)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.MembersLoaded{ProjectID: projectID, Members: members}
+	}
+}
+
+func InviteMember(projectID, email, role string) tea.Cmd {
+	return func() tea.Msg {
+		data := model.ProjectInvite{Email: email, Role: role}
+
+		resp, err := post("/projects/"+projectID+"/invites", data)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var invite model.ProjectInvite
+		if err := json.NewDecoder(resp.Body).Decode(&invite); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		cfg, err := getConfig()
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.MemberInvited{Invite: invite, URL: cfg.Server + "/auth?invite=" + invite.Token}
+	}
+}
+
+func UpdateMemberRole(projectID, userID, role string) tea.Cmd {
+	return func() tea.Msg {
+		data := model.ProjectMember{Role: role}
+
+		resp, err := put("/projects/"+projectID+"/members/"+userID, data)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var member model.ProjectMember
+		if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.MemberRoleUpdated{Member: member}
+	}
+}
+
+func RemoveMember(projectID, userID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/projects/" + projectID + "/members/" + userID)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.MemberRemoved{UserID: userID}
+	}
+}
+
+func RevokeInvite(projectID, inviteID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/projects/" + projectID + "/invites/" + inviteID)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.InviteRevoked{InviteID: inviteID}
+	}
+}
+
 // --- Pods ---
 
 func fetchPods() ([]model.Pod, error) {
@@ -300,9 +439,14 @@ func UpdatePod(pod *model.Pod) tea.Cmd {
 	}
 }
 
-func DeletePod(id, projectID string) tea.Cmd {
+// DeletePod deletes a pod. Its named volumes are only removed with removeVolumes.
+func DeletePod(id, projectID string, removeVolumes bool) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := del("/pods/" + id)
+		path := "/pods/" + id
+		if removeVolumes {
+			path += "?volumes=remove"
+		}
+		resp, err := del(path)
 		if err != nil {
 			return msg.Error{Err: err}
 		}
@@ -314,9 +458,11 @@ func DeletePod(id, projectID string) tea.Cmd {
 
 // --- Pod Deploy ---
 
-func DeployPod(id string) tea.Cmd {
+// DeployPod deploys the tip of the pod's branch.
+// noCache rebuilds from a fresh clone without reusing cached layers.
+func DeployPod(id string, noCache bool) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := post("/pods/"+id+"/deploy", nil)
+		resp, err := post("/pods/"+id+"/deploy", model.DeployRequest{NoCache: noCache})
 		if err != nil {
 			return msg.Error{Err: err}
 		}
@@ -370,193 +516,909 @@ func FetchPodLogs(id string) tea.Cmd {
 	}
 }
 
-// --- Git Tokens ---
-
-func fetchGitTokens() ([]model.GitToken, error) {
-	resp, err := get("/git-tokens")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+// --- Pod Log Stream ---
 
-	var tokens []model.GitToken
-	err = json.NewDecoder(resp.Body).Decode(&tokens)
-	if err != nil {
-		return nil, err
-	}
-	return tokens, nil
-}
+// logStreamIdleTimeout closes a stream whose events are no longer consumed
+// (e.g. the logs page was left without closing it).
+const logStreamIdleTimeout = 30 * time.Second
 
-func CreateGitToken(name, provider, token string) tea.Cmd {
+// OpenLogStream connects to the SSE log stream of a pod.
+// cursor resumes after the last received event (empty for a new stream).
+// Read events with NextLogEvent.
+func OpenLogStream(podID, cursor string) tea.Cmd {
 	return func() tea.Msg {
-		data := struct {
-			Name     string `json:"name"`
-			Provider string `json:"provider"`
-			Token    string `json:"token"`
-		}{
-			Name:     name,
-			Provider: provider,
-			Token:    token,
+		ctx, cancel := context.WithCancel(context.Background())
+		resp, err := do(func(cfg *config.Config) (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, "GET", cfg.Server+"/api/pods/"+podID+"/logs/stream", nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Accept", "text/event-stream")
+			if cursor != "" {
+				req.Header.Set("Last-Event-ID", cursor)
+			}
+			return req, nil
+		})
+		if errors.Is(err, errs.ErrUnauthorized) {
+			cancel()
+			return msg.Error{Err: err}
 		}
-
-		resp, err := post("/git-tokens", data)
 		if err != nil {
-			return msg.Error{Err: err}
+			cancel()
+			return msg.LogStreamClosed{PodID: podID, Err: err}
 		}
-		defer resp.Body.Close()
-
-		var created model.GitToken
-		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		err = checkResponse(resp)
+		if err != nil {
+			resp.Body.Close()
+			cancel()
 			return msg.Error{Err: err}
 		}
 
-		return msg.GitTokenCreated{Token: created}
+		events := make(chan model.LogEvent)
+		go readLogStream(resp.Body, events, cancel)
+
+		return msg.LogStreamOpened{PodID: podID, Events: events, Cancel: cancel}
 	}
 }
 
-func DeleteGitToken(id string) tea.Cmd {
+// NextLogEvent waits for the next event of an open log stream.
+func NextLogEvent(podID string, events <-chan model.LogEvent) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := del("/git-tokens/" + id)
-		if err != nil {
-			return msg.Error{Err: err}
+		e, ok := <-events
+		if !ok {
+			return msg.LogStreamClosed{PodID: podID, Events: events}
 		}
-		defer resp.Body.Close()
-
-		return msg.GitTokenDeleted{TokenID: id}
+		if e.Source == "error" {
+			return msg.LogStreamClosed{PodID: podID, Events: events, Err: errors.New(e.Data)}
+		}
+		return msg.LogStreamEvent{PodID: podID, Events: events, Event: e}
 	}
 }
 
-// --- Pod Domains ---
+// readLogStream parses Server-Sent Events from body into events.
+// The channel is closed when the stream ends.
+func readLogStream(body io.ReadCloser, events chan<- model.LogEvent, cancel context.CancelFunc) {
+	defer close(events)
+	defer body.Close()
+	defer cancel()
+
+	emit := func(e model.LogEvent) bool {
+		select {
+		case events <- e:
+			return true
+		case <-time.After(logStreamIdleTimeout):
+			return false
+		}
+	}
 
-func fetchPodDomains(podID string) ([]model.PodDomain, error) {
-	resp, err := get("/pods/" + podID + "/domains")
-	if err != nil {
-		return nil, err
+	var e model.LogEvent
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// Blank line dispatches the event
+			if e.Source != "" && !emit(e) {
+				return
+			}
+			e = model.LogEvent{}
+		case strings.HasPrefix(line, ":"):
+			// Comment (keepalive)
+		case strings.HasPrefix(line, "id: "):
+			e.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			e.Source = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.Data = strings.TrimPrefix(line, "data: ")
+		}
 	}
-	defer resp.Body.Close()
 
-	var domains []model.PodDomain
-	err = json.NewDecoder(resp.Body).Decode(&domains)
-	if err != nil {
-		return nil, err
+	err := scanner.Err()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		emit(model.LogEvent{Source: "error", Data: err.Error()})
 	}
-	return domains, nil
 }
 
-func CreatePodDomain(podID, domain string, port int, sslEnabled bool) tea.Cmd {
+// --- Pod Deployments ---
+
+func FetchPodDeployments(podID string) tea.Cmd {
 	return func() tea.Msg {
-		data := struct {
-			Domain     string `json:"domain"`
-			Port       int    `json:"port"`
-			SSLEnabled bool   `json:"ssl_enabled"`
-		}{
-			Domain:     domain,
-			Port:       port,
-			SSLEnabled: sslEnabled,
+		resp, err := get("/pods/" + podID + "/deployments")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var deployments []model.Deployment
+		err = json.NewDecoder(resp.Body).Decode(&deployments)
+		if err != nil {
+			return msg.Error{Err: err}
 		}
 
-		resp, err := post("/pods/"+podID+"/domains", data)
+		return msg.PodDeploymentsLoaded{PodID: podID, Deployments: deployments}
+	}
+}
+
+func FetchDeploymentLogs(podID, deploymentID string, offset, limit int) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get(fmt.Sprintf("/pods/%s/deployments/%s/logs?offset=%d&limit=%d", podID, deploymentID, offset, limit))
 		if err != nil {
 			return msg.Error{Err: err}
 		}
 		defer resp.Body.Close()
 
-		var created model.PodDomain
-		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		var logs model.DeploymentLogs
+		err = json.NewDecoder(resp.Body).Decode(&logs)
+		if err != nil {
 			return msg.Error{Err: err}
 		}
 
-		return msg.PodDomainCreated{Domain: created}
+		return msg.DeploymentLogsLoaded{DeploymentID: deploymentID, Logs: logs}
 	}
 }
 
-func DeletePodDomain(podID, domainID string) tea.Cmd {
+func RollbackPod(podID, deploymentID string) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := del("/pods/" + podID + "/domains/" + domainID)
+		resp, err := post("/pods/"+podID+"/deployments/"+deploymentID+"/rollback", nil)
 		if err != nil {
 			return msg.Error{Err: err}
 		}
 		defer resp.Body.Close()
 
-		return msg.PodDomainDeleted{DomainID: domainID, PodID: podID}
+		var deployment model.Deployment
+		if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodRolledBack{Deployment: deployment}
 	}
 }
 
-func UpdatePodDomain(podID, domainID, domain string, port int, sslEnabled bool) tea.Cmd {
+// --- Pod Webhooks ---
+
+func FetchPodWebhook(podID string) tea.Cmd {
 	return func() tea.Msg {
-		data := model.PodDomain{
-			Domain:     domain,
-			Port:       port,
-			SSLEnabled: sslEnabled,
+		resp, err := get("/pods/" + podID + "/webhook")
+		if errors.Is(err, errs.ErrNotFound) {
+			// Not enabled yet, RegeneratePodWebhook creates the secret
+			return msg.PodWebhookLoaded{Webhook: model.PodWebhook{PodID: podID}}
 		}
-
-		resp, err := put("/pods/"+podID+"/domains/"+domainID, data)
 		if err != nil {
 			return msg.Error{Err: err}
 		}
 		defer resp.Body.Close()
 
-		var updated model.PodDomain
-		if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+		var webhook model.PodWebhook
+		err = json.NewDecoder(resp.Body).Decode(&webhook)
+		if err != nil {
 			return msg.Error{Err: err}
 		}
 
-		return msg.PodDomainUpdated{Domain: updated}
+		return msg.PodWebhookLoaded{Webhook: webhook}
 	}
 }
 
-func GenerateAutoDomain(podID string, port int, sslEnabled bool) tea.Cmd {
+func RegeneratePodWebhook(podID string) tea.Cmd {
 	return func() tea.Msg {
-		data := struct {
-			Port       int  `json:"port"`
-			SSLEnabled bool `json:"ssl_enabled"`
-		}{
-			Port:       port,
-			SSLEnabled: sslEnabled,
-		}
-
-		resp, err := post("/pods/"+podID+"/domains/generate", data)
+		resp, err := post("/pods/"+podID+"/webhook/regenerate", nil)
 		if err != nil {
 			return msg.Error{Err: err}
 		}
 		defer resp.Body.Close()
 
-		var created model.PodDomain
-		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		var webhook model.PodWebhook
+		err = json.NewDecoder(resp.Body).Decode(&webhook)
+		if err != nil {
 			return msg.Error{Err: err}
 		}
 
-		return msg.PodDomainCreated{Domain: created}
+		return msg.PodWebhookRegenerated{Webhook: webhook}
 	}
 }
 
-// --- Pod Env Vars ---
+// --- Git Tokens ---
 
-func fetchPodEnvVars(podID string) ([]model.PodEnvVar, error) {
-	resp, err := get("/pods/" + podID + "/vars")
+func fetchGitTokens() ([]model.GitToken, error) {
+	resp, err := get("/git-tokens")
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var envVars []model.PodEnvVar
-	err = json.NewDecoder(resp.Body).Decode(&envVars)
+	var tokens []model.GitToken
+	err = json.NewDecoder(resp.Body).Decode(&tokens)
 	if err != nil {
 		return nil, err
 	}
-	return envVars, nil
+	return tokens, nil
 }
 
-func UpdatePodEnvVars(podID string, vars []model.PodEnvVar) tea.Cmd {
+func CreateGitToken(name, provider, token string) tea.Cmd {
 	return func() tea.Msg {
 		data := struct {
-			Vars []model.PodEnvVar `json:"vars"`
-		}{Vars: vars}
-
-		resp, err := put("/pods/"+podID+"/vars", data)
-		if err != nil {
-			return msg.Error{Err: err}
-		}
+			Name     string `json:"name"`
+			Provider string `json:"provider"`
+			Token    string `json:"token"`
+		}{
+			Name:     name,
+			Provider: provider,
+			Token:    token,
+		}
+
+		resp, err := post("/git-tokens", data)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var created model.GitToken
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.GitTokenCreated{Token: created}
+	}
+}
+
+func DeleteGitToken(id string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/git-tokens/" + id)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.GitTokenDeleted{TokenID: id}
+	}
+}
+
+// --- API Tokens ---
+
+func FetchAPITokens() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/tokens")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var tokens []model.APIToken
+		err = json.NewDecoder(resp.Body).Decode(&tokens)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.APITokensLoaded{Tokens: tokens}
+	}
+}
+
+func CreateAPIToken(token model.APITokenCreate) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/tokens", token)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var created model.APIToken
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.APITokenCreated{Token: created}
+	}
+}
+
+func DeleteAPIToken(id string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/tokens/" + id)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.APITokenDeleted{TokenID: id}
+	}
+}
+
+// --- Sessions ---
+
+func FetchSessions() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/sessions")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var sessions []model.Session
+		err = json.NewDecoder(resp.Body).Decode(&sessions)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.SessionsLoaded{Sessions: sessions}
+	}
+}
+
+func DeleteSession(id string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/sessions/" + id)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.SessionDeleted{SessionID: id}
+	}
+}
+
+// --- Audit Log ---
+
+// FetchAuditEvents loads the newest audit events visible to the user.
+func FetchAuditEvents() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/audit?limit=200")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var events []model.AuditEvent
+		err = json.NewDecoder(resp.Body).Decode(&events)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.AuditEventsLoaded{Events: events}
+	}
+}
+
+// --- Registry Credentials ---
+
+func fetchRegistryCredentials() ([]model.RegistryCredential, error) {
+	resp, err := get("/registry-credentials")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var credentials []model.RegistryCredential
+	err = json.NewDecoder(resp.Body).Decode(&credentials)
+	if err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+func CreateRegistryCredential(credential model.RegistryCredentialCreate) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/registry-credentials", credential)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var created model.RegistryCredential
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.RegistryCredentialCreated{Credential: created}
+	}
+}
+
+func DeleteRegistryCredential(id string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/registry-credentials/" + id)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.RegistryCredentialDeleted{CredentialID: id}
+	}
+}
+
+// --- Pod Domains ---
+
+func fetchPodDomains(podID string) ([]model.PodDomain, error) {
+	resp, err := get("/pods/" + podID + "/domains")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var domains []model.PodDomain
+	err = json.NewDecoder(resp.Body).Decode(&domains)
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// CreatePodDomain adds a domain to a pod. The server derives the type,
+// *.example.com is a wildcard and a domain with RedirectTo only redirects.
+func CreatePodDomain(podID string, domain model.PodDomain) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/pods/"+podID+"/domains", domain)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var created model.PodDomain
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDomainCreated{Domain: created}
+	}
+}
+
+func DeletePodDomain(podID, domainID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/pods/" + podID + "/domains/" + domainID)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.PodDomainDeleted{DomainID: domainID, PodID: podID}
+	}
+}
+
+func UpdatePodDomain(podID, domainID string, domain model.PodDomain) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := put("/pods/"+podID+"/domains/"+domainID, domain)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var updated model.PodDomain
+		if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDomainUpdated{Domain: updated}
+	}
+}
+
+// VerifyPodDomain checks the DNS of a domain again, only verified domains
+// are routed on deploy.
+func VerifyPodDomain(podID, domainID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/pods/"+podID+"/domains/"+domainID+"/verify", nil)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var verified model.PodDomain
+		if err := json.NewDecoder(resp.Body).Decode(&verified); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDomainVerified{Domain: verified}
+	}
+}
+
+// SkipPodDomainVerification routes a domain without checking its DNS, for
+// domains behind a CDN or NAT. Only project owners may skip the check.
+func SkipPodDomainVerification(podID, domainID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/pods/"+podID+"/domains/"+domainID+"/skip-verification", nil)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var verified model.PodDomain
+		if err := json.NewDecoder(resp.Body).Decode(&verified); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDomainVerified{Domain: verified}
+	}
+}
+
+// FetchDNSRecord returns the record new domains of a pod need, the form
+// fills in the name.
+func FetchDNSRecord(podID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/pods/" + podID + "/domains/dns-record")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var record model.DNSRecord
+		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.DNSRecordLoaded{Record: record}
+	}
+}
+
+// UploadCertificate replaces the certificate of a domain, Traefik serves it
+// instead of one from Let's Encrypt after the next deploy.
+func UploadCertificate(podID, domainID string, upload model.CertificateUpload) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := put("/pods/"+podID+"/domains/"+domainID+"/certificate", upload)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var updated model.PodDomain
+		if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDomainUpdated{Domain: updated}
+	}
+}
+
+func DeleteCertificate(podID, domainID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/pods/" + podID + "/domains/" + domainID + "/certificate")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var updated model.PodDomain
+		if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDomainUpdated{Domain: updated}
+	}
+}
+
+func GenerateAutoDomain(podID string, port int, sslEnabled bool) tea.Cmd {
+	return func() tea.Msg {
+		data := struct {
+			Port       int  `json:"port"`
+			SSLEnabled bool `json:"ssl_enabled"`
+		}{
+			Port:       port,
+			SSLEnabled: sslEnabled,
+		}
+
+		resp, err := post("/pods/"+podID+"/domains/generate", data)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var created model.PodDomain
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDomainCreated{Domain: created}
+	}
+}
+
+// --- Pod Volumes ---
+
+func FetchPodVolumes(podID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/pods/" + podID + "/volumes")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var volumes []model.PodVolume
+		err = json.NewDecoder(resp.Body).Decode(&volumes)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodVolumesLoaded{PodID: podID, Volumes: volumes}
+	}
+}
+
+func CreatePodVolume(podID string, volume model.PodVolume) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/pods/"+podID+"/volumes", volume)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var created model.PodVolume
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodVolumeCreated{Volume: created}
+	}
+}
+
+func UpdatePodVolume(podID string, volume model.PodVolume) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := put("/pods/"+podID+"/volumes/"+volume.ID, volume)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var updated model.PodVolume
+		if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodVolumeUpdated{Volume: updated}
+	}
+}
+
+func DeletePodVolume(podID, volumeID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/pods/" + podID + "/volumes/" + volumeID)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.PodVolumeDeleted{VolumeID: volumeID, PodID: podID}
+	}
+}
+
+// --- Backups ---
+
+// BackupTarget is the database or pod volume of backup requests.
+// Pod volumes are identified by PodID and VolumeID.
+type BackupTarget struct {
+	DatabaseID string
+	PodID      string
+	VolumeID   string
+}
+
+// ID returns the ID of the database or volume.
+func (t BackupTarget) ID() string {
+	if t.VolumeID != "" {
+		return t.VolumeID
+	}
+	return t.DatabaseID
+}
+
+func (t BackupTarget) path() string {
+	if t.VolumeID != "" {
+		return "/pods/" + t.PodID + "/volumes/" + t.VolumeID + "/backups"
+	}
+	return "/databases/" + t.DatabaseID + "/backups"
+}
+
+func FetchBackups(target BackupTarget) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get(target.path())
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var backups model.Backups
+		err = json.NewDecoder(resp.Body).Decode(&backups)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.BackupsLoaded{TargetID: target.ID(), Backups: backups}
+	}
+}
+
+func RunBackup(target BackupTarget) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post(target.path(), nil)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var backup model.Backup
+		if err := json.NewDecoder(resp.Body).Decode(&backup); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.BackupStarted{Backup: backup}
+	}
+}
+
+func SaveBackupSchedule(target BackupTarget, schedule model.BackupSchedule) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := put(target.path()+"/schedule", schedule)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var saved model.BackupSchedule
+		if err := json.NewDecoder(resp.Body).Decode(&saved); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.BackupScheduleSaved{Schedule: saved}
+	}
+}
+
+func RestoreBackup(backupID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/backups/"+backupID+"/restore", nil)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.BackupRestored{BackupID: backupID}
+	}
+}
+
+func DeleteBackup(backupID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/backups/" + backupID)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.BackupDeleted{BackupID: backupID}
+	}
+}
+
+// --- Databases ---
+
+func fetchDatabases() ([]model.Database, error) {
+	resp, err := get("/databases")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var databases []model.Database
+	err = json.NewDecoder(resp.Body).Decode(&databases)
+	if err != nil {
+		return nil, err
+	}
+	return databases, nil
+}
+
+// FetchDatabase loads a database including its connection URL.
+func FetchDatabase(id string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/databases/" + id)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var database model.Database
+		if err := json.NewDecoder(resp.Body).Decode(&database); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.DatabaseLoaded{Database: database}
+	}
+}
+
+func CreateDatabase(database *model.Database) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := post("/databases", database)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var created model.Database
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.DatabaseCreated{Database: created}
+	}
+}
+
+// DeleteDatabase deletes a database. Its data volume is only removed with removeVolume.
+func DeleteDatabase(id, projectID string, removeVolume bool) tea.Cmd {
+	return func() tea.Msg {
+		path := "/databases/" + id
+		if removeVolume {
+			path += "?volumes=remove"
+		}
+		resp, err := del(path)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.DatabaseDeleted{DatabaseID: id, ProjectID: projectID}
+	}
+}
+
+// --- Pod Databases ---
+
+func FetchPodDatabases(podID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/pods/" + podID + "/databases")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var links []model.PodDatabase
+		err = json.NewDecoder(resp.Body).Decode(&links)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDatabasesLoaded{PodID: podID, Links: links}
+	}
+}
+
+// LinkPodDatabase links a database to a pod. An empty envVar uses the server default.
+func LinkPodDatabase(podID, databaseID, envVar string) tea.Cmd {
+	return func() tea.Msg {
+		data := model.PodDatabase{DatabaseID: databaseID, EnvVar: envVar}
+
+		resp, err := post("/pods/"+podID+"/databases", data)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var link model.PodDatabase
+		if err := json.NewDecoder(resp.Body).Decode(&link); err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.PodDatabaseLinked{Link: link}
+	}
+}
+
+func UnlinkPodDatabase(podID, databaseID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/pods/" + podID + "/databases/" + databaseID)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.PodDatabaseUnlinked{PodID: podID, DatabaseID: databaseID}
+	}
+}
+
+// --- Pod Env Vars ---
+
+func fetchPodEnvVars(podID string) ([]model.PodEnvVar, error) {
+	resp, err := get("/pods/" + podID + "/vars")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var envVars []model.PodEnvVar
+	err = json.NewDecoder(resp.Body).Decode(&envVars)
+	if err != nil {
+		return nil, err
+	}
+	return envVars, nil
+}
+
+func UpdatePodEnvVars(podID string, vars []model.PodEnvVar) tea.Cmd {
+	return func() tea.Msg {
+		data := struct {
+			Vars []model.PodEnvVar `json:"vars"`
+		}{Vars: vars}
+
+		resp, err := put("/pods/"+podID+"/vars", data)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
 		defer resp.Body.Close()
 
 		var updated []model.PodEnvVar
@@ -649,6 +1511,56 @@ func DeleteServerDomain() tea.Cmd {
 	}
 }
 
+// GetDNSSettings loads the DNS provider of the ACME DNS challenge,
+// secret credentials are left out.
+func GetDNSSettings() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := get("/settings/dns")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var settings model.DNSSettings
+		err = json.NewDecoder(resp.Body).Decode(&settings)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.DNSSettingsLoaded{Settings: settings}
+	}
+}
+
+func SetDNSSettings(settings model.DNSSettings) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := put("/settings/dns", settings)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var saved model.DNSSettings
+		err = json.NewDecoder(resp.Body).Decode(&saved)
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+
+		return msg.DNSSettingsSaved{Settings: saved}
+	}
+}
+
+func DeleteDNSSettings() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := del("/settings/dns")
+		if err != nil {
+			return msg.Error{Err: err}
+		}
+		defer resp.Body.Close()
+
+		return msg.DNSSettingsDeleted{}
+	}
+}
+
 // --- Version Check ---
 
 func CheckLatestVersion() tea.Cmd {
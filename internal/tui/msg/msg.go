@@ -11,14 +11,15 @@ type ChangePage struct {
 	PageFactory func(s Store) tea.Model
 }
 
-
 // Store interface for page factories
 type Store interface {
 	Projects() []model.Project
 	Pods() []model.Pod
 	GitTokens() []model.GitToken
+	RegistryCredentials() []model.RegistryCredential
 	PodDomains(podID string) []model.PodDomain
 	PodEnvVars(podID string) []model.PodEnvVar
+	Databases() []model.Database
 }
 
 // --- Connection ---
@@ -46,11 +47,13 @@ type AuthSuccess struct{}
 // --- Data Loaded ---
 
 type DataLoaded struct {
-	Projects   []model.Project
-	Pods       []model.Pod
-	GitTokens  []model.GitToken
-	PodDomains []model.PodDomain
-	PodEnvVars []model.PodEnvVar
+	Projects            []model.Project
+	Pods                []model.Pod
+	GitTokens           []model.GitToken
+	RegistryCredentials []model.RegistryCredential
+	PodDomains          []model.PodDomain
+	PodEnvVars          []model.PodEnvVar
+	Databases           []model.Database
 }
 
 type ProjectsLoaded struct{ Projects []model.Project }
@@ -68,6 +71,20 @@ type PodDeleted struct {
 	ProjectID string
 }
 
+// --- Project Members ---
+
+type MembersLoaded struct {
+	ProjectID string
+	Members   model.Members
+}
+type MemberInvited struct {
+	Invite model.ProjectInvite
+	URL    string // invite link, only available right after inviting
+}
+type MemberRoleUpdated struct{ Member model.ProjectMember }
+type MemberRemoved struct{ UserID string }
+type InviteRevoked struct{ InviteID string }
+
 // --- Pod Deploy ---
 
 type PodLoaded struct{ Pod model.Pod }
@@ -76,21 +93,124 @@ type PodStopped struct{}
 type PodRestarted struct{}
 type PodLogsLoaded struct{ Logs []string }
 
+// --- Pod Log Stream ---
+
+type LogStreamOpened struct {
+	PodID  string
+	Events <-chan model.LogEvent
+	Cancel func()
+}
+type LogStreamEvent struct {
+	PodID  string
+	Events <-chan model.LogEvent
+	Event  model.LogEvent
+}
+
+// LogStreamClosed is sent when a stream ends. Err is set if the connection
+// failed (the stream can be resumed), nil if the server finished the stream.
+type LogStreamClosed struct {
+	PodID  string
+	Events <-chan model.LogEvent
+	Err    error
+}
+
+// --- Pod Deployments ---
+
+type PodDeploymentsLoaded struct {
+	PodID       string
+	Deployments []model.Deployment
+}
+type PodRolledBack struct{ Deployment model.Deployment }
+type DeploymentLogsLoaded struct {
+	DeploymentID string
+	Logs         model.DeploymentLogs
+}
+
+// --- Pod Webhooks ---
+
+type PodWebhookLoaded struct{ Webhook model.PodWebhook }
+type PodWebhookRegenerated struct{ Webhook model.PodWebhook }
+
 // --- Git Tokens ---
 
 type GitTokenCreated struct{ Token model.GitToken }
 type GitTokenDeleted struct{ TokenID string }
 
+// --- API Tokens ---
+
+type APITokensLoaded struct{ Tokens []model.APIToken }
+type APITokenCreated struct{ Token model.APIToken } // Token.Token is only set here
+type APITokenDeleted struct{ TokenID string }
+
+type SessionsLoaded struct{ Sessions []model.Session }
+type SessionDeleted struct{ SessionID string }
+
+// --- Audit Log ---
+
+type AuditEventsLoaded struct{ Events []model.AuditEvent }
+
+// --- Registry Credentials ---
+
+type RegistryCredentialCreated struct{ Credential model.RegistryCredential }
+type RegistryCredentialDeleted struct{ CredentialID string }
+
 // --- Pod Domains ---
 
 type PodDomainsLoaded struct{ Domains []model.PodDomain }
 type PodDomainCreated struct{ Domain model.PodDomain }
 type PodDomainUpdated struct{ Domain model.PodDomain }
+type PodDomainVerified struct{ Domain model.PodDomain }
+type DNSRecordLoaded struct{ Record model.DNSRecord }
 type PodDomainDeleted struct {
 	DomainID string
 	PodID    string
 }
 
+// --- Pod Volumes ---
+
+type PodVolumesLoaded struct {
+	PodID   string
+	Volumes []model.PodVolume
+}
+type PodVolumeCreated struct{ Volume model.PodVolume }
+type PodVolumeUpdated struct{ Volume model.PodVolume }
+type PodVolumeDeleted struct {
+	VolumeID string
+	PodID    string
+}
+
+// --- Databases ---
+
+type DatabaseCreated struct{ Database model.Database }
+type DatabaseLoaded struct{ Database model.Database }
+type DatabaseDeleted struct {
+	DatabaseID string
+	ProjectID  string
+}
+
+// --- Pod Databases ---
+
+type PodDatabasesLoaded struct {
+	PodID string
+	Links []model.PodDatabase
+}
+type PodDatabaseLinked struct{ Link model.PodDatabase }
+type PodDatabaseUnlinked struct {
+	PodID      string
+	DatabaseID string
+}
+
+// --- Backups ---
+
+type BackupsLoaded struct {
+	TargetID string
+	Backups  model.Backups
+}
+type BackupStarted struct{ Backup model.Backup }
+type BackupScheduleSaved struct{ Schedule model.BackupSchedule }
+type BackupRestored struct{ BackupID string }
+type BackupDeleted struct{ BackupID string }
+
 // --- Pod Env Vars ---
 
 type PodEnvVarsLoaded struct{ EnvVars []model.PodEnvVar }
@@ -105,6 +225,10 @@ type ServerDomainLoaded struct{ Domain string }
 type ServerDomainSet struct{}
 type ServerDomainDeleted struct{}
 
+type DNSSettingsLoaded struct{ Settings model.DNSSettings }
+type DNSSettingsSaved struct{ Settings model.DNSSettings }
+type DNSSettingsDeleted struct{}
+
 // --- Errors ---
 
 type Error struct{ Err error }
@@ -47,3 +47,22 @@ func PodsToItems(pods []model.Pod) []ScrollItem {
 	}
 	return items
 }
+
+// DatabaseItem wraps a Database for use in ScrollList
+type DatabaseItem struct {
+	model.Database
+}
+
+func (i DatabaseItem) Title() string       { return i.Database.Title }
+func (i DatabaseItem) Suffix() string      { return i.Database.Engine + " " + i.Database.Status }
+func (i DatabaseItem) FilterValue() string { return i.Database.Title }
+
+// ResourcesToItems converts the pods and databases of a project to ScrollItems,
+// pods first
+func ResourcesToItems(pods []model.Pod, databases []model.Database) []ScrollItem {
+	items := PodsToItems(pods)
+	for _, d := range databases {
+		items = append(items, DatabaseItem{Database: d})
+	}
+	return items
+}
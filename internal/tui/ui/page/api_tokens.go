@@ -0,0 +1,149 @@
+package page
+
+import (
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// apiTokenItem wraps model.APIToken to implement ScrollItem interface
+type apiTokenItem struct {
+	token model.APIToken
+}
+
+func (i apiTokenItem) Title() string       { return i.token.Name }
+func (i apiTokenItem) FilterValue() string { return i.token.Name }
+
+func (i apiTokenItem) Suffix() string {
+	if i.token.ExpiresAt != nil && time.Now().After(*i.token.ExpiresAt) {
+		return i.token.Scope + " · expired"
+	}
+	if i.token.LastUsedAt == nil {
+		return i.token.Scope + " · never used"
+	}
+	return i.token.Scope + " · used " + i.token.LastUsedAt.Local().Format("2006-01-02 15:04")
+}
+
+var apiTokensCard = styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+// apiTokens lists the personal access tokens of the user.
+type apiTokens struct {
+	list      components.ScrollList
+	token     string // shown once after a token was created
+	loading   bool
+	keyAdd    key.Binding
+	keyDelete key.Binding
+	keyBack   key.Binding
+	width     int
+	height    int
+}
+
+func (m apiTokens) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyAdd, m.keyDelete, m.keyBack}
+}
+
+func NewAPITokens(token string) apiTokens {
+	return apiTokens{
+		list:      components.NewScrollList(nil, components.ScrollListConfig{Width: apiTokensCard.InnerWidth(), Height: 10}),
+		token:     token,
+		loading:   true,
+		keyAdd:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new")),
+		keyDelete: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "revoke")),
+		keyBack:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m apiTokens) Init() tea.Cmd {
+	return api.FetchAPITokens()
+}
+
+func (m apiTokens) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.APITokensLoaded:
+		m.loading = false
+		items := make([]components.ScrollItem, len(tmsg.Tokens))
+		for i, t := range tmsg.Tokens {
+			items[i] = apiTokenItem{token: t}
+		}
+		m.list.SetItems(items)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, m.keyBack):
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewDashboard(s) }}
+			}
+
+		case key.Matches(tmsg, m.keyAdd):
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewAPITokenForm() }}
+			}
+
+		case key.Matches(tmsg, m.keyDelete):
+			item, ok := m.list.SelectedItem().(apiTokenItem)
+			if !ok {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewAPITokenDelete(item.token) }}
+			}
+		}
+
+		// Let ScrollList handle navigation (up/down/j/k/mouse)
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m apiTokens) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("API Tokens"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Send as \"Authorization: Bearer <token>\""))
+	b.WriteString("\n\n")
+
+	if m.token != "" {
+		labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+		b.WriteString(labelStyle.Render("New token (shown once, copy it now)"))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Width(apiTokensCard.InnerWidth()).Render(m.token))
+		b.WriteString("\n\n")
+	}
+
+	switch {
+	case m.loading:
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	case len(m.list.Items()) == 0:
+		b.WriteString(styles.MutedStyle().Render("No tokens yet. Press 'n' to create one."))
+	default:
+		b.WriteString(m.list.View())
+	}
+
+	card := styles.Card(apiTokensCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m apiTokens) Breadcrumbs() []string {
+	return []string{"Settings", "API Tokens"}
+}
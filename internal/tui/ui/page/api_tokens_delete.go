@@ -0,0 +1,95 @@
+package page
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// apiTokenDelete confirms revoking an API token.
+type apiTokenDelete struct {
+	token      model.APIToken
+	keyConfirm key.Binding
+	keyCancel  key.Binding
+	width      int
+	height     int
+}
+
+func (p apiTokenDelete) HelpKeys() []key.Binding {
+	return []key.Binding{p.keyConfirm, p.keyCancel}
+}
+
+func NewAPITokenDelete(token model.APIToken) apiTokenDelete {
+	return apiTokenDelete{
+		token:      token,
+		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (p apiTokenDelete) Init() tea.Cmd {
+	return nil
+}
+
+func (p apiTokenDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	back := func() tea.Msg {
+		return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewAPITokens("") }}
+	}
+
+	switch tmsg := tmsg.(type) {
+	case msg.APITokenDeleted:
+		return p, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Token revoked", Type: msg.StatusSuccess} },
+			back,
+		)
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, p.keyCancel):
+			return p, back
+
+		case key.Matches(tmsg, p.keyConfirm):
+			return p, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Revoking token"} },
+				api.DeleteAPIToken(p.token.ID),
+			)
+		}
+
+	case tea.WindowSizeMsg:
+		p.width = tmsg.Width
+		p.height = tmsg.Height
+	}
+
+	return p, nil
+}
+
+func (p apiTokenDelete) View() tea.View {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Render("Revoke " + p.token.Name)
+
+	body := styles.MutedStyle().
+		PaddingTop(1).
+		Render("Scripts using " + p.token.Prefix + "... stop working immediately.")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, body)
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthSM,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(content)
+
+	centered := lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (p apiTokenDelete) Breadcrumbs() []string {
+	return []string{"Settings", "API Tokens", "Revoke"}
+}
@@ -0,0 +1,221 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldAPITokenName = iota
+	fieldAPITokenScope
+	fieldAPITokenExpiry
+)
+
+const numAPITokenFields = 3
+
+var (
+	apiTokenScopes = []string{model.APITokenScopeRead, model.APITokenScopeDeploy, model.APITokenScopeAdmin}
+	// apiTokenExpiries are the expiry options, with their lifetime in days (0 = never)
+	apiTokenExpiries   = []string{"30 days", "90 days", "1 year", "never"}
+	apiTokenExpiryDays = map[string]int{"30 days": 30, "90 days": 90, "1 year": 365, "never": 0}
+	apiTokenScopeHelp  = map[string]string{
+		model.APITokenScopeRead:   "Read projects, pods and logs",
+		model.APITokenScopeDeploy: "Read, and deploy, stop, restart or roll back pods",
+		model.APITokenScopeAdmin:  "Everything you can do yourself",
+	}
+)
+
+type apiTokenForm struct {
+	nameInput    textinput.Model
+	scope        string
+	expiry       string
+	focusedField int
+	keySave      key.Binding
+	keyCancel    key.Binding
+	keyTab       key.Binding
+	keyShiftTab  key.Binding
+	keyToggle    key.Binding
+	width        int
+	height       int
+}
+
+func (m apiTokenForm) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyToggle, m.keyCancel}
+}
+
+func NewAPITokenForm() apiTokenForm {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+	nameInput := components.NewTextInput(card.InnerWidth())
+	nameInput.Placeholder = "e.g. GitHub Actions"
+	nameInput.CharLimit = 50
+	nameInput.Focus()
+
+	return apiTokenForm{
+		nameInput:   nameInput,
+		scope:       model.APITokenScopeDeploy,
+		expiry:      apiTokenExpiries[1],
+		keySave:     key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "create")),
+		keyCancel:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+		keyToggle:   key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "change")),
+	}
+}
+
+func (m apiTokenForm) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m apiTokenForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.APITokenCreated:
+		token := tmsg.Token.Token
+		return m, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Token created", Type: msg.StatusSuccess} },
+			func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewAPITokens(token) }}
+			},
+		)
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Blink passthrough
+	var cmd tea.Cmd
+	if m.focusedField == fieldAPITokenName {
+		m.nameInput, cmd = m.nameInput.Update(tmsg)
+	}
+	return m, cmd
+}
+
+func (m *apiTokenForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyCancel):
+		return m, func() tea.Msg {
+			return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewAPITokens("") }}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		name := strings.TrimSpace(m.nameInput.Value())
+		if name == "" {
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Creating token"} },
+			api.CreateAPIToken(model.APITokenCreate{
+				Name:          name,
+				Scope:         m.scope,
+				ExpiresInDays: apiTokenExpiryDays[m.expiry],
+			}),
+		)
+
+	case key.Matches(tmsg, m.keyTab):
+		return m, m.focus((m.focusedField + 1) % numAPITokenFields)
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		return m, m.focus((m.focusedField + numAPITokenFields - 1) % numAPITokenFields)
+
+	case m.focusedField == fieldAPITokenScope && key.Matches(tmsg, m.keyToggle):
+		m.scope = nextOption(apiTokenScopes, m.scope, tmsg.String() == "left")
+		return m, nil
+
+	case m.focusedField == fieldAPITokenExpiry && key.Matches(tmsg, m.keyToggle):
+		m.expiry = nextOption(apiTokenExpiries, m.expiry, tmsg.String() == "left")
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if m.focusedField == fieldAPITokenName {
+		m.nameInput, cmd = m.nameInput.Update(tmsg)
+	}
+	return m, cmd
+}
+
+// focus moves the focus to field, only the name field takes text input.
+func (m *apiTokenForm) focus(field int) tea.Cmd {
+	m.focusedField = field
+	if field == fieldAPITokenName {
+		return m.nameInput.Focus()
+	}
+	m.nameInput.Blur()
+	return nil
+}
+
+// nextOption returns the option after (or before, with back) selected.
+func nextOption(options []string, selected string, back bool) string {
+	for i, option := range options {
+		if option == selected {
+			if back {
+				return options[(i+len(options)-1)%len(options)]
+			}
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+func (m apiTokenForm) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("New API Token"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("For scripts and CI, acting as you"))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+	label := func(field int, text string) string {
+		if m.focusedField == field {
+			return activeLabel.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	b.WriteString(label(fieldAPITokenName, "Name"))
+	b.WriteString("\n")
+	b.WriteString(m.nameInput.View())
+	b.WriteString("\n\n")
+
+	b.WriteString(label(fieldAPITokenScope, "Scope"))
+	b.WriteString("\n")
+	b.WriteString(renderOptions(apiTokenScopes, m.scope, labelStyle, activeLabel))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render(apiTokenScopeHelp[m.scope]))
+	b.WriteString("\n\n")
+
+	b.WriteString(label(fieldAPITokenExpiry, "Expires"))
+	b.WriteString("\n")
+	b.WriteString(renderOptions(apiTokenExpiries, m.expiry, labelStyle, activeLabel))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m apiTokenForm) Breadcrumbs() []string {
+	return []string{"Settings", "API Tokens", "New"}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
 	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
 	"github.com/deeploy-sh/deeploy/internal/tui/ui/theme"
+	"github.com/deeploy-sh/deeploy/internal/tui/utils"
 )
 
 const headerHeight = 1
@@ -35,8 +36,10 @@ type app struct {
 	projects         []model.Project
 	pods             []model.Pod
 	gitTokens        []model.GitToken
+	registryCreds    []model.RegistryCredential
 	podDomains       []model.PodDomain
 	podEnvVars       []model.PodEnvVar
+	databases        []model.Database
 	width            int
 	height           int
 	heartbeatStarted bool
@@ -66,6 +69,10 @@ func (m *app) GitTokens() []model.GitToken {
 	return m.gitTokens
 }
 
+func (m *app) RegistryCredentials() []model.RegistryCredential {
+	return m.registryCreds
+}
+
 func (m *app) PodDomains(podID string) []model.PodDomain {
 	var result []model.PodDomain
 	for _, d := range m.podDomains {
@@ -86,6 +93,10 @@ func (m *app) PodEnvVars(podID string) []model.PodEnvVar {
 	return result
 }
 
+func (m *app) Databases() []model.Database {
+	return m.databases
+}
+
 func (m *app) clearStatusAfter(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return msg.ClearStatus{}
@@ -265,8 +276,10 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 		m.projects = tmsg.Projects
 		m.pods = tmsg.Pods
 		m.gitTokens = tmsg.GitTokens
+		m.registryCreds = tmsg.RegistryCredentials
 		m.podDomains = tmsg.PodDomains
 		m.podEnvVars = tmsg.PodEnvVars
+		m.databases = tmsg.Databases
 
 		// Forward to current page so it can update its list
 		var cmd tea.Cmd
@@ -417,8 +430,85 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			},
 		)
 
-	// --- Pod Deploy/Stop/Restart (no store update, just clear loading) ---
-	case msg.PodDeployed, msg.PodStopped, msg.PodRestarted:
+	// --- Database CRUD ---
+	case msg.DatabaseCreated:
+		m.databases = append(m.databases, tmsg.Database)
+		m.isLoading = false
+		databaseID := tmsg.Database.ID
+		return m, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Database created", Type: msg.StatusSuccess} },
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewDatabaseDetail(s, databaseID) },
+				}
+			},
+		)
+
+	case msg.DatabaseDeleted:
+		m.databases = slices.DeleteFunc(m.databases, func(d model.Database) bool { return d.ID == tmsg.DatabaseID })
+		m.isLoading = false
+		projectID := tmsg.ProjectID
+		return m, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Database deleted", Type: msg.StatusSuccess} },
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewProjectDetail(s, projectID) },
+				}
+			},
+		)
+
+	// --- Pod Databases ---
+	case msg.PodDatabaseLinked:
+		m.isLoading = false
+		podID := tmsg.Link.PodID
+		return m, tea.Batch(
+			func() tea.Msg {
+				return msg.ShowStatus{Text: "Database linked. Redeploy to apply.", Type: msg.StatusSuccess}
+			},
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
+				}
+			},
+		)
+
+	case msg.PodDatabaseUnlinked:
+		m.isLoading = false
+		podID := tmsg.PodID
+		return m, tea.Batch(
+			func() tea.Msg {
+				return msg.ShowStatus{Text: "Database unlinked. Redeploy to apply.", Type: msg.StatusSuccess}
+			},
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
+				}
+			},
+		)
+
+	// --- Pod Deploy/Stop/Restart/Rollback (no store update, just clear loading) ---
+	case msg.PodDeployed, msg.PodStopped, msg.PodRestarted, msg.PodRolledBack:
+		m.isLoading = false
+		var cmd tea.Cmd
+		m.currentPage, cmd = m.currentPage.Update(tmsg)
+		return m, cmd
+
+	// --- Project Members (no store, the members page reloads itself) ---
+	case msg.MemberInvited, msg.MemberRoleUpdated, msg.MemberRemoved, msg.InviteRevoked:
+		m.isLoading = false
+		var cmd tea.Cmd
+		m.currentPage, cmd = m.currentPage.Update(tmsg)
+		return m, cmd
+
+	// --- API Tokens and Sessions (no store, their pages load them) ---
+	case msg.APITokenCreated, msg.APITokenDeleted, msg.SessionDeleted:
+		m.isLoading = false
+		var cmd tea.Cmd
+		m.currentPage, cmd = m.currentPage.Update(tmsg)
+		return m, cmd
+
+	// --- Backups (no store, the backups page reloads itself) ---
+	case msg.BackupStarted, msg.BackupScheduleSaved, msg.BackupRestored, msg.BackupDeleted:
 		m.isLoading = false
 		var cmd tea.Cmd
 		m.currentPage, cmd = m.currentPage.Update(tmsg)
@@ -449,6 +539,31 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			},
 		)
 
+	// --- Registry Credential CRUD ---
+	case msg.RegistryCredentialCreated:
+		m.registryCreds = append(m.registryCreds, tmsg.Credential)
+		m.isLoading = false
+		return m, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Credentials created", Type: msg.StatusSuccess} },
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewRegistryCredentials(s.RegistryCredentials()) },
+				}
+			},
+		)
+
+	case msg.RegistryCredentialDeleted:
+		m.registryCreds = slices.DeleteFunc(m.registryCreds, func(c model.RegistryCredential) bool { return c.ID == tmsg.CredentialID })
+		m.isLoading = false
+		return m, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Credentials deleted", Type: msg.StatusSuccess} },
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewRegistryCredentials(s.RegistryCredentials()) },
+				}
+			},
+		)
+
 	// --- Pod Domains (no store update, just navigate back to PodDetail) ---
 	case msg.PodDomainCreated:
 		m.podDomains = append(m.podDomains, tmsg.Domain)
@@ -456,6 +571,7 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 		podID := tmsg.Domain.PodID
 		return m, tea.Batch(
 			func() tea.Msg { return msg.ShowStatus{Text: "Domain created", Type: msg.StatusSuccess} },
+			checkDomain(tmsg.Domain),
 			func() tea.Msg {
 				return msg.ChangePage{
 					PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
@@ -474,6 +590,7 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 		podID := tmsg.Domain.PodID
 		return m, tea.Batch(
 			func() tea.Msg { return msg.ShowStatus{Text: "Domain updated", Type: msg.StatusSuccess} },
+			checkDomain(tmsg.Domain),
 			func() tea.Msg {
 				return msg.ChangePage{
 					PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
@@ -481,6 +598,23 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			},
 		)
 
+	// Re-checks stay on the domains page, it shows the result
+	case msg.PodDomainVerified:
+		for i, d := range m.podDomains {
+			if d.ID == tmsg.Domain.ID {
+				m.podDomains[i] = tmsg.Domain
+				break
+			}
+		}
+		m.isLoading = false
+		status := msg.ShowStatus{Text: tmsg.Domain.Domain + " verified, redeploy to route it", Type: msg.StatusSuccess}
+		if tmsg.Domain.Status != model.DomainStatusVerified {
+			status = msg.ShowStatus{Text: tmsg.Domain.StatusMessage, Type: msg.StatusError}
+		}
+		var cmd tea.Cmd
+		m.currentPage, cmd = m.currentPage.Update(tmsg)
+		return m, tea.Batch(cmd, func() tea.Msg { return status })
+
 	case msg.PodDomainDeleted:
 		m.podDomains = slices.DeleteFunc(m.podDomains, func(d model.PodDomain) bool {
 			return d.ID == tmsg.DomainID
@@ -496,6 +630,49 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			},
 		)
 
+	// --- Pod Volumes ---
+	case msg.PodVolumeCreated:
+		m.isLoading = false
+		podID := tmsg.Volume.PodID
+		return m, tea.Batch(
+			func() tea.Msg {
+				return msg.ShowStatus{Text: "Volume added. Redeploy to apply.", Type: msg.StatusSuccess}
+			},
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
+				}
+			},
+		)
+
+	case msg.PodVolumeUpdated:
+		m.isLoading = false
+		podID := tmsg.Volume.PodID
+		return m, tea.Batch(
+			func() tea.Msg {
+				return msg.ShowStatus{Text: "Volume updated. Redeploy to apply.", Type: msg.StatusSuccess}
+			},
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
+				}
+			},
+		)
+
+	case msg.PodVolumeDeleted:
+		m.isLoading = false
+		podID := tmsg.PodID
+		return m, tea.Batch(
+			func() tea.Msg {
+				return msg.ShowStatus{Text: "Volume removed. Redeploy to apply.", Type: msg.StatusSuccess}
+			},
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
+				}
+			},
+		)
+
 	// --- Pod Env Vars ---
 	case msg.PodEnvVarsUpdated:
 		// Remove old env vars for this pod, add new ones
@@ -547,6 +724,12 @@ func (m app) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 		m.currentPage, cmd = m.currentPage.Update(tmsg)
 		return m, cmd
 
+	case msg.DNSSettingsSaved, msg.DNSSettingsDeleted:
+		m.isLoading = false
+		var cmd tea.Cmd
+		m.currentPage, cmd = m.currentPage.Update(tmsg)
+		return m, cmd
+
 	default:
 		var cmds []tea.Cmd
 
@@ -575,6 +758,15 @@ type PageInfo interface {
 	Breadcrumbs() []string
 }
 
+// checkDomain runs the DNS check of a new or moved domain in the
+// background, the server leaves it pending as the lookup can take a while.
+func checkDomain(domain model.PodDomain) tea.Cmd {
+	if domain.Status != model.DomainStatusPending {
+		return nil
+	}
+	return api.VerifyPodDomain(domain.PodID, domain.ID)
+}
+
 func (m app) getPaletteItems() []components.PaletteItem {
 	items := []components.PaletteItem{
 		{
@@ -615,6 +807,60 @@ func (m app) getPaletteItems() []components.PaletteItem {
 				}
 			},
 		},
+		{
+			ItemTitle:   "API Tokens",
+			Description: "Manage tokens for scripts and CI",
+			Category:    "settings",
+			Action: func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewAPITokens("") },
+				}
+			},
+		},
+		{
+			ItemTitle:   "Sessions",
+			Description: "See where you are signed in and sign out devices",
+			Category:    "settings",
+			Action: func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewSessions() },
+				}
+			},
+		},
+		{
+			ItemTitle:   "Audit Log",
+			Description: "See who deployed, changed or deleted what",
+			Category:    "settings",
+			Action: func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewAudit() },
+				}
+			},
+		},
+		{
+			ItemTitle:   "Two-Factor Authentication",
+			Description: "Turn on login codes from an authenticator app",
+			Category:    "settings",
+			Action: func() tea.Msg {
+				// Enrolling shows a QR code, the browser renders it
+				cfg, err := config.Load()
+				if err != nil {
+					return msg.Error{Err: err}
+				}
+				utils.OpenBrowser(cfg.Server + "/account/two-factor")
+				return msg.ShowStatus{Text: "Opened two-factor settings in the browser", Type: msg.StatusInfo}
+			},
+		},
+		{
+			ItemTitle:   "Registry Credentials",
+			Description: "Manage logins for private container registries",
+			Category:    "settings",
+			Action: func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewRegistryCredentials(s.RegistryCredentials()) },
+				}
+			},
+		},
 		{
 			ItemTitle:   "About / Updates",
 			Description: "Version info and updates",
@@ -637,6 +883,16 @@ func (m app) getPaletteItems() []components.PaletteItem {
 				}
 			},
 		},
+		{
+			ItemTitle:   "DNS Provider",
+			Description: "Get certificates via DNS, for wildcards and firewalled servers",
+			Category:    "settings",
+			Action: func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewServerDNS() },
+				}
+			},
+		},
 	}
 
 	for _, p := range m.projects {
@@ -0,0 +1,157 @@
+package page
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// auditItem wraps model.AuditEvent to implement ScrollItem interface
+type auditItem struct {
+	event model.AuditEvent
+}
+
+func (i auditItem) Title() string {
+	target := i.event.TargetName
+	if target == "" {
+		target = i.event.TargetType
+	}
+	return i.event.Action + " " + target
+}
+
+func (i auditItem) FilterValue() string {
+	return i.event.Action + " " + i.event.TargetName + " " + i.event.ActorEmail
+}
+
+func (i auditItem) Suffix() string {
+	return i.event.ActorEmail + " · " + i.event.CreatedAt.Local().Format("01-02 15:04")
+}
+
+var auditCard = styles.CardProps{Width: styles.CardWidthLG, Padding: []int{1, 2}, Accent: true}
+
+// audit lists who changed what, newest first.
+type audit struct {
+	list    components.ScrollList
+	loading bool
+	keyBack key.Binding
+	width   int
+	height  int
+}
+
+func (m audit) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyBack}
+}
+
+func NewAudit() audit {
+	return audit{
+		list: components.NewScrollList(nil, components.ScrollListConfig{
+			Width:       auditCard.InnerWidth(),
+			Height:      10,
+			WithInput:   true,
+			Placeholder: "Filter by action, target or user...",
+		}),
+		loading: true,
+		keyBack: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m audit) Init() tea.Cmd {
+	return api.FetchAuditEvents()
+}
+
+func (m audit) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.AuditEventsLoaded:
+		m.loading = false
+		items := make([]components.ScrollItem, len(tmsg.Events))
+		for i, e := range tmsg.Events {
+			items[i] = auditItem{event: e}
+		}
+		m.list.SetItems(items)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		if key.Matches(tmsg, m.keyBack) {
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewDashboard(s) }}
+			}
+		}
+
+		// Let ScrollList handle filtering and navigation
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+// changesView shows the changed fields of an event, one per line.
+func changesView(event model.AuditEvent) string {
+	if len(event.Changes) == 0 {
+		return styles.MutedStyle().Render("No changed fields")
+	}
+
+	fields := make([]string, 0, len(event.Changes))
+	for field := range event.Changes {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	value := func(v any) string {
+		if v == nil {
+			return "–"
+		}
+		return fmt.Sprint(v)
+	}
+
+	var lines []string
+	for _, field := range fields {
+		change := event.Changes[field]
+		lines = append(lines, styles.LabelStyle().Render(field+": ")+value(change.Before)+" → "+value(change.After))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m audit) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Audit Log"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Who changed what on this server"))
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else {
+		b.WriteString(m.list.View())
+		if item, ok := m.list.SelectedItem().(auditItem); ok {
+			b.WriteString("\n\n")
+			b.WriteString(changesView(item.event))
+		}
+	}
+
+	card := styles.Card(auditCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m audit) Breadcrumbs() []string {
+	return []string{"Settings", "Audit Log"}
+}
@@ -13,6 +13,7 @@ import (
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
 	"github.com/deeploy-sh/deeploy/internal/tui/config"
 	"github.com/deeploy-sh/deeploy/internal/tui/msg"
 	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
@@ -142,16 +143,15 @@ func (m auth) startBrowserAuth() tea.Cmd {
 			}
 
 			if resp.StatusCode == http.StatusOK {
-				var result struct {
-					Token string `json:"token"`
-				}
+				var result model.AuthTokens
 				json.NewDecoder(resp.Body).Decode(&result)
 				resp.Body.Close()
 
 				if result.Token != "" {
 					cfg := config.Config{
-						Server: m.serverURL,
-						Token:  result.Token,
+						Server:       m.serverURL,
+						Token:        result.Token,
+						RefreshToken: result.RefreshToken,
 					}
 					if err := config.Save(&cfg); err != nil {
 						return msg.AuthError{Err: err}
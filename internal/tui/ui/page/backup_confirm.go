@@ -0,0 +1,131 @@
+package page
+
+import (
+	"fmt"
+	"slices"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// backupConfirm confirms restoring or deleting a backup.
+type backupConfirm struct {
+	parent     backups
+	backup     model.Backup
+	restore    bool // false = delete
+	keyConfirm key.Binding
+	keyCancel  key.Binding
+	width      int
+	height     int
+}
+
+func (p backupConfirm) HelpKeys() []key.Binding {
+	return []key.Binding{p.keyConfirm, p.keyCancel}
+}
+
+func NewBackupConfirm(parent backups, backup model.Backup, restore bool) backupConfirm {
+	return backupConfirm{
+		parent:     parent,
+		backup:     backup,
+		restore:    restore,
+		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (p backupConfirm) Init() tea.Cmd {
+	return nil
+}
+
+func (p backupConfirm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.BackupRestored:
+		return p, p.done("Backup restored")
+
+	case msg.BackupDeleted:
+		return p, p.done("Backup deleted")
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, p.keyCancel):
+			return p, p.done("")
+
+		case key.Matches(tmsg, p.keyConfirm):
+			if p.restore {
+				return p, tea.Batch(
+					func() tea.Msg { return msg.StartLoading{Text: "Restoring backup"} },
+					api.RestoreBackup(p.backup.ID),
+				)
+			}
+			return p, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Deleting backup"} },
+				api.DeleteBackup(p.backup.ID),
+			)
+		}
+
+	case tea.WindowSizeMsg:
+		p.width = tmsg.Width
+		p.height = tmsg.Height
+	}
+
+	return p, nil
+}
+
+// done returns to the backups page, which reloads its list.
+func (p backupConfirm) done(status string) tea.Cmd {
+	parent := p.parent
+	back := func() tea.Msg {
+		return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return parent }}
+	}
+	if status == "" {
+		return back
+	}
+	return tea.Batch(
+		func() tea.Msg { return msg.ShowStatus{Text: status, Type: msg.StatusSuccess} },
+		back,
+	)
+}
+
+func (p backupConfirm) View() tea.View {
+	started := p.backup.StartedAt.Local().Format("2006-01-02 15:04")
+
+	action := "Delete Backup"
+	hint := "The archive is removed from " + p.backup.Destination + " storage."
+	if p.restore {
+		action = "Restore Backup"
+		hint = fmt.Sprintf("The current data of %s is replaced.\nContainers using a volume are stopped meanwhile.", p.parent.title)
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Render(fmt.Sprintf("%s (%s)", action, started))
+
+	body := styles.MutedStyle().
+		PaddingTop(1).
+		Render(hint)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, body)
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthSM,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(content)
+
+	centered := lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (p backupConfirm) Breadcrumbs() []string {
+	if p.restore {
+		return append(slices.Clone(p.parent.crumbs), "Restore")
+	}
+	return append(slices.Clone(p.parent.crumbs), "Delete")
+}
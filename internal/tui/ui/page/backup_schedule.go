@@ -0,0 +1,262 @@
+package page
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldBackupEnabled = iota
+	fieldBackupInterval
+	fieldBackupRetention
+	fieldBackupDestination
+)
+
+const numBackupFields = 4
+
+type backupSchedule struct {
+	parent         backups
+	enabled        bool
+	destination    string
+	intervalInput  textinput.Model
+	retentionInput textinput.Model
+	focusedField   int
+	keySave        key.Binding
+	keyBack        key.Binding
+	keyTab         key.Binding
+	keyShiftTab    key.Binding
+	keyToggle      key.Binding
+	width          int
+	height         int
+}
+
+func (m backupSchedule) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyToggle, m.keyBack}
+}
+
+func NewBackupSchedule(parent backups) backupSchedule {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	inputWidth := card.InnerWidth()
+
+	intervalInput := components.NewTextInput(inputWidth)
+	intervalInput.Placeholder = strconv.Itoa(model.DefaultBackupIntervalHours)
+	intervalInput.CharLimit = 3
+
+	retentionInput := components.NewTextInput(inputWidth)
+	retentionInput.Placeholder = strconv.Itoa(model.DefaultBackupRetention)
+	retentionInput.CharLimit = 3
+
+	enabled := true
+	destination := model.BackupDestinationLocal
+	if parent.schedule != nil {
+		enabled = parent.schedule.Enabled
+		destination = parent.schedule.Destination
+		intervalInput.SetValue(strconv.Itoa(parent.schedule.IntervalHours))
+		retentionInput.SetValue(strconv.Itoa(parent.schedule.Retention))
+	}
+
+	return backupSchedule{
+		parent:         parent,
+		enabled:        enabled,
+		destination:    destination,
+		intervalInput:  intervalInput,
+		retentionInput: retentionInput,
+		keySave:        key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyBack:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:         key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab:    key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+		keyToggle:      key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "toggle")),
+	}
+}
+
+func (m backupSchedule) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m backupSchedule) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.BackupScheduleSaved:
+		parent := m.parent
+		return m, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Backup schedule saved", Type: msg.StatusSuccess} },
+			func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return parent }}
+			},
+		)
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Update focused input for blink messages
+	return m, m.updateInput(tmsg)
+}
+
+func (m *backupSchedule) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		parent := m.parent
+		return m, func() tea.Msg {
+			return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return parent }}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		return m.save()
+
+	case key.Matches(tmsg, m.keyTab):
+		m.focusedField = (m.focusedField + 1) % numBackupFields
+		return m, m.updateFocus()
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		m.focusedField = (m.focusedField + numBackupFields - 1) % numBackupFields
+		return m, m.updateFocus()
+
+	case m.focusedField == fieldBackupEnabled && key.Matches(tmsg, m.keyToggle):
+		m.enabled = !m.enabled
+		return m, nil
+
+	case m.focusedField == fieldBackupDestination && key.Matches(tmsg, m.keyToggle):
+		if m.destination == model.BackupDestinationS3 {
+			m.destination = model.BackupDestinationLocal
+		} else {
+			m.destination = model.BackupDestinationS3
+		}
+		return m, nil
+	}
+
+	return m, m.updateInput(tmsg)
+}
+
+func (m *backupSchedule) updateInput(tmsg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.focusedField {
+	case fieldBackupInterval:
+		m.intervalInput, cmd = m.intervalInput.Update(tmsg)
+	case fieldBackupRetention:
+		m.retentionInput, cmd = m.retentionInput.Update(tmsg)
+	}
+	return cmd
+}
+
+func (m *backupSchedule) updateFocus() tea.Cmd {
+	m.intervalInput.Blur()
+	m.retentionInput.Blur()
+	switch m.focusedField {
+	case fieldBackupInterval:
+		return m.intervalInput.Focus()
+	case fieldBackupRetention:
+		return m.retentionInput.Focus()
+	}
+	return nil
+}
+
+// save sends the schedule. Empty fields are sent as zero, the server applies the defaults.
+func (m *backupSchedule) save() (tea.Model, tea.Cmd) {
+	schedule := model.BackupSchedule{
+		Enabled:     m.enabled,
+		Destination: m.destination,
+	}
+
+	numbers := map[*textinput.Model]*int{
+		&m.intervalInput:  &schedule.IntervalHours,
+		&m.retentionInput: &schedule.Retention,
+	}
+	for input, target := range numbers {
+		value := strings.TrimSpace(input.Value())
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return m, func() tea.Msg {
+				return msg.ShowStatus{Text: "Interval and retention must be numbers", Type: msg.StatusError}
+			}
+		}
+		*target = n
+	}
+
+	return m, tea.Batch(
+		func() tea.Msg { return msg.StartLoading{Text: "Saving backup schedule"} },
+		api.SaveBackupSchedule(m.parent.target, schedule),
+	)
+}
+
+func (m backupSchedule) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Backup Schedule"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Back up " + m.parent.title + " regularly"))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+	label := func(field int, text string) string {
+		if m.focusedField == field {
+			return activeLabel.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	// Enabled
+	b.WriteString(label(fieldBackupEnabled, "Status"))
+	b.WriteString("\n")
+	status := "paused"
+	if m.enabled {
+		status = "active"
+	}
+	b.WriteString(renderOptions([]string{"active", "paused"}, status, labelStyle, activeLabel))
+	b.WriteString("\n\n")
+
+	// Interval
+	b.WriteString(label(fieldBackupInterval, "Interval (hours)"))
+	b.WriteString("\n")
+	b.WriteString(m.intervalInput.View())
+	b.WriteString("\n\n")
+
+	// Retention
+	b.WriteString(label(fieldBackupRetention, "Keep (backups)"))
+	b.WriteString("\n")
+	b.WriteString(m.retentionInput.View())
+	b.WriteString("\n\n")
+
+	// Destination
+	b.WriteString(label(fieldBackupDestination, "Destination"))
+	b.WriteString("\n")
+	b.WriteString(renderOptions([]string{model.BackupDestinationLocal, model.BackupDestinationS3}, m.destination, labelStyle, activeLabel))
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.MutedStyle().Render("S3 needs BACKUP_S3_* in the server's .env.\nOlder backups beyond the limit are deleted."))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m backupSchedule) Breadcrumbs() []string {
+	return append(slices.Clone(m.parent.crumbs), "Schedule")
+}
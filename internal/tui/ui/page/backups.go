@@ -0,0 +1,264 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// backupsPollInterval is how often running backups are checked for completion.
+const backupsPollInterval = 2 * time.Second
+
+// backupItem wraps Backup to implement ScrollItem interface
+type backupItem struct {
+	backup model.Backup
+}
+
+func (b backupItem) Title() string {
+	title := b.backup.StartedAt.Local().Format("2006-01-02 15:04")
+	if b.backup.Status == model.BackupStatusSuccess {
+		title += "  " + formatSize(b.backup.Size)
+	}
+	return title
+}
+
+func (b backupItem) FilterValue() string { return b.Title() }
+
+func (b backupItem) Suffix() string {
+	return b.backup.Destination + " " + b.backup.Status
+}
+
+var backupsCard = styles.CardProps{Width: styles.CardWidthLG, Padding: []int{1, 2}, Accent: true}
+
+// backups lists the backups of a database or pod volume.
+type backups struct {
+	target      api.BackupTarget
+	title       string
+	crumbs      []string
+	back        func(s msg.Store) tea.Model
+	schedule    *model.BackupSchedule
+	list        components.ScrollList
+	loading     bool
+	keyRun      key.Binding
+	keySchedule key.Binding
+	keyRestore  key.Binding
+	keyDelete   key.Binding
+	keyRefresh  key.Binding
+	keyBack     key.Binding
+	width       int
+	height      int
+}
+
+func (m backups) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyRun, m.keySchedule, m.keyRestore, m.keyDelete, m.keyRefresh, m.keyBack}
+}
+
+func NewDatabaseBackups(database *model.Database, project *model.Project) backups {
+	databaseID := database.ID
+	return newBackups(
+		api.BackupTarget{DatabaseID: database.ID},
+		database.Title,
+		[]string{"Projects", project.Title, "Databases", database.Title, "Backups"},
+		func(s msg.Store) tea.Model { return NewDatabaseDetail(s, databaseID) },
+	)
+}
+
+func NewVolumeBackups(volume model.PodVolume, pod *model.Pod, project *model.Project) backups {
+	return newBackups(
+		api.BackupTarget{PodID: pod.ID, VolumeID: volume.ID},
+		volume.Source,
+		[]string{"Projects", project.Title, "Pods", pod.Title, "Volumes", volume.Source, "Backups"},
+		func(s msg.Store) tea.Model { return NewPodVolumes(pod, project) },
+	)
+}
+
+func newBackups(target api.BackupTarget, title string, crumbs []string, back func(s msg.Store) tea.Model) backups {
+	return backups{
+		target:      target,
+		title:       title,
+		crumbs:      crumbs,
+		back:        back,
+		list:        components.NewScrollList(nil, components.ScrollListConfig{Width: backupsCard.InnerWidth(), Height: 10}),
+		loading:     true,
+		keyRun:      key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "back up now")),
+		keySchedule: key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "schedule")),
+		keyRestore:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "restore")),
+		keyDelete:   key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+		keyRefresh:  key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh")),
+		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m backups) Init() tea.Cmd {
+	return api.FetchBackups(m.target)
+}
+
+func (m backups) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.BackupsLoaded:
+		if tmsg.TargetID != m.target.ID() {
+			return m, nil
+		}
+		m.loading = false
+		m.schedule = tmsg.Backups.Schedule
+		items := make([]components.ScrollItem, len(tmsg.Backups.Backups))
+		running := false
+		for i, b := range tmsg.Backups.Backups {
+			items[i] = backupItem{backup: b}
+			running = running || b.Status == model.BackupStatusRunning
+		}
+		m.list.SetItems(items)
+
+		// Poll until running backups are done
+		if running {
+			target := m.target
+			return m, tea.Tick(backupsPollInterval, func(time.Time) tea.Msg {
+				return api.FetchBackups(target)()
+			})
+		}
+		return m, nil
+
+	case msg.BackupStarted:
+		return m, tea.Batch(
+			api.FetchBackups(m.target),
+			func() tea.Msg { return msg.ShowStatus{Text: "Backup started", Type: msg.StatusSuccess} },
+		)
+
+	case msg.BackupDeleted:
+		return m, tea.Batch(
+			api.FetchBackups(m.target),
+			func() tea.Msg { return msg.ShowStatus{Text: "Backup deleted", Type: msg.StatusSuccess} },
+		)
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m backups) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		back := m.back
+		return m, func() tea.Msg {
+			return msg.ChangePage{PageFactory: back}
+		}
+
+	case key.Matches(tmsg, m.keyRefresh):
+		return m, api.FetchBackups(m.target)
+
+	case key.Matches(tmsg, m.keyRun):
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Starting backup"} },
+			api.RunBackup(m.target),
+		)
+
+	case key.Matches(tmsg, m.keySchedule):
+		parent := m
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model { return NewBackupSchedule(parent) },
+			}
+		}
+
+	case key.Matches(tmsg, m.keyRestore), key.Matches(tmsg, m.keyDelete):
+		item := m.list.SelectedItem()
+		if item == nil {
+			return m, nil
+		}
+		b := item.(backupItem).backup
+		restore := key.Matches(tmsg, m.keyRestore)
+		if restore && b.Status != model.BackupStatusSuccess {
+			return m, func() tea.Msg {
+				return msg.ShowStatus{Text: "Only successful backups can be restored", Type: msg.StatusError}
+			}
+		}
+		if b.Status == model.BackupStatusRunning {
+			return m, func() tea.Msg {
+				return msg.ShowStatus{Text: "Backup is still running", Type: msg.StatusError}
+			}
+		}
+		parent := m
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model { return NewBackupConfirm(parent, b, restore) },
+			}
+		}
+	}
+
+	// Let ScrollList handle navigation (up/down/j/k/mouse)
+	m.list, _ = m.list.Update(tmsg)
+	return m, nil
+}
+
+func (m backups) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+
+	b.WriteString(titleStyle.Render("Backups"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Compressed archives of " + m.title))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Schedule"))
+	b.WriteString("\n")
+	switch {
+	case m.schedule == nil:
+		b.WriteString(styles.MutedStyle().Render("None - press 's' to back up regularly"))
+	case !m.schedule.Enabled:
+		b.WriteString(styles.MutedStyle().Render("Paused"))
+	default:
+		b.WriteString(fmt.Sprintf("Every %dh to %s, keeps %d", m.schedule.IntervalHours, m.schedule.Destination, m.schedule.Retention))
+	}
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else if len(m.list.Items()) == 0 {
+		b.WriteString(styles.MutedStyle().Render("No backups yet. Press 'n' to create one."))
+	} else {
+		b.WriteString(m.list.View())
+	}
+
+	card := styles.Card(backupsCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m backups) Breadcrumbs() []string {
+	return m.crumbs
+}
+
+// formatSize formats a size in bytes, e.g. 1.5 MB.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
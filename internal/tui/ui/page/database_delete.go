@@ -0,0 +1,119 @@
+package page
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+type databaseDelete struct {
+	database     *model.Database
+	input        textinput.Model
+	removeVolume bool
+	keyConfirm   key.Binding
+	keyVolume    key.Binding
+	keyCancel    key.Binding
+	width        int
+	height       int
+}
+
+func (p databaseDelete) HelpKeys() []key.Binding {
+	return []key.Binding{p.keyConfirm, p.keyVolume, p.keyCancel}
+}
+
+func NewDatabaseDelete(database *model.Database) databaseDelete {
+	card := styles.CardProps{Width: styles.CardWidthSM, Padding: []int{1, 2}, Accent: true}
+	ti := components.NewTextInput(card.InnerWidth())
+	ti.Placeholder = database.Title
+	ti.Focus()
+	ti.CharLimit = 100
+
+	return databaseDelete{
+		database:   database,
+		input:      ti,
+		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyVolume:  key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "keep/remove data")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (p databaseDelete) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (p databaseDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		switch tmsg.Code {
+		case tea.KeyEscape:
+			projectID := p.database.ProjectID
+			return p, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectDetail(s, projectID) }}
+			}
+		case tea.KeyTab:
+			p.removeVolume = !p.removeVolume
+			return p, nil
+		case tea.KeyEnter:
+			// Only delete if input matches database title exactly
+			if p.input.Value() != p.database.Title {
+				return p, nil
+			}
+			return p, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Deleting database"} },
+				api.DeleteDatabase(p.database.ID, p.database.ProjectID, p.removeVolume),
+			)
+		}
+	case tea.WindowSizeMsg:
+		p.width = tmsg.Width
+		p.height = tmsg.Height
+		return p, nil
+	}
+
+	p.input, cmd = p.input.Update(tmsg)
+	return p, cmd
+}
+
+func (p databaseDelete) View() tea.View {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Render(fmt.Sprintf("Delete Database (%v)", p.database.Title))
+
+	hint := styles.MutedStyle().
+		PaddingTop(1).
+		PaddingBottom(1).
+		Render("Type '" + p.database.Title + "' to confirm")
+
+	// The data volume outlives the database unless removed explicitly
+	volume := "[ ] Remove data volume (keep data)"
+	if p.removeVolume {
+		volume = "[x] Remove data volume (data is lost)"
+	}
+	volumeHint := styles.MutedStyle().PaddingTop(1).Render(volume)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, hint, p.input.View(), volumeHint)
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthSM,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(content)
+
+	centered := lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (p databaseDelete) Breadcrumbs() []string {
+	return []string{"Projects", "Databases", "Delete"}
+}
@@ -0,0 +1,197 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+type databaseDetail struct {
+	database   *model.Database
+	project    *model.Project
+	showURL    bool
+	keyShowURL key.Binding
+	keyRefresh key.Binding
+	keyBackups key.Binding
+	keyDelete  key.Binding
+	keyBack    key.Binding
+	width      int
+	height     int
+}
+
+func (m databaseDetail) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyShowURL, m.keyRefresh, m.keyBackups, m.keyDelete, m.keyBack}
+}
+
+func NewDatabaseDetail(s msg.Store, databaseID string) databaseDetail {
+	database := &model.Database{ID: databaseID}
+	for _, d := range s.Databases() {
+		if d.ID == databaseID {
+			database = &d
+			break
+		}
+	}
+	project := &model.Project{}
+	for _, p := range s.Projects() {
+		if p.ID == database.ProjectID {
+			project = &p
+			break
+		}
+	}
+
+	return databaseDetail{
+		database:   database,
+		project:    project,
+		keyShowURL: key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "show/hide url")),
+		keyRefresh: key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh")),
+		keyBackups: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "backups")),
+		keyDelete:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+		keyBack:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m databaseDetail) Init() tea.Cmd {
+	return api.FetchDatabase(m.database.ID)
+}
+
+func (m databaseDetail) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.DatabaseLoaded:
+		if tmsg.Database.ID == m.database.ID {
+			m.database = &tmsg.Database
+		}
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, m.keyBack):
+			projectID := m.database.ProjectID
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewProjectDetail(s, projectID) },
+				}
+			}
+
+		case key.Matches(tmsg, m.keyShowURL):
+			m.showURL = !m.showURL
+			return m, nil
+
+		case key.Matches(tmsg, m.keyRefresh):
+			// Status changes once provisioning is done - keep the store in sync too
+			return m, tea.Batch(api.FetchDatabase(m.database.ID), api.LoadData())
+
+		case key.Matches(tmsg, m.keyBackups):
+			database := m.database
+			project := m.project
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewDatabaseBackups(database, project) },
+				}
+			}
+
+		case key.Matches(tmsg, m.keyDelete):
+			database := m.database
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewDatabaseDelete(database) },
+				}
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m databaseDetail) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+
+	b.WriteString(titleStyle.Render(m.database.Title))
+	b.WriteString(" ")
+	b.WriteString(m.renderStatus())
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Engine"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s", m.database.Engine, m.database.Version))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Host"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s:%d", m.database.Host, m.database.Port))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Only reachable by pods on this server"))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("User"))
+	b.WriteString("\n")
+	b.WriteString(m.database.Username)
+	if m.database.Engine != model.DatabaseEngineRedis {
+		b.WriteString("\n\n")
+		b.WriteString(labelStyle.Render("Database"))
+		b.WriteString("\n")
+		b.WriteString(m.database.DatabaseName)
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Connection URL"))
+	b.WriteString("\n")
+	switch {
+	case m.database.URL == "":
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	case m.showURL:
+		b.WriteString(m.database.URL)
+	default:
+		b.WriteString(styles.MutedStyle().Render("(hidden - press 'u' to show)"))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(styles.MutedStyle().Render("Link it to a pod with 'b' in the pod view to get the URL as env var."))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthLG,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+// renderStatus prefers the live container state once the database is provisioned.
+func (m databaseDetail) renderStatus() string {
+	status := m.database.ContainerState
+	if status == "" {
+		status = m.database.Status
+	}
+	style := lipgloss.NewStyle()
+	switch status {
+	case "running":
+		style = style.Foreground(lipgloss.Color("10"))
+	case "failed", "exited", "dead":
+		style = style.Foreground(lipgloss.Color("9"))
+	case "provisioning", "restarting":
+		style = style.Foreground(lipgloss.Color("11"))
+	default:
+		style = style.Foreground(lipgloss.Color("8"))
+	}
+	return style.Render("[" + status + "]")
+}
+
+func (m databaseDetail) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Databases", m.database.Title}
+}
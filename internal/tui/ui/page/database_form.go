@@ -0,0 +1,261 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldDatabaseTitle = iota
+	fieldDatabaseEngine
+	fieldDatabaseVersion
+	fieldDatabaseName
+)
+
+var databaseEngines = []string{model.DatabaseEnginePostgres, model.DatabaseEngineMySQL, model.DatabaseEngineRedis}
+
+// databaseVersions are the default versions of the engines, shown as placeholder.
+var databaseVersions = map[string]string{
+	model.DatabaseEnginePostgres: "17",
+	model.DatabaseEngineMySQL:    "8.4",
+	model.DatabaseEngineRedis:    "7",
+}
+
+type databaseForm struct {
+	projectID    string
+	engine       string
+	titleInput   textinput.Model
+	versionInput textinput.Model
+	nameInput    textinput.Model
+	focusedField int
+	keySave      key.Binding
+	keyBack      key.Binding
+	keyTab       key.Binding
+	keyShiftTab  key.Binding
+	keyToggle    key.Binding
+	width        int
+	height       int
+}
+
+func (m databaseForm) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyToggle, m.keyBack}
+}
+
+func NewDatabaseForm(projectID string) databaseForm {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	inputWidth := card.InnerWidth()
+
+	titleInput := components.NewTextInput(inputWidth)
+	titleInput.Placeholder = "Title"
+	titleInput.CharLimit = 100
+	titleInput.Focus()
+
+	versionInput := components.NewTextInput(inputWidth)
+	versionInput.CharLimit = 128
+
+	nameInput := components.NewTextInput(inputWidth)
+	nameInput.Placeholder = "app"
+	nameInput.CharLimit = 63
+
+	m := databaseForm{
+		projectID:    projectID,
+		engine:       model.DatabaseEnginePostgres,
+		titleInput:   titleInput,
+		versionInput: versionInput,
+		nameInput:    nameInput,
+		keySave:      key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyBack:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:       key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab:  key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+		keyToggle:    key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "toggle")),
+	}
+	m.versionInput.Placeholder = databaseVersions[m.engine]
+	return m
+}
+
+// fields returns the focusable fields in tab order.
+// Redis has no named databases.
+func (m databaseForm) fields() []int {
+	if m.engine == model.DatabaseEngineRedis {
+		return []int{fieldDatabaseTitle, fieldDatabaseEngine, fieldDatabaseVersion}
+	}
+	return []int{fieldDatabaseTitle, fieldDatabaseEngine, fieldDatabaseVersion, fieldDatabaseName}
+}
+
+func (m databaseForm) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m databaseForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Blink passthrough
+	return m, m.updateInput(tmsg)
+}
+
+func (m *databaseForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		projectID := m.projectID
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model { return NewProjectDetail(s, projectID) },
+			}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		return m.save()
+
+	case key.Matches(tmsg, m.keyTab):
+		return m, m.moveFocus(1)
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		return m, m.moveFocus(-1)
+
+	case m.focusedField == fieldDatabaseEngine && key.Matches(tmsg, m.keyToggle):
+		step := 1
+		if tmsg.String() == "left" {
+			step = len(databaseEngines) - 1
+		}
+		for i, engine := range databaseEngines {
+			if engine == m.engine {
+				m.engine = databaseEngines[(i+step)%len(databaseEngines)]
+				break
+			}
+		}
+		m.versionInput.Placeholder = databaseVersions[m.engine]
+		return m, nil
+	}
+
+	return m, m.updateInput(tmsg)
+}
+
+func (m *databaseForm) updateInput(tmsg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.focusedField {
+	case fieldDatabaseTitle:
+		m.titleInput, cmd = m.titleInput.Update(tmsg)
+	case fieldDatabaseVersion:
+		m.versionInput, cmd = m.versionInput.Update(tmsg)
+	case fieldDatabaseName:
+		m.nameInput, cmd = m.nameInput.Update(tmsg)
+	}
+	return cmd
+}
+
+func (m *databaseForm) moveFocus(delta int) tea.Cmd {
+	fields := m.fields()
+	current := 0
+	for i, f := range fields {
+		if f == m.focusedField {
+			current = i
+			break
+		}
+	}
+	m.focusedField = fields[(current+delta+len(fields))%len(fields)]
+
+	m.titleInput.Blur()
+	m.versionInput.Blur()
+	m.nameInput.Blur()
+	switch m.focusedField {
+	case fieldDatabaseTitle:
+		return m.titleInput.Focus()
+	case fieldDatabaseVersion:
+		return m.versionInput.Focus()
+	case fieldDatabaseName:
+		return m.nameInput.Focus()
+	}
+	return nil
+}
+
+func (m *databaseForm) save() (tea.Model, tea.Cmd) {
+	database := &model.Database{
+		ProjectID: m.projectID,
+		Title:     strings.TrimSpace(m.titleInput.Value()),
+		Engine:    m.engine,
+		Version:   strings.TrimSpace(m.versionInput.Value()),
+	}
+	if database.Title == "" {
+		return m, nil
+	}
+	if m.engine != model.DatabaseEngineRedis {
+		database.DatabaseName = strings.TrimSpace(m.nameInput.Value())
+	}
+
+	return m, tea.Batch(
+		func() tea.Msg { return msg.StartLoading{Text: "Creating database"} },
+		api.CreateDatabase(database),
+	)
+}
+
+func (m databaseForm) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("New Database"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Runs the official image with generated credentials"))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+	label := func(field int, text string) string {
+		if m.focusedField == field {
+			return activeLabel.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	b.WriteString(label(fieldDatabaseTitle, "Title"))
+	b.WriteString("\n")
+	b.WriteString(m.titleInput.View())
+	b.WriteString("\n\n")
+
+	b.WriteString(label(fieldDatabaseEngine, "Engine"))
+	b.WriteString("\n")
+	b.WriteString(renderOptions(databaseEngines, m.engine, labelStyle, activeLabel))
+	b.WriteString("\n\n")
+
+	b.WriteString(label(fieldDatabaseVersion, "Version"))
+	b.WriteString("\n")
+	b.WriteString(m.versionInput.View())
+
+	if m.engine != model.DatabaseEngineRedis {
+		b.WriteString("\n\n")
+		b.WriteString(label(fieldDatabaseName, "Database Name"))
+		b.WriteString("\n")
+		b.WriteString(m.nameInput.View())
+	}
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m databaseForm) Breadcrumbs() []string {
+	return []string{"Projects", "Databases", "New"}
+}
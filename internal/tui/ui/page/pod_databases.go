@@ -0,0 +1,185 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// linkItem wraps a PodDatabase to implement ScrollItem interface
+type linkItem struct {
+	link     model.PodDatabase
+	database model.Database
+}
+
+func (l linkItem) Title() string       { return l.link.EnvVar }
+func (l linkItem) FilterValue() string { return l.link.EnvVar }
+func (l linkItem) Suffix() string      { return l.database.Title + " " + l.database.Engine }
+
+var podDatabasesCard = styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+type podDatabases struct {
+	pod       *model.Pod
+	project   *model.Project
+	databases []model.Database // databases of the project
+	links     components.ScrollList
+	loading   bool
+	keyLink   key.Binding
+	keyUnlink key.Binding
+	keyBack   key.Binding
+	width     int
+	height    int
+}
+
+func (m podDatabases) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyLink, m.keyUnlink, m.keyBack}
+}
+
+func NewPodDatabases(s msg.Store, pod *model.Pod, project *model.Project) podDatabases {
+	var databases []model.Database
+	for _, d := range s.Databases() {
+		if d.ProjectID == pod.ProjectID {
+			databases = append(databases, d)
+		}
+	}
+
+	return podDatabases{
+		pod:       pod,
+		project:   project,
+		databases: databases,
+		links:     components.NewScrollList(nil, components.ScrollListConfig{Width: podDatabasesCard.InnerWidth(), Height: 8}),
+		loading:   true,
+		keyLink:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "link")),
+		keyUnlink: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "unlink")),
+		keyBack:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m podDatabases) Init() tea.Cmd {
+	return api.FetchPodDatabases(m.pod.ID)
+}
+
+func (m podDatabases) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.PodDatabasesLoaded:
+		if tmsg.PodID != m.pod.ID {
+			return m, nil
+		}
+		m.loading = false
+		items := make([]components.ScrollItem, 0, len(tmsg.Links))
+		for _, l := range tmsg.Links {
+			item := linkItem{link: l}
+			for _, d := range m.databases {
+				if d.ID == l.DatabaseID {
+					item.database = d
+					break
+				}
+			}
+			items = append(items, item)
+		}
+		m.links.SetItems(items)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.links, _ = m.links.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m podDatabases) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		podID := m.pod.ID
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDetail(s, podID)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keyLink):
+		// Only offer databases that are not linked yet
+		linked := make(map[string]bool)
+		for _, item := range m.links.Items() {
+			linked[item.(linkItem).link.DatabaseID] = true
+		}
+		var available []model.Database
+		for _, d := range m.databases {
+			if !linked[d.ID] {
+				available = append(available, d)
+			}
+		}
+		if len(available) == 0 {
+			return m, func() tea.Msg {
+				return msg.ShowStatus{Text: "No databases to link - create one in the project view", Type: msg.StatusError}
+			}
+		}
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDatabasesForm(pod, project, available)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keyUnlink):
+		if item := m.links.SelectedItem(); item != nil {
+			link := item.(linkItem).link
+			return m, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Unlinking database"} },
+				api.UnlinkPodDatabase(link.PodID, link.DatabaseID),
+			)
+		}
+	}
+
+	// Let ScrollList handle navigation (up/down/j/k/mouse)
+	m.links, _ = m.links.Update(tmsg)
+	return m, nil
+}
+
+func (m podDatabases) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Databases"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Connection URLs passed to " + m.pod.Title))
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else if len(m.links.Items()) == 0 {
+		b.WriteString(styles.MutedStyle().Render("No databases linked."))
+		b.WriteString("\n\n")
+		b.WriteString(styles.MutedStyle().Render("Press 'n' to link a database of this project."))
+	} else {
+		b.WriteString(m.links.View())
+	}
+
+	card := styles.Card(podDatabasesCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podDatabases) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Databases"}
+}
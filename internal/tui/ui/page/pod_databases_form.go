@@ -0,0 +1,157 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+type podDatabasesForm struct {
+	pod          *model.Pod
+	project      *model.Project
+	databases    components.ScrollList
+	envVarInput  textinput.Model
+	focusedInput bool // false = database list
+	keySave      key.Binding
+	keyBack      key.Binding
+	keyTab       key.Binding
+	width        int
+	height       int
+}
+
+func (m podDatabasesForm) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyBack}
+}
+
+func NewPodDatabasesForm(pod *model.Pod, project *model.Project, databases []model.Database) podDatabasesForm {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+	items := make([]components.ScrollItem, len(databases))
+	for i, d := range databases {
+		items[i] = components.DatabaseItem{Database: d}
+	}
+
+	envVarInput := components.NewTextInput(card.InnerWidth())
+	envVarInput.Placeholder = "DATABASE_URL (REDIS_URL for redis)"
+	envVarInput.CharLimit = 100
+
+	return podDatabasesForm{
+		pod:         pod,
+		project:     project,
+		databases:   components.NewScrollList(items, components.ScrollListConfig{Width: card.InnerWidth(), Height: 6}),
+		envVarInput: envVarInput,
+		keySave:     key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "link")),
+		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:      key.NewBinding(key.WithKeys("tab", "shift+tab"), key.WithHelp("tab", "switch field")),
+	}
+}
+
+func (m podDatabasesForm) Init() tea.Cmd {
+	return nil
+}
+
+func (m podDatabasesForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, m.keyBack):
+			pod := m.pod
+			project := m.project
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewPodDatabases(s, pod, project) },
+				}
+			}
+
+		case key.Matches(tmsg, m.keySave):
+			item := m.databases.SelectedItem()
+			if item == nil {
+				return m, nil
+			}
+			database := item.(components.DatabaseItem).Database
+			return m, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Linking database"} },
+				api.LinkPodDatabase(m.pod.ID, database.ID, strings.TrimSpace(m.envVarInput.Value())),
+			)
+
+		case key.Matches(tmsg, m.keyTab):
+			m.focusedInput = !m.focusedInput
+			if m.focusedInput {
+				return m, m.envVarInput.Focus()
+			}
+			m.envVarInput.Blur()
+			return m, nil
+		}
+
+		if !m.focusedInput {
+			m.databases, _ = m.databases.Update(tmsg)
+			return m, nil
+		}
+
+	case tea.MouseWheelMsg:
+		m.databases, _ = m.databases.Update(tmsg)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.envVarInput, cmd = m.envVarInput.Update(tmsg)
+	return m, cmd
+}
+
+func (m podDatabasesForm) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Link Database"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Applied on the next deploy or restart"))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+
+	if m.focusedInput {
+		b.WriteString(labelStyle.Render("Database"))
+	} else {
+		b.WriteString(activeLabel.Render("Database"))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.databases.View())
+	b.WriteString("\n\n")
+
+	if m.focusedInput {
+		b.WriteString(activeLabel.Render("Env Var"))
+	} else {
+		b.WriteString(labelStyle.Render("Env Var"))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.envVarInput.View())
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podDatabasesForm) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Databases", "Link"}
+}
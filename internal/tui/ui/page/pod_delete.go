@@ -21,16 +21,18 @@ type podToDelete struct {
 }
 
 type podDelete struct {
-	pod        podToDelete
-	input      textinput.Model
-	keyConfirm key.Binding
-	keyCancel  key.Binding
-	width      int
-	height     int
+	pod           podToDelete
+	input         textinput.Model
+	removeVolumes bool
+	keyConfirm    key.Binding
+	keyVolumes    key.Binding
+	keyCancel     key.Binding
+	width         int
+	height        int
 }
 
 func (p podDelete) HelpKeys() []key.Binding {
-	return []key.Binding{p.keyConfirm, p.keyCancel}
+	return []key.Binding{p.keyConfirm, p.keyVolumes, p.keyCancel}
 }
 
 func NewPodDelete(pod *model.Pod) podDelete {
@@ -44,6 +46,7 @@ func NewPodDelete(pod *model.Pod) podDelete {
 		pod:        podToDelete{ID: pod.ID, Title: pod.Title, ProjectID: pod.ProjectID},
 		input:      ti,
 		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyVolumes: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "keep/remove volumes")),
 		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
 	}
 }
@@ -63,6 +66,9 @@ func (p podDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			return p, func() tea.Msg {
 				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectDetail(s, projectID) }}
 			}
+		case tea.KeyTab:
+			p.removeVolumes = !p.removeVolumes
+			return p, nil
 		case tea.KeyEnter:
 			// Only delete if input matches pod title exactly
 			if p.input.Value() != p.pod.Title {
@@ -70,7 +76,7 @@ func (p podDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return p, tea.Batch(
 				func() tea.Msg { return msg.StartLoading{Text: "Deleting pod"} },
-				api.DeletePod(p.pod.ID, p.pod.ProjectID),
+				api.DeletePod(p.pod.ID, p.pod.ProjectID, p.removeVolumes),
 			)
 		}
 	case tea.WindowSizeMsg:
@@ -93,7 +99,14 @@ func (p podDelete) View() tea.View {
 		PaddingBottom(1).
 		Render("Type '" + p.pod.Title + "' to confirm")
 
-	content := lipgloss.JoinVertical(lipgloss.Left, title, hint, p.input.View())
+	// Named volumes outlive the pod unless removed explicitly, host paths are never touched
+	volumes := "[ ] Remove named volumes (keep data)"
+	if p.removeVolumes {
+		volumes = "[x] Remove named volumes (data is lost)"
+	}
+	volumesHint := styles.MutedStyle().PaddingTop(1).Render(volumes)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, hint, p.input.View(), volumesHint)
 
 	card := styles.Card(styles.CardProps{
 		Width:   styles.CardWidthSM,
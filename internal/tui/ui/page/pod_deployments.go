@@ -0,0 +1,201 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// deploymentItem wraps Deployment to implement ScrollItem interface
+type deploymentItem struct {
+	deployment model.Deployment
+	live       bool
+}
+
+func (d deploymentItem) Title() string {
+	commit := "-------"
+	if d.deployment.CommitSHA != nil && len(*d.deployment.CommitSHA) >= 7 {
+		commit = (*d.deployment.CommitSHA)[:7]
+	}
+	source := d.deployment.Branch
+	if d.deployment.CommitSHA == nil && d.deployment.Ref != nil {
+		// Image deployments have no commit, show the pulled image instead
+		source = *d.deployment.Ref
+	}
+	return fmt.Sprintf("%s %s  %s", commit, source, d.deployment.StartedAt.Local().Format("2006-01-02 15:04"))
+}
+
+func (d deploymentItem) FilterValue() string { return d.Title() }
+
+func (d deploymentItem) Suffix() string {
+	badges := d.deployment.Status
+	if d.deployment.RollbackOf != nil {
+		badges = "rollback " + badges
+	}
+	if d.deployment.NoCache {
+		badges = "no cache " + badges
+	}
+	if d.live {
+		badges += " live"
+	}
+	return badges
+}
+
+var podDeploymentsCard = styles.CardProps{Width: styles.CardWidthLG, Padding: []int{1, 2}, Accent: true}
+
+type podDeployments struct {
+	pod         *model.Pod
+	project     *model.Project
+	deployments components.ScrollList
+	loading     bool
+	keyRollback key.Binding
+	keyRefresh  key.Binding
+	keyBack     key.Binding
+	width       int
+	height      int
+}
+
+func (m podDeployments) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyRollback, m.keyRefresh, m.keyBack}
+}
+
+func NewPodDeployments(pod *model.Pod, project *model.Project) podDeployments {
+	return podDeployments{
+		pod:         pod,
+		project:     project,
+		deployments: components.NewScrollList(nil, components.ScrollListConfig{Width: podDeploymentsCard.InnerWidth(), Height: 10}),
+		loading:     true,
+		keyRollback: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "rollback")),
+		keyRefresh:  key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh")),
+		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m podDeployments) Init() tea.Cmd {
+	return api.FetchPodDeployments(m.pod.ID)
+}
+
+func (m podDeployments) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.PodDeploymentsLoaded:
+		if tmsg.PodID != m.pod.ID {
+			return m, nil
+		}
+		m.loading = false
+		m.deployments.SetItems(deploymentsToItems(tmsg.Deployments, m.pod))
+		return m, nil
+
+	case msg.PodRolledBack:
+		// The rollback runs in background like a deploy - follow its log
+		podID := m.pod.ID
+		return m, tea.Batch(
+			api.LoadData(),
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model {
+						return NewPodLogs(s, podID)
+					},
+				}
+			},
+		)
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.deployments, _ = m.deployments.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m podDeployments) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		podID := m.pod.ID
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDetail(s, podID)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keyRefresh):
+		return m, api.FetchPodDeployments(m.pod.ID)
+
+	case key.Matches(tmsg, m.keyRollback):
+		item := m.deployments.SelectedItem()
+		if item == nil {
+			return m, nil
+		}
+		d := item.(deploymentItem)
+		if d.deployment.Status != model.DeploymentStatusSuccess {
+			return m, func() tea.Msg {
+				return msg.ShowStatus{Text: "Only successful deployments can be rolled back to", Type: msg.StatusError}
+			}
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Rolling back"} },
+			api.RollbackPod(m.pod.ID, d.deployment.ID),
+		)
+	}
+
+	// Let ScrollList handle navigation (up/down/j/k/mouse)
+	m.deployments, _ = m.deployments.Update(tmsg)
+	return m, nil
+}
+
+func (m podDeployments) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Deployments"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Deployment history of " + m.pod.Title))
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else if len(m.deployments.Items()) == 0 {
+		b.WriteString(styles.MutedStyle().Render("No deployments yet."))
+	} else {
+		b.WriteString(m.deployments.View())
+	}
+
+	card := styles.Card(podDeploymentsCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podDeployments) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Deployments"}
+}
+
+// deploymentsToItems converts deployments to list items.
+// The newest successful deployment is marked live while the pod is running.
+func deploymentsToItems(deployments []model.Deployment, pod *model.Pod) []components.ScrollItem {
+	items := make([]components.ScrollItem, len(deployments))
+	liveFound := pod.Status != "running"
+	for i, d := range deployments {
+		live := !liveFound && d.Status == model.DeploymentStatusSuccess
+		if live {
+			liveFound = true
+		}
+		items[i] = deploymentItem{deployment: d, live: live}
+	}
+	return items
+}
@@ -19,21 +19,30 @@ type podDetail struct {
 	project     *model.Project
 	domains     []model.PodDomain
 	envVarCount int
+	volumes     []model.PodVolume
+	links       []model.PodDatabase
+	webhook     *model.PodWebhook
 	keyDeploy   key.Binding
+	keyClean    key.Binding
 	keyStop     key.Binding
 	keyRestart  key.Binding
 	keyLogs     key.Binding
+	keyHistory  key.Binding
 	keyEdit     key.Binding
 	keyDomains  key.Binding
 	keyVars     key.Binding
+	keyVolumes  key.Binding
+	keyDatabase key.Binding
 	keyToken    key.Binding
+	keyHealth   key.Binding
+	keyWebhook  key.Binding
 	keyBack     key.Binding
 	width       int
 	height      int
 }
 
 func (m podDetail) HelpKeys() []key.Binding {
-	return []key.Binding{m.keyDeploy, m.keyStop, m.keyRestart, m.keyLogs, m.keyEdit, m.keyDomains, m.keyVars, m.keyToken, m.keyBack}
+	return []key.Binding{m.keyDeploy, m.keyClean, m.keyStop, m.keyRestart, m.keyLogs, m.keyHistory, m.keyEdit, m.keyDomains, m.keyVars, m.keyVolumes, m.keyDatabase, m.keyToken, m.keyHealth, m.keyWebhook, m.keyBack}
 }
 
 func NewPodDetail(s msg.Store, podID string) podDetail {
@@ -62,25 +71,45 @@ func NewPodDetail(s msg.Store, podID string) podDetail {
 		domains:     s.PodDomains(podID),
 		envVarCount: len(s.PodEnvVars(podID)),
 		keyDeploy:   key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "deploy")),
+		keyClean:    key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "deploy without cache")),
 		keyStop:     key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "stop")),
 		keyRestart:  key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "restart")),
 		keyLogs:     key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "logs")),
+		keyHistory:  key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "deployments")),
 		keyEdit:     key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit")),
 		keyDomains:  key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "domains")),
 		keyVars:     key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "env vars")),
+		keyVolumes:  key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "volumes")),
+		keyDatabase: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "databases")),
 		keyToken:    key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "token")),
+		keyHealth:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "health check")),
+		keyWebhook:  key.NewBinding(key.WithKeys("W"), key.WithHelp("W", "new webhook secret")),
 		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
 	}
 }
 
 func (m podDetail) Init() tea.Cmd {
-	return nil
+	if m.pod == nil {
+		return nil
+	}
+	return tea.Batch(api.FetchPodWebhook(m.pod.ID), api.FetchPodVolumes(m.pod.ID), api.FetchPodDatabases(m.pod.ID))
 }
 
 func (m podDetail) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 	switch tmsg := tmsg.(type) {
 	case msg.PodDeployed:
-		return m, api.LoadData()
+		// Deployment is recorded now - follow its build log
+		podID := m.pod.ID
+		return m, tea.Batch(
+			api.LoadData(),
+			func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model {
+						return NewPodLogs(s, podID)
+					},
+				}
+			},
+		)
 
 	case msg.PodStopped:
 		return m, tea.Batch(
@@ -91,9 +120,35 @@ func (m podDetail) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 	case msg.PodRestarted:
 		return m, tea.Batch(
 			api.LoadData(),
-			func() tea.Msg { return msg.ShowStatus{Text: "Pod restarted", Type: msg.StatusSuccess} },
+			func() tea.Msg { return msg.ShowStatus{Text: "Restarting in background", Type: msg.StatusInfo} },
 		)
 
+	case msg.PodWebhookLoaded:
+		if tmsg.Webhook.PodID == m.pod.ID {
+			m.webhook = &tmsg.Webhook
+		}
+		return m, nil
+
+	case msg.PodVolumesLoaded:
+		if tmsg.PodID == m.pod.ID {
+			m.volumes = tmsg.Volumes
+		}
+		return m, nil
+
+	case msg.PodDatabasesLoaded:
+		if tmsg.PodID == m.pod.ID {
+			m.links = tmsg.Links
+		}
+		return m, nil
+
+	case msg.PodWebhookRegenerated:
+		if tmsg.Webhook.PodID == m.pod.ID {
+			m.webhook = &tmsg.Webhook
+		}
+		return m, func() tea.Msg {
+			return msg.ShowStatus{Text: "Webhook secret created", Type: msg.StatusSuccess}
+		}
+
 	case msg.DataLoaded:
 		for _, p := range tmsg.Pods {
 			if p.ID == m.pod.ID {
@@ -134,17 +189,15 @@ func (m podDetail) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case key.Matches(tmsg, m.keyDeploy):
-		podID := m.pod.ID
 		return m, tea.Batch(
 			func() tea.Msg { return msg.StartLoading{Text: "Deploying"} },
-			api.DeployPod(podID),
-			func() tea.Msg {
-				return msg.ChangePage{
-					PageFactory: func(s msg.Store) tea.Model {
-						return NewPodLogs(s, podID)
-					},
-				}
-			},
+			api.DeployPod(m.pod.ID, false),
+		)
+
+	case key.Matches(tmsg, m.keyClean):
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Deploying without cache"} },
+			api.DeployPod(m.pod.ID, true),
 		)
 
 	case key.Matches(tmsg, m.keyStop):
@@ -169,6 +222,17 @@ func (m podDetail) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case key.Matches(tmsg, m.keyHistory):
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDeployments(pod, project)
+				},
+			}
+		}
+
 	case key.Matches(tmsg, m.keyEdit):
 		pod := m.pod
 		projectID := m.project.ID
@@ -202,16 +266,55 @@ func (m podDetail) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case key.Matches(tmsg, m.keyVolumes):
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodVolumes(pod, project)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keyDatabase):
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDatabases(s, pod, project)
+				},
+			}
+		}
+
 	case key.Matches(tmsg, m.keyToken):
 		pod := m.pod
 		project := m.project
 		return m, func() tea.Msg {
 			return msg.ChangePage{
 				PageFactory: func(s msg.Store) tea.Model {
+					if pod.SourceType == model.PodSourceImage {
+						return NewPodRegistry(pod, project, s.RegistryCredentials())
+					}
 					return NewPodToken(pod, project, s.GitTokens())
 				},
 			}
 		}
+
+	case key.Matches(tmsg, m.keyHealth):
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodHealth(pod, project)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keyWebhook):
+		return m, api.RegeneratePodWebhook(m.pod.ID)
 	}
 
 	return m, nil
@@ -234,6 +337,108 @@ func (m podDetail) View() tea.View {
 
 	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
 
+	if m.pod.SourceType == model.PodSourceImage {
+		m.viewImageSource(&b, labelStyle)
+	} else {
+		m.viewGitSource(&b, labelStyle)
+	}
+
+	// Domains
+	b.WriteString(labelStyle.Render("Domains"))
+	b.WriteString("\n")
+	if len(m.domains) > 0 {
+		b.WriteString(fmt.Sprintf("%d configured", len(m.domains)))
+	} else {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("(none)"))
+	}
+	b.WriteString("\n\n")
+
+	// Env Vars
+	b.WriteString(labelStyle.Render("Env Vars"))
+	b.WriteString("\n")
+	if m.envVarCount > 0 {
+		b.WriteString(fmt.Sprintf("%d configured", m.envVarCount))
+	} else {
+		b.WriteString(styles.MutedStyle().Render("(none)"))
+	}
+	b.WriteString("\n\n")
+
+	// Volumes
+	b.WriteString(labelStyle.Render("Volumes"))
+	b.WriteString("\n")
+	if len(m.volumes) > 0 {
+		mounts := make([]string, len(m.volumes))
+		for i, v := range m.volumes {
+			mounts[i] = v.MountPath
+		}
+		b.WriteString(strings.Join(mounts, ", "))
+	} else {
+		b.WriteString(styles.MutedStyle().Render("(none - data is lost on redeploy)"))
+	}
+	b.WriteString("\n\n")
+
+	// Databases
+	b.WriteString(labelStyle.Render("Databases"))
+	b.WriteString("\n")
+	if len(m.links) > 0 {
+		envVars := make([]string, len(m.links))
+		for i, l := range m.links {
+			envVars[i] = l.EnvVar
+		}
+		b.WriteString(strings.Join(envVars, ", "))
+	} else {
+		b.WriteString(styles.MutedStyle().Render("(none)"))
+	}
+	b.WriteString("\n\n")
+
+	// Resources
+	b.WriteString(labelStyle.Render("Resources"))
+	b.WriteString("\n")
+	b.WriteString(m.renderResources())
+	b.WriteString("\n\n")
+
+	// Health Check
+	b.WriteString(labelStyle.Render("Health Check"))
+	b.WriteString("\n")
+	expected := "2xx/3xx"
+	if m.pod.HealthCheckStatus != 0 {
+		expected = fmt.Sprintf("%d", m.pod.HealthCheckStatus)
+	}
+	b.WriteString(fmt.Sprintf("GET %s → %s", m.pod.HealthCheckPath, expected))
+	b.WriteString(styles.MutedStyle().Render(fmt.Sprintf("  every %ds, timeout %ds, grace %ds",
+		m.pod.HealthCheckInterval, m.pod.HealthCheckTimeout, m.pod.HealthCheckGracePeriod)))
+	b.WriteString("\n\n")
+
+	// Webhook - image pods are deployed by pulling, pushes don't concern them
+	b.WriteString(labelStyle.Render("Webhook"))
+	b.WriteString("\n")
+	if m.pod.SourceType == model.PodSourceImage {
+		b.WriteString(styles.MutedStyle().Render("(not available for image pods)"))
+	} else if m.webhook != nil && m.webhook.Secret == "" {
+		b.WriteString(styles.MutedStyle().Render("Not enabled, press W to create a secret"))
+	} else if m.webhook != nil {
+		b.WriteString(m.webhook.URL)
+		b.WriteString("\n")
+		b.WriteString(styles.MutedStyle().Render("Secret "))
+		b.WriteString(m.webhook.Secret)
+	} else {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	}
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthLG,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+// viewGitSource renders the source sections of pods built from a git repository.
+func (m podDetail) viewGitSource(b *strings.Builder, labelStyle lipgloss.Style) {
 	// Repo Info
 	b.WriteString(labelStyle.Render("Repo"))
 	b.WriteString("\n")
@@ -246,10 +451,26 @@ func (m podDetail) View() tea.View {
 	}
 	b.WriteString("\n\n")
 
+	// Live Commit
+	b.WriteString(labelStyle.Render("Live Commit"))
+	b.WriteString("\n")
+	if m.pod.CommitSHA != nil && len(*m.pod.CommitSHA) >= 7 {
+		b.WriteString((*m.pod.CommitSHA)[:7])
+		if m.pod.CommitMessage != nil && *m.pod.CommitMessage != "" {
+			b.WriteString(" ")
+			b.WriteString(styles.MutedStyle().Render(*m.pod.CommitMessage))
+		}
+	} else {
+		b.WriteString(styles.MutedStyle().Render("(not deployed)"))
+	}
+	b.WriteString("\n\n")
+
 	// Dockerfile
 	b.WriteString(labelStyle.Render("Dockerfile"))
 	b.WriteString("\n")
-	if m.pod.DockerfilePath != "" {
+	if m.pod.BuildStrategy == model.BuildStrategyAuto {
+		b.WriteString(styles.MutedStyle().Render("(generated - auto build)"))
+	} else if m.pod.DockerfilePath != "" {
 		b.WriteString(m.pod.DockerfilePath)
 	} else {
 		b.WriteString("Dockerfile")
@@ -274,37 +495,59 @@ func (m podDetail) View() tea.View {
 	} else {
 		b.WriteString(styles.MutedStyle().Render("(none)"))
 	}
-	b.WriteString("\n\n")
+}
 
-	// Domains
-	b.WriteString(labelStyle.Render("Domains"))
+// viewImageSource renders the source sections of pods deployed from a prebuilt image.
+func (m podDetail) viewImageSource(b *strings.Builder, labelStyle lipgloss.Style) {
+	// Image
+	b.WriteString(labelStyle.Render("Image"))
 	b.WriteString("\n")
-	if len(m.domains) > 0 {
-		b.WriteString(fmt.Sprintf("%d configured", len(m.domains)))
+	if m.pod.Image != nil && *m.pod.Image != "" {
+		b.WriteString(*m.pod.Image)
 	} else {
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("(none)"))
+		b.WriteString(styles.MutedStyle().Render("(not configured)"))
 	}
 	b.WriteString("\n\n")
 
-	// Env Vars
-	b.WriteString(labelStyle.Render("Env Vars"))
+	// Registry Credentials
+	b.WriteString(labelStyle.Render("Registry Credentials"))
 	b.WriteString("\n")
-	if m.envVarCount > 0 {
-		b.WriteString(fmt.Sprintf("%d configured", m.envVarCount))
+	if m.pod.RegistryCredentialID != nil {
+		credentialFound := false
+		for _, c := range m.store.RegistryCredentials() {
+			if c.ID == *m.pod.RegistryCredentialID {
+				b.WriteString(fmt.Sprintf("%s [%s]", c.Name, c.Registry))
+				credentialFound = true
+				break
+			}
+		}
+		if !credentialFound {
+			b.WriteString(styles.MutedStyle().Render("(unknown)"))
+		}
 	} else {
-		b.WriteString(styles.MutedStyle().Render("(none)"))
+		b.WriteString(styles.MutedStyle().Render("(none - public image)"))
 	}
+}
 
-	card := styles.Card(styles.CardProps{
-		Width:   styles.CardWidthLG,
-		Padding: []int{1, 2},
-		Accent:  true,
-	}).Render(b.String())
-
-	centered := lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center, card)
-
-	return tea.NewView(centered)
+// renderResources renders the resource limits of the pod, e.g. "512 MB memory, 0.5 CPUs".
+func (m podDetail) renderResources() string {
+	var limits []string
+	if m.pod.MemoryLimit > 0 {
+		limits = append(limits, fmt.Sprintf("%d MB memory", m.pod.MemoryLimit))
+	}
+	if m.pod.MemoryReservation > 0 {
+		limits = append(limits, fmt.Sprintf("%d MB reserved", m.pod.MemoryReservation))
+	}
+	if m.pod.CPULimit > 0 {
+		limits = append(limits, fmt.Sprintf("%g CPUs", m.pod.CPULimit))
+	}
+	if m.pod.PIDsLimit > 0 {
+		limits = append(limits, fmt.Sprintf("%d processes", m.pod.PIDsLimit))
+	}
+	if len(limits) == 0 {
+		return styles.MutedStyle().Render("(unlimited)")
+	}
+	return strings.Join(limits, ", ")
 }
 
 func (m podDetail) renderStatus() string {
@@ -3,11 +3,13 @@ package page
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
 	"github.com/deeploy-sh/deeploy/internal/tui/msg"
 	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
 	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
@@ -19,14 +21,36 @@ type domainItem struct {
 	domain model.PodDomain
 }
 
-func (d domainItem) Title() string       { return d.domain.Domain }
-func (d domainItem) FilterValue() string { return d.domain.Domain }
+func (d domainItem) Title() string       { return d.domain.Domain + d.domain.Path }
+func (d domainItem) FilterValue() string { return d.domain.Domain + d.domain.Path }
 func (d domainItem) Suffix() string {
-	badges := fmt.Sprintf(":%d", d.domain.Port)
-	if d.domain.Type == "auto" {
-		badges += " auto"
+	var suffix string
+	switch d.domain.Type {
+	case model.DomainTypeRedirect:
+		suffix = "→ " + d.domain.RedirectTo
+	case model.DomainTypeAuto, model.DomainTypeWildcard:
+		suffix = fmt.Sprintf(":%d %s", d.domain.Port, d.domain.Type)
+	default:
+		suffix = fmt.Sprintf(":%d", d.domain.Port)
 	}
-	return badges
+
+	switch {
+	case !d.domain.SSLEnabled:
+		suffix += " http"
+	case d.domain.Certificate != nil:
+		suffix += " cert " + d.domain.Certificate.ExpiresAt.Local().Format(time.DateOnly)
+	}
+	if d.domain.Status != model.DomainStatusVerified {
+		suffix += " " + d.domain.Status
+	}
+	return suffix
+}
+
+// dnsRecordView shows the DNS record a domain needs to point at the server.
+func dnsRecordView(record model.DNSRecord) string {
+	return styles.LabelStyle().Render("Type:  ") + record.Type + "\n" +
+		styles.LabelStyle().Render("Name:  ") + record.Name + "\n" +
+		styles.LabelStyle().Render("Value: ") + record.Value
 }
 
 var podDomainsCard = styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
@@ -39,6 +63,9 @@ type podDomains struct {
 	keyAuto   key.Binding
 	keyEdit   key.Binding
 	keyDelete key.Binding
+	keyCert   key.Binding
+	keyVerify key.Binding
+	keySkip   key.Binding
 	keyOpen   key.Binding
 	keyBack   key.Binding
 	width     int
@@ -46,7 +73,7 @@ type podDomains struct {
 }
 
 func (m podDomains) HelpKeys() []key.Binding {
-	return []key.Binding{m.keyAdd, m.keyAuto, m.keyEdit, m.keyDelete, m.keyOpen, m.keyBack}
+	return []key.Binding{m.keyAdd, m.keyAuto, m.keyEdit, m.keyDelete, m.keyCert, m.keyVerify, m.keySkip, m.keyOpen, m.keyBack}
 }
 
 func NewPodDomains(s msg.Store, pod *model.Pod, project *model.Project) podDomains {
@@ -64,6 +91,9 @@ func NewPodDomains(s msg.Store, pod *model.Pod, project *model.Project) podDomai
 		keyAuto:   key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "generate auto")),
 		keyEdit:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit")),
 		keyDelete: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+		keyCert:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "certificate")),
+		keyVerify: key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "re-check dns")),
+		keySkip:   key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "skip dns check")),
 		keyOpen:   key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open")),
 		keyBack:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
 	}
@@ -75,6 +105,15 @@ func (m podDomains) Init() tea.Cmd {
 
 func (m podDomains) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 	switch tmsg := tmsg.(type) {
+	case msg.PodDomainVerified:
+		items := m.domains.Items()
+		for i, item := range items {
+			if item.(domainItem).domain.ID == tmsg.Domain.ID {
+				items[i] = domainItem{domain: tmsg.Domain}
+			}
+		}
+		m.domains.SetItems(items)
+
 	case tea.KeyPressMsg:
 		return m.handleKeyPress(tmsg)
 
@@ -151,6 +190,40 @@ func (m podDomains) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case key.Matches(tmsg, m.keyCert):
+		if item := m.domains.SelectedItem(); item != nil {
+			domain := item.(domainItem).domain
+			pod := m.pod
+			project := m.project
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model {
+						return NewPodDomainCertificate(domain, pod, project)
+					},
+				}
+			}
+		}
+
+	case key.Matches(tmsg, m.keyVerify):
+		if item := m.domains.SelectedItem(); item != nil {
+			domain := item.(domainItem).domain
+			return m, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Checking DNS of " + domain.Domain} },
+				api.VerifyPodDomain(m.pod.ID, domain.ID),
+			)
+		}
+
+	case key.Matches(tmsg, m.keySkip):
+		if item := m.domains.SelectedItem(); item != nil {
+			domain := item.(domainItem).domain
+			if domain.Status != model.DomainStatusVerified {
+				return m, tea.Batch(
+					func() tea.Msg { return msg.StartLoading{Text: "Skipping the DNS check of " + domain.Domain} },
+					api.SkipPodDomainVerification(m.pod.ID, domain.ID),
+				)
+			}
+		}
+
 	case key.Matches(tmsg, m.keyOpen):
 		if item := m.domains.SelectedItem(); item != nil {
 			return m, utils.OpenBrowserCmd(item.(domainItem).domain.URL)
@@ -179,6 +252,10 @@ func (m podDomains) View() tea.View {
 		b.WriteString(styles.MutedStyle().Render("A domain is required before you can deploy."))
 	} else {
 		b.WriteString(m.domains.View())
+		if item, ok := m.domains.SelectedItem().(domainItem); ok && item.domain.Status != model.DomainStatusVerified {
+			b.WriteString("\n\n")
+			b.WriteString(m.verificationView(item.domain))
+		}
 	}
 
 	card := styles.Card(podDomainsCard).Render(b.String())
@@ -187,6 +264,25 @@ func (m podDomains) View() tea.View {
 	return tea.NewView(centered)
 }
 
+// verificationView tells what an unverified domain is missing before it's routed.
+func (m podDomains) verificationView(domain model.PodDomain) string {
+	var b strings.Builder
+	if domain.StatusMessage != "" {
+		b.WriteString(styles.ErrorStyle().Render(domain.StatusMessage))
+		b.WriteString("\n")
+	}
+	if domain.DNSRecord == nil {
+		b.WriteString(styles.MutedStyle().Render("Not routed until its DNS is verified, press 'v' to re-check."))
+	} else {
+		b.WriteString(styles.MutedStyle().Render("Not routed until this DNS record exists, then press 'v':"))
+		b.WriteString("\n")
+		b.WriteString(dnsRecordView(*domain.DNSRecord))
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Behind a CDN or NAT? Server admins press 'V' to route it without the check."))
+	return b.String()
+}
+
 func (m podDomains) Breadcrumbs() []string {
 	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Domains"}
 }
@@ -0,0 +1,245 @@
+package page
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldCertificateFile = iota
+	fieldCertificateKeyFile
+)
+
+const numCertificateFields = 2
+
+// podDomainCertificate uploads a certificate for a domain from local
+// PEM files, e.g. one issued by a corporate CA.
+type podDomainCertificate struct {
+	domain       model.PodDomain
+	pod          *model.Pod
+	project      *model.Project
+	inputs       []textinput.Model
+	focusedField int
+	keySave      key.Binding
+	keyRemove    key.Binding
+	keyBack      key.Binding
+	keyTab       key.Binding
+	keyShiftTab  key.Binding
+	width        int
+	height       int
+}
+
+func (m podDomainCertificate) HelpKeys() []key.Binding {
+	if m.domain.Certificate != nil {
+		return []key.Binding{m.keySave, m.keyRemove, m.keyTab, m.keyBack}
+	}
+	return []key.Binding{m.keySave, m.keyTab, m.keyBack}
+}
+
+func NewPodDomainCertificate(domain model.PodDomain, pod *model.Pod, project *model.Project) podDomainCertificate {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	inputWidth := card.InnerWidth()
+
+	inputs := make([]textinput.Model, numCertificateFields)
+	for i := range inputs {
+		inputs[i] = components.NewTextInput(inputWidth)
+		inputs[i].CharLimit = 300
+	}
+	inputs[fieldCertificateFile].Placeholder = "~/certs/fullchain.pem"
+	inputs[fieldCertificateKeyFile].Placeholder = "~/certs/privkey.pem"
+	inputs[fieldCertificateFile].Focus()
+
+	return podDomainCertificate{
+		domain:      domain,
+		pod:         pod,
+		project:     project,
+		inputs:      inputs,
+		keySave:     key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "upload")),
+		keyRemove:   key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "use let's encrypt")),
+		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+	}
+}
+
+func (m podDomainCertificate) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m podDomainCertificate) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Blink passthrough
+	var cmd tea.Cmd
+	m.inputs[m.focusedField], cmd = m.inputs[m.focusedField].Update(tmsg)
+	return m, cmd
+}
+
+func (m *podDomainCertificate) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDomains(s, pod, project)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		return m, m.upload()
+
+	case key.Matches(tmsg, m.keyRemove):
+		if m.domain.Certificate == nil {
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Removing certificate"} },
+			api.DeleteCertificate(m.pod.ID, m.domain.ID),
+		)
+
+	case key.Matches(tmsg, m.keyTab):
+		m.focusedField = (m.focusedField + 1) % numCertificateFields
+		return m, m.updateFocus()
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		m.focusedField = (m.focusedField + numCertificateFields - 1) % numCertificateFields
+		return m, m.updateFocus()
+	}
+
+	// Update focused input
+	var cmd tea.Cmd
+	m.inputs[m.focusedField], cmd = m.inputs[m.focusedField].Update(tmsg)
+	return m, cmd
+}
+
+func (m *podDomainCertificate) updateFocus() tea.Cmd {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+	return m.inputs[m.focusedField].Focus()
+}
+
+// upload reads the PEM files locally and sends their content to the server.
+func (m *podDomainCertificate) upload() tea.Cmd {
+	certPath := strings.TrimSpace(m.inputs[fieldCertificateFile].Value())
+	keyPath := strings.TrimSpace(m.inputs[fieldCertificateKeyFile].Value())
+	if certPath == "" || keyPath == "" {
+		return nil
+	}
+
+	cert, err := readLocalFile(certPath)
+	if err != nil {
+		return func() tea.Msg {
+			return msg.ShowStatus{Text: "Can't read certificate: " + err.Error(), Type: msg.StatusError}
+		}
+	}
+	privateKey, err := readLocalFile(keyPath)
+	if err != nil {
+		return func() tea.Msg {
+			return msg.ShowStatus{Text: "Can't read private key: " + err.Error(), Type: msg.StatusError}
+		}
+	}
+
+	return tea.Batch(
+		func() tea.Msg { return msg.StartLoading{Text: "Uploading certificate"} },
+		api.UploadCertificate(m.pod.ID, m.domain.ID, model.CertificateUpload{Certificate: cert, PrivateKey: privateKey}),
+	)
+}
+
+// readLocalFile reads a file of this machine, ~ is the home directory.
+func readLocalFile(path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (m podDomainCertificate) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Certificate"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render(m.domain.Domain))
+	b.WriteString("\n\n")
+
+	if c := m.domain.Certificate; c != nil {
+		b.WriteString("Custom certificate")
+		if c.Issuer != "" {
+			b.WriteString(" by " + c.Issuer)
+		}
+		b.WriteString("\n")
+		expires := "Expires " + c.ExpiresAt.Local().Format(time.DateOnly)
+		if time.Until(c.ExpiresAt) < 30*24*time.Hour {
+			b.WriteString(styles.WarningStyle().Render(expires))
+		} else {
+			b.WriteString(styles.MutedStyle().Render(expires))
+		}
+	} else {
+		b.WriteString(styles.MutedStyle().Render("Let's Encrypt, renewed automatically"))
+	}
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+
+	labels := []string{"Certificate file (PEM, with intermediates)", "Private key file (PEM)"}
+	for i, label := range labels {
+		if m.focusedField == i {
+			b.WriteString(activeLabel.Render(label))
+		} else {
+			b.WriteString(labelStyle.Render(label))
+		}
+		b.WriteString("\n")
+		b.WriteString(m.inputs[i].View())
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(styles.MutedStyle().Render("The key is encrypted at rest. Redeploy the pod to use the certificate."))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podDomainCertificate) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Domains", "Certificate"}
+}
@@ -1,8 +1,10 @@
 package page
 
 import (
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textinput"
@@ -16,27 +18,38 @@ import (
 )
 
 type podDomainsForm struct {
-	domain       *model.PodDomain // nil = create, otherwise edit
-	pod          *model.Pod
-	project      *model.Project
-	isAuto       bool // only relevant for create
-	domainInput  textinput.Model
-	portInput    textinput.Model
-	focusedField int
-	keySave      key.Binding
-	keyBack      key.Binding
-	keyTab       key.Binding
-	keyShiftTab  key.Binding
-	width        int
-	height       int
+	domain        *model.PodDomain // nil = create, otherwise edit
+	pod           *model.Pod
+	project       *model.Project
+	isAuto        bool // only relevant for create
+	domainInput   textinput.Model
+	pathInput     textinput.Model
+	redirectInput textinput.Model
+	portInput     textinput.Model
+	sslEnabled    bool
+	dnsRecord     *model.DNSRecord // record custom domains need, name from the domain input
+	focusedField  int
+	keySave       key.Binding
+	keyToggle     key.Binding
+	keyBack       key.Binding
+	keyTab        key.Binding
+	keyShiftTab   key.Binding
+	width         int
+	height        int
 }
 
 const (
 	fieldDomain = iota
+	fieldPath
+	fieldRedirect
 	fieldPort
+	fieldSSL // toggle, no text input
 )
 
 func (m podDomainsForm) HelpKeys() []key.Binding {
+	if m.focusedField == fieldSSL {
+		return []key.Binding{m.keySave, m.keyToggle, m.keyTab, m.keyBack}
+	}
 	return []key.Binding{m.keySave, m.keyTab, m.keyBack}
 }
 
@@ -48,14 +61,26 @@ func NewPodDomainsForm(pod *model.Pod, project *model.Project, domain *model.Pod
 	domainInput.Placeholder = "app.example.com"
 	domainInput.CharLimit = 100
 
+	pathInput := components.NewTextInput(inputWidth)
+	pathInput.Placeholder = "/api (optional)"
+	pathInput.CharLimit = 100
+
+	redirectInput := components.NewTextInput(inputWidth)
+	redirectInput.Placeholder = "example.com (optional, only redirects)"
+	redirectInput.CharLimit = 100
+
 	portInput := components.NewTextInput(inputWidth)
 	portInput.Placeholder = "8080"
 	portInput.CharLimit = 5
 	portInput.SetValue("8080")
 
 	// Set values if editing
+	sslEnabled := true
 	if domain != nil {
+		sslEnabled = domain.SSLEnabled
 		domainInput.SetValue(domain.Domain)
+		pathInput.SetValue(domain.Path)
+		redirectInput.SetValue(domain.RedirectTo)
 		portInput.SetValue(strconv.Itoa(domain.Port))
 	}
 
@@ -73,21 +98,37 @@ func NewPodDomainsForm(pod *model.Pod, project *model.Project, domain *model.Pod
 	}
 
 	return podDomainsForm{
-		domain:       domain,
-		pod:          pod,
-		project:      project,
-		isAuto:       isAuto,
-		domainInput:  domainInput,
-		portInput:    portInput,
-		focusedField: focusedField,
-		keySave:      key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
-		keyBack:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
-		keyTab:       key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
-		keyShiftTab:  key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+		domain:        domain,
+		pod:           pod,
+		project:       project,
+		isAuto:        isAuto,
+		domainInput:   domainInput,
+		pathInput:     pathInput,
+		redirectInput: redirectInput,
+		portInput:     portInput,
+		sslEnabled:    sslEnabled,
+		focusedField:  focusedField,
+		keySave:       key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyToggle:     key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "toggle")),
+		keyBack:       key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:        key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+	}
+}
+
+// needsDNSRecord reports whether the domain is entered by hand, generated
+// domains resolve without a record.
+func (m podDomainsForm) needsDNSRecord() bool {
+	if m.domain == nil {
+		return !m.isAuto
 	}
+	return m.domain.Type != model.DomainTypeAuto
 }
 
 func (m podDomainsForm) Init() tea.Cmd {
+	if m.needsDNSRecord() {
+		return tea.Batch(textinput.Blink, api.FetchDNSRecord(m.pod.ID))
+	}
 	return textinput.Blink
 }
 
@@ -96,6 +137,10 @@ func (m podDomainsForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyPressMsg:
 		return m.handleKeyPress(tmsg)
 
+	case msg.DNSRecordLoaded:
+		m.dnsRecord = &tmsg.Record
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = tmsg.Width
 		m.height = tmsg.Height
@@ -103,14 +148,44 @@ func (m podDomainsForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	// Update focused input for blink messages
+	if m.focusedField == fieldSSL {
+		return m, nil
+	}
 	var cmd tea.Cmd
-	switch m.focusedField {
-	case fieldDomain:
-		m.domainInput, cmd = m.domainInput.Update(tmsg)
+	*m.input(m.focusedField), cmd = m.input(m.focusedField).Update(tmsg)
+	return m, cmd
+}
+
+// fields returns the fields shown in the form, in tab order.
+func (m podDomainsForm) fields() []int {
+	switch {
+	case m.domain == nil && m.isAuto:
+		// Auto create: only port, the domain is generated
+		return []int{fieldPort, fieldSSL}
+	case m.domain != nil && m.domain.Type == model.DomainTypeAuto:
+		return []int{fieldDomain, fieldPath, fieldPort, fieldSSL}
+	}
+	return []int{fieldDomain, fieldPath, fieldRedirect, fieldPort, fieldSSL}
+}
+
+func (m *podDomainsForm) input(field int) *textinput.Model {
+	switch field {
+	case fieldPath:
+		return &m.pathInput
+	case fieldRedirect:
+		return &m.redirectInput
 	case fieldPort:
-		m.portInput, cmd = m.portInput.Update(tmsg)
+		return &m.portInput
 	}
-	return m, cmd
+	return &m.domainInput
+}
+
+// moveFocus focuses the field delta steps away in tab order.
+func (m *podDomainsForm) moveFocus(delta int) tea.Cmd {
+	fields := m.fields()
+	current := slices.Index(fields, m.focusedField)
+	m.focusedField = fields[(current+delta+len(fields))%len(fields)]
+	return m.updateFocus()
 }
 
 func (m *podDomainsForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
@@ -130,47 +205,38 @@ func (m *podDomainsForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cm
 		return m.save()
 
 	case key.Matches(tmsg, m.keyTab):
-		if m.isAuto && m.domain == nil {
-			// Auto create: only port field, no tab
-			return m, nil
-		}
-		m.focusedField = (m.focusedField + 1) % 2
-		return m, m.updateFocus()
+		return m, m.moveFocus(1)
 
 	case key.Matches(tmsg, m.keyShiftTab):
-		if m.isAuto && m.domain == nil {
-			// Auto create: only port field, no tab
-			return m, nil
+		return m, m.moveFocus(-1)
+	}
+
+	if m.focusedField == fieldSSL {
+		if key.Matches(tmsg, m.keyToggle) {
+			m.sslEnabled = !m.sslEnabled
 		}
-		m.focusedField = (m.focusedField + 1) % 2
-		return m, m.updateFocus()
+		return m, nil
 	}
 
 	// Update focused input
 	var cmd tea.Cmd
-	switch m.focusedField {
-	case fieldDomain:
-		m.domainInput, cmd = m.domainInput.Update(tmsg)
-	case fieldPort:
-		m.portInput, cmd = m.portInput.Update(tmsg)
-	}
+	*m.input(m.focusedField), cmd = m.input(m.focusedField).Update(tmsg)
 	return m, cmd
 }
 
 func (m *podDomainsForm) blurAll() {
 	m.domainInput.Blur()
+	m.pathInput.Blur()
+	m.redirectInput.Blur()
 	m.portInput.Blur()
 }
 
 func (m *podDomainsForm) updateFocus() tea.Cmd {
 	m.blurAll()
-	switch m.focusedField {
-	case fieldDomain:
-		return m.domainInput.Focus()
-	case fieldPort:
-		return m.portInput.Focus()
+	if m.focusedField == fieldSSL {
+		return nil
 	}
-	return nil
+	return m.input(m.focusedField).Focus()
 }
 
 func (m *podDomainsForm) save() (tea.Model, tea.Cmd) {
@@ -184,7 +250,7 @@ func (m *podDomainsForm) save() (tea.Model, tea.Cmd) {
 	if m.domain == nil && m.isAuto {
 		return m, tea.Batch(
 			func() tea.Msg { return msg.StartLoading{Text: "Generating domain"} },
-			api.GenerateAutoDomain(m.pod.ID, port, true),
+			api.GenerateAutoDomain(m.pod.ID, port, m.sslEnabled),
 		)
 	}
 
@@ -194,18 +260,27 @@ func (m *podDomainsForm) save() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The server derives the type from the domain and redirect target
+	data := model.PodDomain{
+		Domain:     domain,
+		Path:       strings.TrimSpace(m.pathInput.Value()),
+		RedirectTo: strings.TrimSpace(m.redirectInput.Value()),
+		Port:       port,
+		SSLEnabled: m.sslEnabled,
+	}
+
 	// Create
 	if m.domain == nil {
 		return m, tea.Batch(
 			func() tea.Msg { return msg.StartLoading{Text: "Creating domain"} },
-			api.CreatePodDomain(m.pod.ID, domain, port, true),
+			api.CreatePodDomain(m.pod.ID, data),
 		)
 	}
 
 	// Update
 	return m, tea.Batch(
 		func() tea.Msg { return msg.StartLoading{Text: "Updating domain"} },
-		api.UpdatePodDomain(m.pod.ID, m.domain.ID, domain, port, true),
+		api.UpdatePodDomain(m.pod.ID, m.domain.ID, data),
 	)
 }
 
@@ -226,43 +301,47 @@ func (m podDomainsForm) View() tea.View {
 	} else {
 		b.WriteString(titleStyle.Render("Edit Domain"))
 		b.WriteString("\n")
-		typeLabel := "custom"
-		if m.domain.Type == "auto" {
-			typeLabel = "auto"
-		}
-		b.WriteString(styles.MutedStyle().Render("Type: " + typeLabel))
+		b.WriteString(styles.MutedStyle().Render("Type: " + m.domain.Type))
 	}
 	b.WriteString("\n\n")
 
 	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
 	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
 
-	// Domain field (not for auto create)
-	if !(m.domain == nil && m.isAuto) {
-		if m.focusedField == fieldDomain {
-			b.WriteString(activeLabel.Render("Domain"))
+	labels := map[int]string{
+		fieldDomain:   "Domain (*.example.com for all subdomains)",
+		fieldPath:     "Path",
+		fieldRedirect: "Redirect to",
+		fieldPort:     "Port",
+	}
+	for _, field := range m.fields() {
+		if field == fieldSSL {
+			continue
+		}
+		if m.focusedField == field {
+			b.WriteString(activeLabel.Render(labels[field]))
 		} else {
-			b.WriteString(labelStyle.Render("Domain"))
+			b.WriteString(labelStyle.Render(labels[field]))
 		}
 		b.WriteString("\n")
-		b.WriteString(m.domainInput.View())
+		b.WriteString(m.input(field).View())
 		b.WriteString("\n\n")
 	}
 
-	// Port field
-	if m.focusedField == fieldPort {
-		b.WriteString(activeLabel.Render("Port"))
+	if m.focusedField == fieldSSL {
+		b.WriteString(activeLabel.Render("SSL"))
 	} else {
-		b.WriteString(labelStyle.Render("Port"))
+		b.WriteString(labelStyle.Render("SSL"))
 	}
 	b.WriteString("\n")
-	b.WriteString(m.portInput.View())
-	b.WriteString("\n\n")
+	b.WriteString(m.sslView(labelStyle, activeLabel))
 
-	// SSL info
-	b.WriteString(labelStyle.Render("SSL"))
-	b.WriteString("\n")
-	b.WriteString(styles.MutedStyle().Render("Automatic (Let's Encrypt)"))
+	if m.needsDNSRecord() && m.dnsRecord != nil {
+		b.WriteString("\n\n")
+		b.WriteString(labelStyle.Render("DNS record"))
+		b.WriteString("\n")
+		b.WriteString(m.dnsRecordView())
+	}
 
 	card := styles.Card(styles.CardProps{
 		Width:   styles.CardWidthMD,
@@ -276,6 +355,31 @@ func (m podDomainsForm) View() tea.View {
 	return tea.NewView(centered)
 }
 
+// sslView shows the SSL toggle and where the certificate comes from.
+func (m podDomainsForm) sslView(labelStyle, activeLabel lipgloss.Style) string {
+	selected, source := "off", "plain HTTP"
+	if m.sslEnabled {
+		selected, source = "on", "Let's Encrypt"
+		if m.domain != nil && m.domain.Certificate != nil {
+			source = "custom certificate, expires " + m.domain.Certificate.ExpiresAt.Local().Format(time.DateOnly)
+		}
+	}
+	return renderOptions([]string{"on", "off"}, selected, labelStyle, activeLabel) + "\n" + styles.MutedStyle().Render(source)
+}
+
+// dnsRecordView shows the record for the domain as typed. The domain is
+// routed once the server finds it.
+func (m podDomainsForm) dnsRecordView() string {
+	record := *m.dnsRecord
+	record.Name = strings.ToLower(strings.TrimSpace(m.domainInput.Value()))
+	record.Name = strings.TrimPrefix(strings.TrimPrefix(record.Name, "https://"), "http://")
+	record.Name = strings.TrimSuffix(record.Name, "/")
+	if record.Name == "" {
+		record.Name = m.domainInput.Placeholder
+	}
+	return dnsRecordView(record) + "\n" + styles.MutedStyle().Render("Routed once the record points to this server")
+}
+
 func (m podDomainsForm) Breadcrumbs() []string {
 	if m.domain == nil {
 		return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Domains", "New"}
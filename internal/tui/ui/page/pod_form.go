@@ -1,6 +1,7 @@
 package page
 
 import (
+	"strconv"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -21,23 +22,58 @@ type podForm struct {
 	repoURLInput    textinput.Model
 	branchInput     textinput.Model
 	dockerfileInput textinput.Model
+	imageInput      textinput.Model
+	limitInputs     []textinput.Model // in limitFields order
+	sourceType      string
+	buildStrategy   string
 	focusedField    int
 	keySave         key.Binding
 	keyBack         key.Binding
 	keyTab          key.Binding
 	keyShiftTab     key.Binding
+	keyToggle       key.Binding
 	width           int
 	height          int
 }
 
 const (
 	fieldTitle = iota
+	fieldSource
 	fieldRepoURL
 	fieldBranch
+	fieldBuildStrategy
 	fieldDockerfile
+	fieldImage
+	fieldMemoryLimit
+	fieldMemoryReservation
+	fieldCPULimit
+	fieldPIDsLimit
 )
 
-const numFields = 4
+var limitFields = []int{fieldMemoryLimit, fieldMemoryReservation, fieldCPULimit, fieldPIDsLimit}
+
+// fields returns the form fields shown for the selected source, in tab order.
+func (m podForm) fields() []int {
+	fields := []int{fieldTitle, fieldSource, fieldRepoURL, fieldBranch, fieldBuildStrategy, fieldDockerfile}
+	if m.sourceType == model.PodSourceImage {
+		fields = []int{fieldTitle, fieldSource, fieldImage}
+	}
+	return append(fields, limitFields...)
+}
+
+// moveFocus moves the focus by delta fields, wrapping around.
+func (m *podForm) moveFocus(delta int) tea.Cmd {
+	fields := m.fields()
+	current := 0
+	for i, f := range fields {
+		if f == m.focusedField {
+			current = i
+			break
+		}
+	}
+	m.focusedField = fields[(current+delta+len(fields))%len(fields)]
+	return m.updateFocus()
+}
 
 func (m podForm) HelpKeys() []key.Binding {
 	return []key.Binding{m.keySave, m.keyTab, m.keyBack}
@@ -76,6 +112,36 @@ func NewPodForm(projectID string, pod *model.Pod) podForm {
 		dockerfileInput.SetValue("Dockerfile")
 	}
 
+	imageInput := components.NewTextInput(inputWidth)
+	imageInput.Placeholder = "ghcr.io/org/app:1.0.0"
+	if pod != nil && pod.Image != nil {
+		imageInput.SetValue(*pod.Image)
+	}
+
+	// Resource limits, empty = unlimited
+	limitInputs := make([]textinput.Model, len(limitFields))
+	for i := range limitInputs {
+		limitInputs[i] = components.NewTextInput(inputWidth/len(limitFields) - 2)
+		limitInputs[i].CharLimit = 8
+		limitInputs[i].Placeholder = "unlimited"
+	}
+	if pod != nil {
+		limitInputs[0].SetValue(formatLimit(float64(pod.MemoryLimit)))
+		limitInputs[1].SetValue(formatLimit(float64(pod.MemoryReservation)))
+		limitInputs[2].SetValue(formatLimit(pod.CPULimit))
+		limitInputs[3].SetValue(formatLimit(float64(pod.PIDsLimit)))
+	}
+
+	sourceType := model.PodSourceGit
+	if pod != nil && pod.SourceType != "" {
+		sourceType = pod.SourceType
+	}
+
+	buildStrategy := model.BuildStrategyDockerfile
+	if pod != nil && pod.BuildStrategy != "" {
+		buildStrategy = pod.BuildStrategy
+	}
+
 	return podForm{
 		pod:             pod,
 		projectID:       projectID,
@@ -83,11 +149,16 @@ func NewPodForm(projectID string, pod *model.Pod) podForm {
 		repoURLInput:    repoInput,
 		branchInput:     branchInput,
 		dockerfileInput: dockerfileInput,
+		imageInput:      imageInput,
+		limitInputs:     limitInputs,
+		sourceType:      sourceType,
+		buildStrategy:   buildStrategy,
 		focusedField:    fieldTitle,
 		keySave:         key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
 		keyBack:         key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
 		keyTab:          key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
 		keyShiftTab:     key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+		keyToggle:       key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "toggle")),
 	}
 }
 
@@ -117,6 +188,11 @@ func (m podForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 		m.branchInput, cmd = m.branchInput.Update(tmsg)
 	case fieldDockerfile:
 		m.dockerfileInput, cmd = m.dockerfileInput.Update(tmsg)
+	case fieldImage:
+		m.imageInput, cmd = m.imageInput.Update(tmsg)
+	case fieldMemoryLimit, fieldMemoryReservation, fieldCPULimit, fieldPIDsLimit:
+		i := m.focusedField - fieldMemoryLimit
+		m.limitInputs[i], cmd = m.limitInputs[i].Update(tmsg)
 	}
 	return m, cmd
 }
@@ -145,12 +221,26 @@ func (m *podForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m.save()
 
 	case key.Matches(tmsg, m.keyTab):
-		m.focusedField = (m.focusedField + 1) % numFields
-		return m, m.updateFocus()
+		return m, m.moveFocus(1)
 
 	case key.Matches(tmsg, m.keyShiftTab):
-		m.focusedField = (m.focusedField + numFields - 1) % numFields
-		return m, m.updateFocus()
+		return m, m.moveFocus(-1)
+
+	case m.focusedField == fieldSource && key.Matches(tmsg, m.keyToggle):
+		if m.sourceType == model.PodSourceImage {
+			m.sourceType = model.PodSourceGit
+		} else {
+			m.sourceType = model.PodSourceImage
+		}
+		return m, nil
+
+	case m.focusedField == fieldBuildStrategy && key.Matches(tmsg, m.keyToggle):
+		if m.buildStrategy == model.BuildStrategyAuto {
+			m.buildStrategy = model.BuildStrategyDockerfile
+		} else {
+			m.buildStrategy = model.BuildStrategyAuto
+		}
+		return m, nil
 	}
 
 	// Update focused input
@@ -164,6 +254,11 @@ func (m *podForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.branchInput, cmd = m.branchInput.Update(tmsg)
 	case fieldDockerfile:
 		m.dockerfileInput, cmd = m.dockerfileInput.Update(tmsg)
+	case fieldImage:
+		m.imageInput, cmd = m.imageInput.Update(tmsg)
+	case fieldMemoryLimit, fieldMemoryReservation, fieldCPULimit, fieldPIDsLimit:
+		i := m.focusedField - fieldMemoryLimit
+		m.limitInputs[i], cmd = m.limitInputs[i].Update(tmsg)
 	}
 	return m, cmd
 }
@@ -173,6 +268,10 @@ func (m *podForm) blurAll() {
 	m.repoURLInput.Blur()
 	m.branchInput.Blur()
 	m.dockerfileInput.Blur()
+	m.imageInput.Blur()
+	for i := range m.limitInputs {
+		m.limitInputs[i].Blur()
+	}
 }
 
 func (m *podForm) updateFocus() tea.Cmd {
@@ -186,6 +285,10 @@ func (m *podForm) updateFocus() tea.Cmd {
 		return m.branchInput.Focus()
 	case fieldDockerfile:
 		return m.dockerfileInput.Focus()
+	case fieldImage:
+		return m.imageInput.Focus()
+	case fieldMemoryLimit, fieldMemoryReservation, fieldCPULimit, fieldPIDsLimit:
+		return m.limitInputs[m.focusedField-fieldMemoryLimit].Focus()
 	}
 	return nil
 }
@@ -196,12 +299,27 @@ func (m *podForm) save() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	limits, err := m.parseLimits()
+	if err != nil {
+		return m, func() tea.Msg {
+			return msg.ShowStatus{Text: "Resource limits must be numbers", Type: msg.StatusError}
+		}
+	}
+
 	pod := m.pod
 	if pod == nil {
 		pod = &model.Pod{ProjectID: m.projectID}
 	}
 
 	pod.Title = title
+	pod.SourceType = m.sourceType
+
+	image := strings.TrimSpace(m.imageInput.Value())
+	if image != "" {
+		pod.Image = &image
+	} else {
+		pod.Image = nil
+	}
 
 	repoURL := strings.TrimSpace(m.repoURLInput.Value())
 	if repoURL != "" {
@@ -215,11 +333,18 @@ func (m *podForm) save() (tea.Model, tea.Cmd) {
 		pod.Branch = "main"
 	}
 
+	pod.BuildStrategy = m.buildStrategy
+
 	pod.DockerfilePath = m.dockerfileInput.Value()
 	if pod.DockerfilePath == "" {
 		pod.DockerfilePath = "Dockerfile"
 	}
 
+	pod.MemoryLimit = int(limits[0])
+	pod.MemoryReservation = int(limits[1])
+	pod.CPULimit = limits[2]
+	pod.PIDsLimit = int(limits[3])
+
 	if m.pod == nil {
 		return m, tea.Batch(
 			func() tea.Msg { return msg.StartLoading{Text: "Creating pod"} },
@@ -257,6 +382,60 @@ func (m podForm) View() tea.View {
 	b.WriteString(m.titleInput.View())
 	b.WriteString("\n\n")
 
+	// Source
+	if m.focusedField == fieldSource {
+		b.WriteString(activeLabel.Render("Source"))
+	} else {
+		b.WriteString(labelStyle.Render("Source"))
+	}
+	b.WriteString("\n")
+	b.WriteString(renderOptions([]string{model.PodSourceGit, model.PodSourceImage}, m.sourceType, labelStyle, activeLabel))
+	b.WriteString("\n\n")
+
+	if m.sourceType == model.PodSourceImage {
+		// Image
+		if m.focusedField == fieldImage {
+			b.WriteString(activeLabel.Render("Image"))
+		} else {
+			b.WriteString(labelStyle.Render("Image"))
+		}
+		b.WriteString("\n")
+		b.WriteString(m.imageInput.View())
+		b.WriteString("\n")
+		b.WriteString(styles.MutedStyle().Render("Private registry? Select credentials with 't' in the pod view."))
+	} else {
+		m.viewGitFields(&b, labelStyle, activeLabel)
+	}
+	b.WriteString("\n\n")
+
+	// Resource limits, side by side
+	labels := []string{"Memory (MB)", "Reserved (MB)", "CPUs", "Processes"}
+	columns := make([]string, len(limitFields))
+	for i, field := range limitFields {
+		label := labelStyle.Render(labels[i])
+		if m.focusedField == field {
+			label = activeLabel.Render(labels[i])
+		}
+		columns[i] = lipgloss.NewStyle().PaddingRight(2).Render(label + "\n" + m.limitInputs[i].View())
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Empty = unlimited. Applied on the next deploy or restart."))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthLG,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+// viewGitFields renders the fields of git sourced pods.
+func (m podForm) viewGitFields(b *strings.Builder, labelStyle, activeLabel lipgloss.Style) {
 	// Repo URL
 	if m.focusedField == fieldRepoURL {
 		b.WriteString(activeLabel.Render("Repo URL"))
@@ -277,6 +456,20 @@ func (m podForm) View() tea.View {
 	b.WriteString(m.branchInput.View())
 	b.WriteString("\n\n")
 
+	// Build Strategy
+	if m.focusedField == fieldBuildStrategy {
+		b.WriteString(activeLabel.Render("Build Strategy"))
+	} else {
+		b.WriteString(labelStyle.Render("Build Strategy"))
+	}
+	b.WriteString("\n")
+	b.WriteString(renderOptions([]string{model.BuildStrategyDockerfile, model.BuildStrategyAuto}, m.buildStrategy, labelStyle, activeLabel))
+	if m.buildStrategy == model.BuildStrategyAuto {
+		b.WriteString("\n")
+		b.WriteString(styles.MutedStyle().Render("Detects Go, Node, Python or static sites and generates a Dockerfile."))
+	}
+	b.WriteString("\n\n")
+
 	// Dockerfile
 	if m.focusedField == fieldDockerfile {
 		b.WriteString(activeLabel.Render("Dockerfile"))
@@ -285,17 +478,48 @@ func (m podForm) View() tea.View {
 	}
 	b.WriteString("\n")
 	b.WriteString(m.dockerfileInput.View())
+}
 
-	card := styles.Card(styles.CardProps{
-		Width:   styles.CardWidthLG,
-		Padding: []int{1, 2},
-		Accent:  true,
-	}).Render(b.String())
+// parseLimits parses the resource limit inputs in limitFields order.
+// Empty inputs are 0 (unlimited).
+func (m podForm) parseLimits() ([]float64, error) {
+	limits := make([]float64, len(m.limitInputs))
+	for i, input := range m.limitInputs {
+		value := strings.TrimSpace(input.Value())
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		limits[i] = n
+	}
+	return limits, nil
+}
 
-	centered := lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center, card)
+// formatLimit formats a resource limit for its input, 0 (unlimited) as empty.
+func formatLimit(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
 
-	return tea.NewView(centered)
+// renderOptions renders a single choice option row like "(•) git  ( ) image".
+func renderOptions(options []string, selected string, labelStyle, activeLabel lipgloss.Style) string {
+	var b strings.Builder
+	for i, option := range options {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		if option == selected {
+			b.WriteString(activeLabel.Render("(•) " + option))
+		} else {
+			b.WriteString(labelStyle.Render("( ) " + option))
+		}
+	}
+	return b.String()
 }
 
 func (m podForm) Breadcrumbs() []string {
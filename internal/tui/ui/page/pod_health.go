@@ -0,0 +1,213 @@
+package page
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldHealthPath = iota
+	fieldHealthStatus
+	fieldHealthInterval
+	fieldHealthTimeout
+	fieldHealthGracePeriod
+)
+
+const numHealthFields = 5
+
+type podHealth struct {
+	pod          *model.Pod
+	project      *model.Project
+	inputs       []textinput.Model
+	focusedField int
+	keySave      key.Binding
+	keyCancel    key.Binding
+	keyTab       key.Binding
+	keyShiftTab  key.Binding
+	width        int
+	height       int
+}
+
+func (m podHealth) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyCancel}
+}
+
+func NewPodHealth(pod *model.Pod, project *model.Project) podHealth {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	inputWidth := card.InnerWidth()
+
+	inputs := make([]textinput.Model, numHealthFields)
+	for i := range inputs {
+		inputs[i] = components.NewTextInput(inputWidth)
+		inputs[i].CharLimit = 5
+	}
+
+	inputs[fieldHealthPath].Placeholder = model.DefaultHealthCheckPath
+	inputs[fieldHealthPath].CharLimit = 200
+	inputs[fieldHealthPath].SetValue(pod.HealthCheckPath)
+	inputs[fieldHealthStatus].Placeholder = "any 2xx/3xx"
+	if pod.HealthCheckStatus != 0 {
+		inputs[fieldHealthStatus].SetValue(strconv.Itoa(pod.HealthCheckStatus))
+	}
+	inputs[fieldHealthInterval].Placeholder = strconv.Itoa(model.DefaultHealthCheckInterval)
+	inputs[fieldHealthInterval].SetValue(strconv.Itoa(pod.HealthCheckInterval))
+	inputs[fieldHealthTimeout].Placeholder = strconv.Itoa(model.DefaultHealthCheckTimeout)
+	inputs[fieldHealthTimeout].SetValue(strconv.Itoa(pod.HealthCheckTimeout))
+	inputs[fieldHealthGracePeriod].Placeholder = strconv.Itoa(model.DefaultHealthCheckGracePeriod)
+	inputs[fieldHealthGracePeriod].SetValue(strconv.Itoa(pod.HealthCheckGracePeriod))
+	inputs[fieldHealthPath].Focus()
+
+	return podHealth{
+		pod:         pod,
+		project:     project,
+		inputs:      inputs,
+		keySave:     key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyCancel:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+	}
+}
+
+func (m podHealth) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m podHealth) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Blink passthrough
+	var cmd tea.Cmd
+	m.inputs[m.focusedField], cmd = m.inputs[m.focusedField].Update(tmsg)
+	return m, cmd
+}
+
+func (m *podHealth) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyCancel):
+		podID := m.pod.ID
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, podID) },
+			}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		pod, ok := m.healthCheck()
+		if !ok {
+			return m, func() tea.Msg {
+				return msg.ShowStatus{Text: "Status, interval, timeout and grace period must be numbers", Type: msg.StatusError}
+			}
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Saving health check"} },
+			api.UpdatePod(&pod),
+		)
+
+	case key.Matches(tmsg, m.keyTab):
+		m.focusedField = (m.focusedField + 1) % numHealthFields
+		return m, m.updateFocus()
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		m.focusedField = (m.focusedField + numHealthFields - 1) % numHealthFields
+		return m, m.updateFocus()
+	}
+
+	// Update focused input
+	var cmd tea.Cmd
+	m.inputs[m.focusedField], cmd = m.inputs[m.focusedField].Update(tmsg)
+	return m, cmd
+}
+
+// healthCheck returns a copy of the pod with the entered health check.
+// Empty fields are sent as zero, the server applies the defaults.
+func (m podHealth) healthCheck() (model.Pod, bool) {
+	pod := *m.pod
+	pod.HealthCheckPath = strings.TrimSpace(m.inputs[fieldHealthPath].Value())
+
+	numbers := map[int]*int{
+		fieldHealthStatus:      &pod.HealthCheckStatus,
+		fieldHealthInterval:    &pod.HealthCheckInterval,
+		fieldHealthTimeout:     &pod.HealthCheckTimeout,
+		fieldHealthGracePeriod: &pod.HealthCheckGracePeriod,
+	}
+	for field, target := range numbers {
+		value := strings.TrimSpace(m.inputs[field].Value())
+		if value == "" {
+			*target = 0
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return pod, false
+		}
+		*target = n
+	}
+	return pod, true
+}
+
+func (m *podHealth) updateFocus() tea.Cmd {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+	return m.inputs[m.focusedField].Focus()
+}
+
+func (m podHealth) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Health Check"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("New containers must pass it before they replace the old one"))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+
+	labels := []string{"Path", "Expected Status", "Interval (seconds)", "Timeout (seconds)", "Grace Period (seconds)"}
+	for i, label := range labels {
+		if m.focusedField == i {
+			b.WriteString(activeLabel.Render(label))
+		} else {
+			b.WriteString(labelStyle.Render(label))
+		}
+		b.WriteString("\n")
+		b.WriteString(m.inputs[i].View())
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(styles.MutedStyle().Render("Not healthy within the grace period? The deploy fails\nand the previous container keeps running."))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podHealth) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Health Check"}
+}
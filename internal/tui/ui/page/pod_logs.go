@@ -1,9 +1,7 @@
 package page
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 
@@ -12,25 +10,33 @@ import (
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 	"github.com/deeploy-sh/deeploy/internal/shared/model"
-	"github.com/deeploy-sh/deeploy/internal/tui/config"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
 	"github.com/deeploy-sh/deeploy/internal/tui/msg"
 	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
 )
 
-// logsResponse from the API
-type logsResponse struct {
-	Logs   []string `json:"logs"`
-	Status string   `json:"status"`
+// pollBuildLogMsg triggers a refresh of a build log page that is still being written
+type pollBuildLogMsg struct {
+	deploymentID string
+	offset       int
 }
 
-// pollLogsMsg triggers a poll
-type pollLogsMsg struct{}
+// reconnectLogStreamMsg resumes the log stream after a connection error
+type reconnectLogStreamMsg struct{}
 
-// logsUpdated contains fetched logs
-type logsUpdated struct {
-	logs   []string
-	status string
-}
+// maxLogLines caps the streamed lines kept in memory
+const maxLogLines = 5000
+
+// buildLogPageSize is the number of lines fetched per build log page.
+const buildLogPageSize = 500
+
+// logsMode selects what the logs page shows.
+type logsMode int
+
+const (
+	logsModeContainer logsMode = iota // current build (while building) or container output
+	logsModeBuild                     // persisted build log of a selected deployment
+)
 
 // podLogs displays streaming build/container logs with auto-scroll.
 // Uses bubbles/viewport for proper scrolling and dimension constraints.
@@ -41,11 +47,29 @@ type podLogs struct {
 	viewport  viewport.Model // handles scrolling, truncation, rendering
 	logs      []string       // raw log lines from API
 	status    string         // building, running, failed
+	mode      logsMode
 	keyBack   key.Binding
 	keyDeploy key.Binding
+	keyMode   key.Binding
+	keyOlder  key.Binding
+	keyNewer  key.Binding
+	keyPrev   key.Binding
+	keyNext   key.Binding
 	width     int
 	height    int
 	cardProps styles.CardProps
+
+	// Build log mode: deployments newest first, selected index and current page
+	deployments   []model.Deployment
+	deploymentIdx int
+	logOffset     int
+	logTotal      int
+	jumpToEnd     bool // load the last page once the total line count is known
+
+	// Container mode: open log stream and the cursor of the last received line
+	stream       <-chan model.LogEvent
+	cancelStream func()
+	cursor       string
 }
 
 func NewPodLogs(s msg.Store, podID string) podLogs {
@@ -70,74 +94,143 @@ func NewPodLogs(s msg.Store, podID string) podLogs {
 		pod:       &pod,
 		project:   &project,
 		viewport:  viewport.New(),
-		status:    "building",
+		status:    pod.Status,
 		keyBack:   key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("esc/q", "back")),
 		keyDeploy: key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "redeploy")),
+		keyMode:   key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "build/container logs")),
+		keyOlder:  key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/→", "older/newer deployment")),
+		keyNewer:  key.NewBinding(key.WithKeys("right", "l")),
+		keyPrev:   key.NewBinding(key.WithKeys("["), key.WithHelp("[/]", "prev/next page")),
+		keyNext:   key.NewBinding(key.WithKeys("]")),
 	}
 }
 
 func (m podLogs) Init() tea.Cmd {
-	return tea.Batch(
-		m.fetchLogs(),
-		m.schedulePoll(),
-	)
+	return api.OpenLogStream(m.pod.ID, "")
 }
 
-func (m podLogs) schedulePoll() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
-		return pollLogsMsg{}
-	})
+// closeStream stops the current log stream (if any).
+func (m *podLogs) closeStream() {
+	if m.cancelStream != nil {
+		m.cancelStream()
+	}
+	m.stream = nil
+	m.cancelStream = nil
 }
 
-func (m podLogs) fetchLogs() tea.Cmd {
-	return func() tea.Msg {
-		cfg, err := config.Load()
-		if err != nil {
-			return logsUpdated{logs: []string{"Error: " + err.Error()}, status: "error"}
-		}
+// restartStream drops the shown lines and opens a fresh log stream.
+func (m *podLogs) restartStream(text string) tea.Cmd {
+	m.closeStream()
+	m.cursor = ""
+	m.logs = nil
+	if text != "" {
+		m.logs = []string{text}
+	}
+	m.updateViewport()
+	return api.OpenLogStream(m.pod.ID, "")
+}
 
-		url := fmt.Sprintf("%s/api/pods/%s/logs", cfg.Server, m.pod.ID)
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return logsUpdated{logs: []string{"Error: " + err.Error()}, status: "error"}
-		}
-		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+func (m podLogs) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return logsUpdated{logs: []string{"Error: " + err.Error()}, status: "error"}
+	switch tmsg := tmsg.(type) {
+	case msg.LogStreamOpened:
+		if tmsg.PodID != m.pod.ID || m.mode != logsModeContainer {
+			tmsg.Cancel()
+			return m, nil
+		}
+		m.closeStream()
+		m.stream = tmsg.Events
+		m.cancelStream = tmsg.Cancel
+		return m, api.NextLogEvent(m.pod.ID, m.stream)
+
+	case msg.LogStreamEvent:
+		if tmsg.Events != m.stream {
+			return m, nil
 		}
-		defer resp.Body.Close()
+		switch tmsg.Event.Source {
+		case model.LogSourceStatus:
+			m.status = tmsg.Event.Data
+		default:
+			m.logs = append(m.logs, tmsg.Event.Data)
+			if len(m.logs) > maxLogLines {
+				m.logs = m.logs[len(m.logs)-maxLogLines:]
+			}
+			m.cursor = tmsg.Event.ID
+			m.updateViewport()
+		}
+		return m, api.NextLogEvent(m.pod.ID, m.stream)
 
-		var result logsResponse
-		err = json.NewDecoder(resp.Body).Decode(&result)
-		if err != nil {
-			return logsUpdated{logs: []string{"Error: " + err.Error()}, status: "error"}
+	case msg.LogStreamClosed:
+		if tmsg.PodID != m.pod.ID || (tmsg.Events != nil && tmsg.Events != m.stream) {
+			return m, nil
+		}
+		m.closeStream()
+		// Connection lost - resume from the last received line
+		if tmsg.Err != nil && m.mode == logsModeContainer {
+			return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+				return reconnectLogStreamMsg{}
+			})
 		}
+		return m, nil
 
-		return logsUpdated{logs: result.Logs, status: result.Status}
-	}
-}
+	case reconnectLogStreamMsg:
+		if m.mode == logsModeContainer && m.stream == nil {
+			return m, api.OpenLogStream(m.pod.ID, m.cursor)
+		}
+		return m, nil
 
-func (m podLogs) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
+	case msg.PodDeployed:
+		return m, m.restartStream("Starting new deployment...")
 
-	switch tmsg := tmsg.(type) {
-	case pollLogsMsg:
-		// Keep polling while building
-		if m.status == "building" {
-			return m, tea.Batch(m.fetchLogs(), m.schedulePoll())
+	case pollBuildLogMsg:
+		// Refresh the selected build log page while its deployment is still building
+		if m.mode == logsModeBuild && len(m.deployments) > 0 &&
+			tmsg.deploymentID == m.deployments[m.deploymentIdx].ID && tmsg.offset == m.logOffset {
+			return m, m.fetchDeploymentLogs()
 		}
 		return m, nil
 
-	case logsUpdated:
-		m.logs = tmsg.logs
-		m.status = tmsg.status
+	case msg.PodDeploymentsLoaded:
+		if tmsg.PodID != m.pod.ID || m.mode != logsModeBuild {
+			return m, nil
+		}
+		m.deployments = tmsg.Deployments
+		if len(m.deployments) == 0 {
+			m.logs = []string{"No deployments yet."}
+			m.status = ""
+			m.updateViewport()
+			return m, nil
+		}
+		return m.selectDeployment(0)
+
+	case msg.DeploymentLogsLoaded:
+		if m.mode != logsModeBuild || len(m.deployments) == 0 || tmsg.DeploymentID != m.deployments[m.deploymentIdx].ID {
+			return m, nil
+		}
+		m.logTotal = tmsg.Logs.Total
+		m.status = tmsg.Logs.Status
+		if m.jumpToEnd {
+			m.jumpToEnd = false
+			lastPage := max(m.logTotal-1, 0) / buildLogPageSize * buildLogPageSize
+			if lastPage != m.logOffset {
+				m.logOffset = lastPage
+				return m, m.fetchDeploymentLogs()
+			}
+		}
+		m.logs = tmsg.Logs.Lines
 		m.updateViewport()
+		if m.status == "building" {
+			deploymentID, offset := tmsg.DeploymentID, m.logOffset
+			return m, tea.Tick(time.Second, func(t time.Time) tea.Msg {
+				return pollBuildLogMsg{deploymentID: deploymentID, offset: offset}
+			})
+		}
 		return m, nil
 
 	case tea.KeyPressMsg:
 		if key.Matches(tmsg, m.keyBack) {
+			m.closeStream()
 			podID := m.pod.ID
 			return m, func() tea.Msg {
 				return msg.ChangePage{
@@ -145,15 +238,48 @@ func (m podLogs) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
+		if key.Matches(tmsg, m.keyMode) {
+			if m.mode == logsModeContainer {
+				m.closeStream()
+				m.mode = logsModeBuild
+				m.logs = []string{"Loading deployments..."}
+				m.updateViewport()
+				return m, api.FetchPodDeployments(m.pod.ID)
+			}
+			m.mode = logsModeContainer
+			return m, m.restartStream("")
+		}
+		if m.mode == logsModeBuild && len(m.deployments) > 0 {
+			switch {
+			case key.Matches(tmsg, m.keyOlder):
+				if m.deploymentIdx < len(m.deployments)-1 {
+					return m.selectDeployment(m.deploymentIdx + 1)
+				}
+				return m, nil
+			case key.Matches(tmsg, m.keyNewer):
+				if m.deploymentIdx > 0 {
+					return m.selectDeployment(m.deploymentIdx - 1)
+				}
+				return m, nil
+			case key.Matches(tmsg, m.keyPrev):
+				if m.logOffset > 0 {
+					m.logOffset = max(m.logOffset-buildLogPageSize, 0)
+					return m, m.fetchDeploymentLogs()
+				}
+				return m, nil
+			case key.Matches(tmsg, m.keyNext):
+				if m.logOffset+buildLogPageSize < m.logTotal {
+					m.logOffset += buildLogPageSize
+					return m, m.fetchDeploymentLogs()
+				}
+				return m, nil
+			}
+		}
 		if key.Matches(tmsg, m.keyDeploy) {
-			// Redeploy - trigger deploy and restart polling
-			m.status = "building"
-			m.logs = []string{"Starting new deployment..."}
-			m.updateViewport()
-			return m, tea.Batch(
-				m.triggerDeploy(),
-				m.schedulePoll(),
-			)
+			// Redeploy - the stream is reopened once the deployment is created (PodDeployed)
+			m.mode = logsModeContainer
+			m.closeStream()
+			return m, api.DeployPod(m.pod.ID, false)
 		}
 
 		// viewport handles up/down/pgup/pgdown/home/end natively
@@ -181,15 +307,17 @@ func (m podLogs) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m podLogs) triggerDeploy() tea.Cmd {
-	return func() tea.Msg {
-		cfg, _ := config.Load()
-		url := fmt.Sprintf("%s/api/pods/%s/deploy", cfg.Server, m.pod.ID)
-		req, _ := http.NewRequest("POST", url, nil)
-		req.Header.Set("Authorization", "Bearer "+cfg.Token)
-		http.DefaultClient.Do(req)
-		return pollLogsMsg{}
-	}
+// selectDeployment shows the build log of the deployment at index i (newest = 0).
+func (m podLogs) selectDeployment(i int) (tea.Model, tea.Cmd) {
+	m.deploymentIdx = i
+	m.logOffset = 0
+	m.logTotal = 0
+	m.jumpToEnd = true
+	return m, m.fetchDeploymentLogs()
+}
+
+func (m podLogs) fetchDeploymentLogs() tea.Cmd {
+	return api.FetchDeploymentLogs(m.pod.ID, m.deployments[m.deploymentIdx].ID, m.logOffset, buildLogPageSize)
 }
 
 // updateViewport syncs logs to viewport with "follow mode":
@@ -211,6 +339,18 @@ func (m podLogs) View() tea.View {
 	bg := styles.ColorBackgroundPanel()
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary()).Background(bg)
 	header := titleStyle.Render(fmt.Sprintf("Build Logs: %s", m.pod.Title))
+	if m.mode == logsModeBuild && len(m.deployments) > 0 {
+		d := m.deployments[m.deploymentIdx]
+		title := fmt.Sprintf("Build Log #%d: %s", len(m.deployments)-m.deploymentIdx, m.pod.Title)
+		if d.CommitSHA != nil && len(*d.CommitSHA) >= 7 {
+			title += " @ " + (*d.CommitSHA)[:7]
+		}
+		header = titleStyle.Render(title)
+		if m.logTotal > buildLogPageSize {
+			pageInfo := fmt.Sprintf("  lines %d-%d of %d", m.logOffset+1, min(m.logOffset+buildLogPageSize, m.logTotal), m.logTotal)
+			header += styles.MutedStyle().Background(bg).Render(pageInfo)
+		}
+	}
 
 	var statusText string
 	switch m.status {
@@ -254,9 +394,13 @@ func (m podLogs) Breadcrumbs() []string {
 }
 
 func (m podLogs) HelpKeys() []key.Binding {
-	return []key.Binding{
+	keys := []key.Binding{
 		key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
 		key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "redeploy")),
-		key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
+		m.keyMode,
+	}
+	if m.mode == logsModeBuild {
+		keys = append(keys, m.keyOlder, m.keyPrev)
 	}
+	return append(keys, key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")))
 }
@@ -0,0 +1,169 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+type podRegistry struct {
+	pod         *model.Pod
+	project     *model.Project
+	credentials []model.RegistryCredential
+	selected    int // 0 = none, 1+ = credential index
+	keySelect   key.Binding
+	keyBack     key.Binding
+	width       int
+	height      int
+}
+
+func (m podRegistry) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySelect, m.keyBack}
+}
+
+func NewPodRegistry(pod *model.Pod, project *model.Project, credentials []model.RegistryCredential) podRegistry {
+	// Find current selection
+	selected := 0
+	if pod.RegistryCredentialID != nil {
+		for i, c := range credentials {
+			if c.ID == *pod.RegistryCredentialID {
+				selected = i + 1
+				break
+			}
+		}
+	}
+
+	return podRegistry{
+		pod:         pod,
+		project:     project,
+		credentials: credentials,
+		selected:    selected,
+		keySelect:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m podRegistry) Init() tea.Cmd {
+	return nil
+}
+
+func (m podRegistry) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m *podRegistry) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		podID := m.pod.ID
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDetail(s, podID)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keySelect):
+		return m.selectCredential()
+
+	case tmsg.Code == tea.KeyUp:
+		if m.selected > 0 {
+			m.selected--
+		}
+		return m, nil
+
+	case tmsg.Code == tea.KeyDown:
+		if m.selected < len(m.credentials) {
+			m.selected++
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m *podRegistry) selectCredential() (tea.Model, tea.Cmd) {
+	if m.selected == 0 {
+		m.pod.RegistryCredentialID = nil
+	} else if m.selected <= len(m.credentials) {
+		credentialID := m.credentials[m.selected-1].ID
+		m.pod.RegistryCredentialID = &credentialID
+	}
+
+	return m, api.UpdatePod(m.pod)
+}
+
+func (m podRegistry) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Select Registry Credentials"))
+	b.WriteString("\n\n")
+
+	cursorStyle := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+	normalStyle := lipgloss.NewStyle()
+
+	// None option
+	cursor := "  "
+	style := normalStyle
+	if m.selected == 0 {
+		cursor = "> "
+		style = selectedStyle
+	}
+	b.WriteString(cursorStyle.Render(cursor))
+	b.WriteString(style.Render("(none - public image)"))
+	b.WriteString("\n")
+
+	// Credential options
+	for i, c := range m.credentials {
+		cursor = "  "
+		style = normalStyle
+		if m.selected == i+1 {
+			cursor = "> "
+			style = selectedStyle
+		}
+		b.WriteString(cursorStyle.Render(cursor))
+		b.WriteString(style.Render(fmt.Sprintf("%s [%s]", c.Name, c.Registry)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if len(m.credentials) == 0 {
+		b.WriteString(styles.MutedStyle().Render("No registry credentials configured."))
+		b.WriteString("\n")
+	}
+	b.WriteString(styles.MutedStyle().Render("Manage credentials: Alt+P > Registry Credentials"))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podRegistry) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Registry"}
+}
@@ -19,13 +19,45 @@ type podVars struct {
 	textarea textarea.Model
 	envVars  []model.PodEnvVar
 	keySave  key.Binding
+	keyScope key.Binding
 	keyBack  key.Binding
 	width    int
 	height   int
 }
 
 func (m podVars) HelpKeys() []key.Binding {
-	return []key.Binding{m.keySave, m.keyBack}
+	return []key.Binding{m.keySave, m.keyScope, m.keyBack}
+}
+
+// Scope prefixes in the editor. Runtime vars have no prefix.
+const (
+	scopePrefixBuild = "[build] "
+	scopePrefixBoth  = "[both] "
+)
+
+// splitScope strips the scope prefix of an editor line and returns the scope.
+func splitScope(line string) (string, string) {
+	if rest, ok := strings.CutPrefix(line, strings.TrimSpace(scopePrefixBuild)); ok {
+		return model.EnvVarScopeBuild, strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(line, strings.TrimSpace(scopePrefixBoth)); ok {
+		return model.EnvVarScopeBoth, strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(line, "[runtime]"); ok {
+		return model.EnvVarScopeRuntime, strings.TrimSpace(rest)
+	}
+	return model.EnvVarScopeRuntime, line
+}
+
+func scopePrefix(scope string) string {
+	switch scope {
+	case model.EnvVarScopeBuild:
+		return scopePrefixBuild
+	case model.EnvVarScopeBoth:
+		return scopePrefixBoth
+	default:
+		return ""
+	}
 }
 
 func NewPodVars(s msg.Store, pod *model.Pod, project *model.Project) podVars {
@@ -45,6 +77,7 @@ func NewPodVars(s msg.Store, pod *model.Pod, project *model.Project) podVars {
 		textarea: ta,
 		envVars:  envVars,
 		keySave:  key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyScope: key.NewBinding(key.WithKeys("ctrl+b"), key.WithHelp("ctrl+b", "toggle scope")),
 		keyBack:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
 	}
 	ta.SetValue(m.envVarsToText())
@@ -75,6 +108,11 @@ func (m podVars) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.save()
 		}
 
+		if key.Matches(tmsg, m.keyScope) {
+			m.toggleScope()
+			return m, nil
+		}
+
 		var cmd tea.Cmd
 		m.textarea, cmd = m.textarea.Update(tmsg)
 		return m, cmd
@@ -93,7 +131,7 @@ func (m podVars) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 func (m podVars) envVarsToText() string {
 	var lines []string
 	for _, v := range m.envVars {
-		lines = append(lines, v.Key+"="+v.Value)
+		lines = append(lines, scopePrefix(v.Scope)+v.Key+"="+v.Value)
 	}
 	return strings.Join(lines, "\n")
 }
@@ -108,6 +146,7 @@ func (m podVars) textToEnvVars() []model.PodEnvVar {
 			continue
 		}
 
+		scope, line := splitScope(line)
 		parts := strings.SplitN(line, "=", 2)
 		if len(parts) != 2 {
 			continue
@@ -123,12 +162,45 @@ func (m podVars) textToEnvVars() []model.PodEnvVar {
 		vars = append(vars, model.PodEnvVar{
 			Key:   key,
 			Value: value,
+			Scope: scope,
 		})
 	}
 
 	return vars
 }
 
+// toggleScope cycles the scope of the var on the cursor line: runtime -> build -> both.
+func (m *podVars) toggleScope() {
+	row := m.textarea.Line()
+	lines := strings.Split(m.textarea.Value(), "\n")
+	if row >= len(lines) || strings.TrimSpace(lines[row]) == "" {
+		return
+	}
+
+	scope, rest := splitScope(strings.TrimSpace(lines[row]))
+	switch scope {
+	case model.EnvVarScopeRuntime:
+		scope = model.EnvVarScopeBuild
+	case model.EnvVarScopeBuild:
+		scope = model.EnvVarScopeBoth
+	default:
+		scope = model.EnvVarScopeRuntime
+	}
+	lines[row] = scopePrefix(scope) + rest
+
+	// SetValue moves the cursor to the end - put it back on the edited line
+	m.textarea.SetValue(strings.Join(lines, "\n"))
+	m.textarea.MoveToBegin()
+	// CursorDown walks soft-wrapped rows too, so step until the logical line is reached
+	for range m.textarea.Length() {
+		if m.textarea.Line() >= row {
+			break
+		}
+		m.textarea.CursorDown()
+	}
+	m.textarea.CursorEnd()
+}
+
 func (m *podVars) save() (tea.Model, tea.Cmd) {
 	vars := m.textToEnvVars()
 	return m, tea.Batch(
@@ -143,7 +215,7 @@ func (m podVars) View() tea.View {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
 	b.WriteString(titleStyle.Render("Environment Variables"))
 	b.WriteString("\n")
-	b.WriteString(styles.MutedStyle().Render("One KEY=value per line."))
+	b.WriteString(styles.MutedStyle().Render("One KEY=value per line. [build] = build arg, [both] = build arg and runtime."))
 	b.WriteString("\n\n")
 
 	b.WriteString(m.textarea.View())
@@ -0,0 +1,200 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// volumeItem wraps PodVolume to implement ScrollItem interface
+type volumeItem struct {
+	volume model.PodVolume
+}
+
+func (v volumeItem) Title() string       { return v.volume.Source + " → " + v.volume.MountPath }
+func (v volumeItem) FilterValue() string { return v.volume.MountPath }
+func (v volumeItem) Suffix() string {
+	badges := v.volume.Type
+	if v.volume.ReadOnly {
+		badges += " ro"
+	}
+	return badges
+}
+
+var podVolumesCard = styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+type podVolumes struct {
+	pod        *model.Pod
+	project    *model.Project
+	volumes    components.ScrollList
+	loading    bool
+	keyAdd     key.Binding
+	keyEdit    key.Binding
+	keyBackups key.Binding
+	keyDelete  key.Binding
+	keyBack    key.Binding
+	width      int
+	height     int
+}
+
+func (m podVolumes) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyAdd, m.keyEdit, m.keyBackups, m.keyDelete, m.keyBack}
+}
+
+func NewPodVolumes(pod *model.Pod, project *model.Project) podVolumes {
+	return podVolumes{
+		pod:        pod,
+		project:    project,
+		volumes:    components.NewScrollList(nil, components.ScrollListConfig{Width: podVolumesCard.InnerWidth(), Height: 8}),
+		loading:    true,
+		keyAdd:     key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new")),
+		keyEdit:    key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit")),
+		keyBackups: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "backups")),
+		keyDelete:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+		keyBack:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m podVolumes) Init() tea.Cmd {
+	return api.FetchPodVolumes(m.pod.ID)
+}
+
+func (m podVolumes) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.PodVolumesLoaded:
+		if tmsg.PodID != m.pod.ID {
+			return m, nil
+		}
+		m.loading = false
+		items := make([]components.ScrollItem, len(tmsg.Volumes))
+		for i, v := range tmsg.Volumes {
+			items[i] = volumeItem{volume: v}
+		}
+		m.volumes.SetItems(items)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.volumes, _ = m.volumes.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m podVolumes) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		podID := m.pod.ID
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodDetail(s, podID)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keyAdd):
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodVolumesForm(pod, project, nil)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keyEdit):
+		if item := m.volumes.SelectedItem(); item != nil {
+			volume := item.(volumeItem).volume
+			pod := m.pod
+			project := m.project
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model {
+						return NewPodVolumesForm(pod, project, &volume)
+					},
+				}
+			}
+		}
+
+	case key.Matches(tmsg, m.keyBackups):
+		if item := m.volumes.SelectedItem(); item != nil {
+			volume := item.(volumeItem).volume
+			if volume.Type != model.VolumeTypeVolume {
+				return m, func() tea.Msg {
+					return msg.ShowStatus{Text: "Only named volumes can be backed up", Type: msg.StatusError}
+				}
+			}
+			pod := m.pod
+			project := m.project
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model {
+						return NewVolumeBackups(volume, pod, project)
+					},
+				}
+			}
+		}
+
+	case key.Matches(tmsg, m.keyDelete):
+		if item := m.volumes.SelectedItem(); item != nil {
+			volume := item.(volumeItem).volume
+			pod := m.pod
+			project := m.project
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model {
+						return NewPodVolumesDelete(volume, pod, project)
+					},
+				}
+			}
+		}
+	}
+
+	// Let ScrollList handle navigation (up/down/j/k/mouse)
+	m.volumes, _ = m.volumes.Update(tmsg)
+	return m, nil
+}
+
+func (m podVolumes) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Volumes"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Persistent storage for " + m.pod.Title))
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else if len(m.volumes.Items()) == 0 {
+		b.WriteString(styles.MutedStyle().Render("No volumes configured."))
+		b.WriteString("\n\n")
+		b.WriteString(styles.MutedStyle().Render("Press 'n' to add one. Files outside of volumes are lost on every deploy."))
+	} else {
+		b.WriteString(m.volumes.View())
+	}
+
+	card := styles.Card(podVolumesCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podVolumes) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Volumes"}
+}
@@ -0,0 +1,126 @@
+package page
+
+import (
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+type podVolumesDelete struct {
+	volume     model.PodVolume
+	pod        *model.Pod
+	project    *model.Project
+	input      textinput.Model
+	keyConfirm key.Binding
+	keyCancel  key.Binding
+	width      int
+	height     int
+}
+
+func (p podVolumesDelete) HelpKeys() []key.Binding {
+	return []key.Binding{p.keyConfirm, p.keyCancel}
+}
+
+func NewPodVolumesDelete(volume model.PodVolume, pod *model.Pod, project *model.Project) podVolumesDelete {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	ti := components.NewTextInput(card.InnerWidth())
+	ti.Placeholder = volume.MountPath
+	ti.Focus()
+	ti.CharLimit = 255
+
+	return podVolumesDelete{
+		volume:     volume,
+		pod:        pod,
+		project:    project,
+		input:      ti,
+		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (p podVolumesDelete) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (p podVolumesDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		switch tmsg.Code {
+		case tea.KeyEscape:
+			pod := p.pod
+			project := p.project
+			return p, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewPodVolumes(pod, project) },
+				}
+			}
+		case tea.KeyEnter:
+			// Only delete if input matches mount path exactly
+			if p.input.Value() != p.volume.MountPath {
+				return p, nil
+			}
+			return p, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Removing volume"} },
+				api.DeletePodVolume(p.pod.ID, p.volume.ID),
+			)
+		}
+
+	case tea.WindowSizeMsg:
+		p.width = tmsg.Width
+		p.height = tmsg.Height
+		return p, nil
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(tmsg)
+	return p, cmd
+}
+
+func (p podVolumesDelete) View() tea.View {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.ColorPrimary()).
+		Render("Remove Volume")
+
+	volumeName := lipgloss.NewStyle().
+		Bold(true).
+		Render(p.volume.Source + " → " + p.volume.MountPath)
+
+	note := styles.MutedStyle().
+		Render("The volume is detached on the next deploy, its data is kept.")
+
+	hint := styles.MutedStyle().
+		Render("Type '" + p.volume.MountPath + "' to confirm")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		volumeName,
+		note,
+		"",
+		hint,
+		"",
+		p.input.View(),
+	)
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(content)
+
+	centered := lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (p podVolumesDelete) Breadcrumbs() []string {
+	return []string{"Projects", p.project.Title, "Pods", p.pod.Title, "Volumes", "Delete"}
+}
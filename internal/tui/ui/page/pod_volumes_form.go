@@ -0,0 +1,283 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldVolumeType = iota
+	fieldVolumeSource
+	fieldVolumeMountPath
+	fieldVolumeReadOnly
+)
+
+const numVolumeFields = 4
+
+type podVolumesForm struct {
+	volume         *model.PodVolume // nil = create, otherwise edit
+	pod            *model.Pod
+	project        *model.Project
+	volumeType     string
+	readOnly       bool
+	sourceInput    textinput.Model
+	mountPathInput textinput.Model
+	focusedField   int
+	keySave        key.Binding
+	keyBack        key.Binding
+	keyTab         key.Binding
+	keyShiftTab    key.Binding
+	keyToggle      key.Binding
+	width          int
+	height         int
+}
+
+func (m podVolumesForm) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyToggle, m.keyBack}
+}
+
+func NewPodVolumesForm(pod *model.Pod, project *model.Project, volume *model.PodVolume) podVolumesForm {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	inputWidth := card.InnerWidth()
+
+	sourceInput := components.NewTextInput(inputWidth)
+	sourceInput.CharLimit = 255
+
+	mountPathInput := components.NewTextInput(inputWidth)
+	mountPathInput.Placeholder = "/app/data"
+	mountPathInput.CharLimit = 255
+
+	volumeType := model.VolumeTypeVolume
+	readOnly := false
+	if volume != nil {
+		volumeType = volume.Type
+		readOnly = volume.ReadOnly
+		sourceInput.SetValue(volume.Source)
+		mountPathInput.SetValue(volume.MountPath)
+	}
+
+	m := podVolumesForm{
+		volume:         volume,
+		pod:            pod,
+		project:        project,
+		volumeType:     volumeType,
+		readOnly:       readOnly,
+		sourceInput:    sourceInput,
+		mountPathInput: mountPathInput,
+		focusedField:   fieldVolumeSource,
+		keySave:        key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyBack:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:         key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab:    key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+		keyToggle:      key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "toggle")),
+	}
+	m.updateSourcePlaceholder()
+	m.sourceInput.Focus()
+	return m
+}
+
+func (m podVolumesForm) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m podVolumesForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Update focused input for blink messages
+	return m, m.updateInput(tmsg)
+}
+
+func (m *podVolumesForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		pod := m.pod
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model {
+					return NewPodVolumes(pod, project)
+				},
+			}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		return m.save()
+
+	case key.Matches(tmsg, m.keyTab):
+		m.focusedField = (m.focusedField + 1) % numVolumeFields
+		return m, m.updateFocus()
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		m.focusedField = (m.focusedField + numVolumeFields - 1) % numVolumeFields
+		return m, m.updateFocus()
+
+	case m.focusedField == fieldVolumeType && key.Matches(tmsg, m.keyToggle):
+		if m.volumeType == model.VolumeTypeBind {
+			m.volumeType = model.VolumeTypeVolume
+		} else {
+			m.volumeType = model.VolumeTypeBind
+		}
+		m.updateSourcePlaceholder()
+		return m, nil
+
+	case m.focusedField == fieldVolumeReadOnly && key.Matches(tmsg, m.keyToggle):
+		m.readOnly = !m.readOnly
+		return m, nil
+	}
+
+	return m, m.updateInput(tmsg)
+}
+
+func (m *podVolumesForm) updateInput(tmsg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.focusedField {
+	case fieldVolumeSource:
+		m.sourceInput, cmd = m.sourceInput.Update(tmsg)
+	case fieldVolumeMountPath:
+		m.mountPathInput, cmd = m.mountPathInput.Update(tmsg)
+	}
+	return cmd
+}
+
+func (m *podVolumesForm) updateSourcePlaceholder() {
+	if m.volumeType == model.VolumeTypeBind {
+		m.sourceInput.Placeholder = "/srv/" + m.pod.Title
+	} else {
+		m.sourceInput.Placeholder = m.pod.Title + "-data"
+	}
+}
+
+func (m *podVolumesForm) updateFocus() tea.Cmd {
+	m.sourceInput.Blur()
+	m.mountPathInput.Blur()
+	switch m.focusedField {
+	case fieldVolumeSource:
+		return m.sourceInput.Focus()
+	case fieldVolumeMountPath:
+		return m.mountPathInput.Focus()
+	}
+	return nil
+}
+
+func (m *podVolumesForm) save() (tea.Model, tea.Cmd) {
+	volume := model.PodVolume{
+		Type:      m.volumeType,
+		Source:    strings.TrimSpace(m.sourceInput.Value()),
+		MountPath: strings.TrimSpace(m.mountPathInput.Value()),
+		ReadOnly:  m.readOnly,
+	}
+	if volume.Source == "" || volume.MountPath == "" {
+		return m, func() tea.Msg {
+			return msg.ShowStatus{Text: "Source and mount path are required", Type: msg.StatusError}
+		}
+	}
+
+	// Create
+	if m.volume == nil {
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Adding volume"} },
+			api.CreatePodVolume(m.pod.ID, volume),
+		)
+	}
+
+	// Update
+	volume.ID = m.volume.ID
+	return m, tea.Batch(
+		func() tea.Msg { return msg.StartLoading{Text: "Updating volume"} },
+		api.UpdatePodVolume(m.pod.ID, volume),
+	)
+}
+
+func (m podVolumesForm) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	if m.volume == nil {
+		b.WriteString(titleStyle.Render("New Volume"))
+	} else {
+		b.WriteString(titleStyle.Render("Edit Volume"))
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Changes apply on the next deploy"))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+	label := func(field int, text string) string {
+		if m.focusedField == field {
+			return activeLabel.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	// Type
+	b.WriteString(label(fieldVolumeType, "Type"))
+	b.WriteString("\n")
+	b.WriteString(renderOptions([]string{model.VolumeTypeVolume, model.VolumeTypeBind}, m.volumeType, labelStyle, activeLabel))
+	b.WriteString("\n\n")
+
+	// Source
+	if m.volumeType == model.VolumeTypeBind {
+		b.WriteString(label(fieldVolumeSource, "Host Path"))
+	} else {
+		b.WriteString(label(fieldVolumeSource, "Volume Name"))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.sourceInput.View())
+	b.WriteString("\n\n")
+
+	// Mount Path
+	b.WriteString(label(fieldVolumeMountPath, "Mount Path"))
+	b.WriteString("\n")
+	b.WriteString(m.mountPathInput.View())
+	b.WriteString("\n\n")
+
+	// Read Only
+	b.WriteString(label(fieldVolumeReadOnly, "Access"))
+	b.WriteString("\n")
+	access := "read-write"
+	if m.readOnly {
+		access = "read-only"
+	}
+	b.WriteString(renderOptions([]string{"read-write", "read-only"}, access, labelStyle, activeLabel))
+
+	if m.volumeType == model.VolumeTypeBind {
+		b.WriteString("\n\n")
+		b.WriteString(styles.MutedStyle().Render("Bind mounts give the container access to the host."))
+	}
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m podVolumesForm) Breadcrumbs() []string {
+	if m.volume == nil {
+		return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Volumes", "New"}
+	}
+	return []string{"Projects", m.project.Title, "Pods", m.pod.Title, "Volumes", "Edit"}
+}
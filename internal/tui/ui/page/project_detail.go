@@ -13,18 +13,20 @@ import (
 type projectDetail struct {
 	store          msg.Store
 	project        *model.Project
-	pods           components.ScrollList
+	pods           components.ScrollList // pods and databases
 	keyNewPod      key.Binding
+	keyNewDatabase key.Binding
 	keySelectPod   key.Binding
 	keyDeletePod   key.Binding
 	keyEditProject key.Binding
+	keyMembers     key.Binding
 	keyBack        key.Binding
 	width          int
 	height         int
 }
 
 func (m projectDetail) HelpKeys() []key.Binding {
-	return []key.Binding{m.keyNewPod, m.keySelectPod, m.keyDeletePod, m.keyEditProject, m.keyBack}
+	return []key.Binding{m.keyNewPod, m.keyNewDatabase, m.keySelectPod, m.keyDeletePod, m.keyEditProject, m.keyMembers, m.keyBack}
 }
 
 func NewProjectDetail(s msg.Store, projectID string) projectDetail {
@@ -43,8 +45,15 @@ func NewProjectDetail(s msg.Store, projectID string) projectDetail {
 		}
 	}
 
+	var databases []model.Database
+	for _, d := range s.Databases() {
+		if d.ProjectID == projectID {
+			databases = append(databases, d)
+		}
+	}
+
 	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 1}, Accent: true}
-	l := components.NewScrollList(components.PodsToItems(pods), components.ScrollListConfig{
+	l := components.NewScrollList(components.ResourcesToItems(pods, databases), components.ScrollListConfig{
 		Width:  card.InnerWidth(),
 		Height: 15,
 	})
@@ -54,9 +63,11 @@ func NewProjectDetail(s msg.Store, projectID string) projectDetail {
 		pods:           l,
 		project:        &project,
 		keyNewPod:      key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new pod")),
-		keyDeletePod:   key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete pod")),
-		keySelectPod:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select pod")),
+		keyNewDatabase: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "new database")),
+		keyDeletePod:   key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+		keySelectPod:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
 		keyEditProject: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit project")),
+		keyMembers:     key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "members")),
 		keyBack:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
 	}
 }
@@ -78,7 +89,13 @@ func (m projectDetail) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 				pods = append(pods, p)
 			}
 		}
-		m.pods.SetItems(components.PodsToItems(pods))
+		var databases []model.Database
+		for _, d := range tmsg.Databases {
+			if d.ProjectID == m.project.ID {
+				databases = append(databases, d)
+			}
+		}
+		m.pods.SetItems(components.ResourcesToItems(pods, databases))
 
 		// Update project data too (for title changes)
 		for _, p := range tmsg.Projects {
@@ -100,21 +117,41 @@ func (m projectDetail) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, func() tea.Msg {
 				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewPodForm(projectID, nil) }}
 			}
+		case key.Matches(tmsg, m.keyNewDatabase):
+			projectID := m.project.ID
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewDatabaseForm(projectID) }}
+			}
 		case key.Matches(tmsg, m.keySelectPod):
-			item := m.pods.SelectedItem()
-			if item != nil {
-				pod := item.(components.PodItem).Pod
+			switch item := m.pods.SelectedItem().(type) {
+			case components.PodItem:
+				pod := item.Pod
 				return m, func() tea.Msg {
 					return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewPodDetail(s, pod.ID) }}
 				}
+			case components.DatabaseItem:
+				databaseID := item.Database.ID
+				return m, func() tea.Msg {
+					return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewDatabaseDetail(s, databaseID) }}
+				}
 			}
 		case key.Matches(tmsg, m.keyDeletePod):
-			item := m.pods.SelectedItem()
-			if item != nil {
-				pod := item.(components.PodItem).Pod
+			switch item := m.pods.SelectedItem().(type) {
+			case components.PodItem:
+				pod := item.Pod
 				return m, func() tea.Msg {
 					return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewPodDelete(&pod) }}
 				}
+			case components.DatabaseItem:
+				database := item.Database
+				return m, func() tea.Msg {
+					return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewDatabaseDelete(&database) }}
+				}
+			}
+		case key.Matches(tmsg, m.keyMembers):
+			project := m.project
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectMembers(project, "") }}
 			}
 		case key.Matches(tmsg, m.keyEditProject):
 			if m.project != nil {
@@ -156,7 +193,7 @@ func (m projectDetail) renderContent() string {
 		Foreground(styles.ColorPrimary()).
 		PaddingLeft(1).
 		PaddingBottom(1).
-		Render(m.project.Title + " > Pods")
+		Render(m.project.Title + " > Resources")
 
 	podsContent := lipgloss.NewStyle().
 		Width(w).
@@ -177,7 +214,7 @@ func (m projectDetail) renderEmptyState() string {
 
 	return lipgloss.JoinVertical(lipgloss.Center,
 		titleStyle.Render("No pods yet"),
-		styles.MutedStyle().Render("Press 'n' to create your first pod"),
+		styles.MutedStyle().Render("Press 'n' to create your first pod or 'b' for a database"),
 	)
 }
 
@@ -0,0 +1,209 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// projectRoles in the order "r" cycles through them.
+var projectRoles = []string{model.ProjectRoleViewer, model.ProjectRoleDeveloper, model.ProjectRoleOwner}
+
+// memberItem wraps a member or pending invite to implement ScrollItem interface
+type memberItem struct {
+	member *model.ProjectMember
+	invite *model.ProjectInvite
+}
+
+func (i memberItem) Title() string {
+	if i.invite != nil {
+		return i.invite.Email
+	}
+	return i.member.Email
+}
+
+func (i memberItem) FilterValue() string { return i.Title() }
+
+func (i memberItem) Suffix() string {
+	if i.invite != nil {
+		return i.invite.Role + " (invited)"
+	}
+	return i.member.Role
+}
+
+var membersCard = styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+// projectMembers lists the members and pending invites of a project.
+// Only owners can change them.
+type projectMembers struct {
+	project   *model.Project
+	list      components.ScrollList
+	inviteURL string // shown once after an invite was created
+	loading   bool
+	keyInvite key.Binding
+	keyRole   key.Binding
+	keyRemove key.Binding
+	keyBack   key.Binding
+	width     int
+	height    int
+}
+
+func (m projectMembers) HelpKeys() []key.Binding {
+	if !m.isOwner() {
+		return []key.Binding{m.keyBack}
+	}
+	return []key.Binding{m.keyInvite, m.keyRole, m.keyRemove, m.keyBack}
+}
+
+func NewProjectMembers(project *model.Project, inviteURL string) projectMembers {
+	return projectMembers{
+		project:   project,
+		list:      components.NewScrollList(nil, components.ScrollListConfig{Width: membersCard.InnerWidth(), Height: 10}),
+		inviteURL: inviteURL,
+		loading:   true,
+		keyInvite: key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "invite")),
+		keyRole:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "change role")),
+		keyRemove: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "remove")),
+		keyBack:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m projectMembers) isOwner() bool {
+	return m.project.Role == model.ProjectRoleOwner
+}
+
+func (m projectMembers) Init() tea.Cmd {
+	return api.FetchMembers(m.project.ID)
+}
+
+func (m projectMembers) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.MembersLoaded:
+		if tmsg.ProjectID != m.project.ID {
+			return m, nil
+		}
+		m.loading = false
+		var items []components.ScrollItem
+		for i := range tmsg.Members.Members {
+			items = append(items, memberItem{member: &tmsg.Members.Members[i]})
+		}
+		for i := range tmsg.Members.Invites {
+			items = append(items, memberItem{invite: &tmsg.Members.Invites[i]})
+		}
+		m.list.SetItems(items)
+		return m, nil
+
+	case msg.MemberRoleUpdated:
+		return m, tea.Batch(
+			api.FetchMembers(m.project.ID),
+			func() tea.Msg { return msg.ShowStatus{Text: "Role changed", Type: msg.StatusSuccess} },
+		)
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m projectMembers) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		projectID := m.project.ID
+		return m, func() tea.Msg {
+			return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectDetail(s, projectID) }}
+		}
+
+	case !m.isOwner():
+		// Members without owner role only read the list
+
+	case key.Matches(tmsg, m.keyInvite):
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectMembersForm(project) }}
+		}
+
+	case key.Matches(tmsg, m.keyRole):
+		item, ok := m.list.SelectedItem().(memberItem)
+		if !ok || item.member == nil {
+			return m, nil
+		}
+		role := nextProjectRole(item.member.Role)
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Changing role"} },
+			api.UpdateMemberRole(m.project.ID, item.member.UserID, role),
+		)
+
+	case key.Matches(tmsg, m.keyRemove):
+		item, ok := m.list.SelectedItem().(memberItem)
+		if !ok {
+			return m, nil
+		}
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectMembersDelete(project, item) }}
+		}
+	}
+
+	// Let ScrollList handle navigation (up/down/j/k/mouse)
+	m.list, _ = m.list.Update(tmsg)
+	return m, nil
+}
+
+// nextProjectRole returns the role after role in projectRoles.
+func nextProjectRole(role string) string {
+	for i, r := range projectRoles {
+		if r == role {
+			return projectRoles[(i+1)%len(projectRoles)]
+		}
+	}
+	return projectRoles[0]
+}
+
+func (m projectMembers) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Members"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Viewers read, developers deploy, owners manage members"))
+	b.WriteString("\n\n")
+
+	if m.inviteURL != "" {
+		labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+		b.WriteString(labelStyle.Render(fmt.Sprintf("Invite link (shown once, valid for %d days)", model.DefaultInviteDays)))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Width(membersCard.InnerWidth()).Render(m.inviteURL))
+		b.WriteString("\n\n")
+	}
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else {
+		b.WriteString(m.list.View())
+	}
+
+	card := styles.Card(membersCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m projectMembers) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Members"}
+}
@@ -0,0 +1,121 @@
+package page
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// projectMembersDelete confirms removing a member or revoking an invite.
+type projectMembersDelete struct {
+	project    *model.Project
+	item       memberItem
+	keyConfirm key.Binding
+	keyCancel  key.Binding
+	width      int
+	height     int
+}
+
+func (p projectMembersDelete) HelpKeys() []key.Binding {
+	return []key.Binding{p.keyConfirm, p.keyCancel}
+}
+
+func NewProjectMembersDelete(project *model.Project, item memberItem) projectMembersDelete {
+	return projectMembersDelete{
+		project:    project,
+		item:       item,
+		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (p projectMembersDelete) Init() tea.Cmd {
+	return nil
+}
+
+func (p projectMembersDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.MemberRemoved:
+		return p, p.done("Member removed")
+
+	case msg.InviteRevoked:
+		return p, p.done("Invite revoked")
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, p.keyCancel):
+			return p, p.done("")
+
+		case key.Matches(tmsg, p.keyConfirm):
+			if p.item.invite != nil {
+				return p, tea.Batch(
+					func() tea.Msg { return msg.StartLoading{Text: "Revoking invite"} },
+					api.RevokeInvite(p.project.ID, p.item.invite.ID),
+				)
+			}
+			return p, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Removing member"} },
+				api.RemoveMember(p.project.ID, p.item.member.UserID),
+			)
+		}
+
+	case tea.WindowSizeMsg:
+		p.width = tmsg.Width
+		p.height = tmsg.Height
+	}
+
+	return p, nil
+}
+
+// done returns to the members page, which reloads its list.
+func (p projectMembersDelete) done(status string) tea.Cmd {
+	project := p.project
+	back := func() tea.Msg {
+		return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectMembers(project, "") }}
+	}
+	if status == "" {
+		return back
+	}
+	return tea.Batch(
+		func() tea.Msg { return msg.ShowStatus{Text: status, Type: msg.StatusSuccess} },
+		back,
+	)
+}
+
+func (p projectMembersDelete) View() tea.View {
+	action := "Remove " + p.item.Title()
+	hint := "They lose access to " + p.project.Title + "."
+	if p.item.invite != nil {
+		action = "Revoke invite for " + p.item.Title()
+		hint = "The invite link stops working."
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Render(action)
+
+	body := styles.MutedStyle().
+		PaddingTop(1).
+		Render(hint)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, body)
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthSM,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(content)
+
+	centered := lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (p projectMembersDelete) Breadcrumbs() []string {
+	return []string{"Projects", p.project.Title, "Members", "Remove"}
+}
@@ -0,0 +1,175 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldInviteEmail = iota
+	fieldInviteRole
+)
+
+// projectMembersForm invites someone to a project by email.
+type projectMembersForm struct {
+	project      *model.Project
+	emailInput   textinput.Model
+	role         string
+	focusedField int
+	keySave      key.Binding
+	keyCancel    key.Binding
+	keyTab       key.Binding
+	keyToggle    key.Binding
+	width        int
+	height       int
+}
+
+func (m projectMembersForm) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyToggle, m.keyCancel}
+}
+
+func NewProjectMembersForm(project *model.Project) projectMembersForm {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+	emailInput := components.NewTextInput(card.InnerWidth())
+	emailInput.Placeholder = "name@example.com"
+	emailInput.CharLimit = 254
+	emailInput.Focus()
+
+	return projectMembersForm{
+		project:    project,
+		emailInput: emailInput,
+		role:       model.ProjectRoleDeveloper,
+		keySave:    key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "invite")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:     key.NewBinding(key.WithKeys("tab", "shift+tab"), key.WithHelp("tab", "next")),
+		keyToggle:  key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "role")),
+	}
+}
+
+func (m projectMembersForm) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m projectMembersForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.MemberInvited:
+		project := m.project
+		url := tmsg.URL
+		return m, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Invite created", Type: msg.StatusSuccess} },
+			func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectMembers(project, url) }}
+			},
+		)
+
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Blink passthrough
+	var cmd tea.Cmd
+	if m.focusedField == fieldInviteEmail {
+		m.emailInput, cmd = m.emailInput.Update(tmsg)
+	}
+	return m, cmd
+}
+
+func (m *projectMembersForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyCancel):
+		project := m.project
+		return m, func() tea.Msg {
+			return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewProjectMembers(project, "") }}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		email := strings.TrimSpace(m.emailInput.Value())
+		if email == "" {
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Creating invite"} },
+			api.InviteMember(m.project.ID, email, m.role),
+		)
+
+	case key.Matches(tmsg, m.keyTab):
+		if m.focusedField == fieldInviteEmail {
+			m.focusedField = fieldInviteRole
+			m.emailInput.Blur()
+			return m, nil
+		}
+		m.focusedField = fieldInviteEmail
+		return m, m.emailInput.Focus()
+
+	case m.focusedField == fieldInviteRole && key.Matches(tmsg, m.keyToggle):
+		m.role = nextProjectRole(m.role)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if m.focusedField == fieldInviteEmail {
+		m.emailInput, cmd = m.emailInput.Update(tmsg)
+	}
+	return m, cmd
+}
+
+func (m projectMembersForm) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Invite Member"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Invite someone to " + m.project.Title))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+	label := func(field int, text string) string {
+		if m.focusedField == field {
+			return activeLabel.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	b.WriteString(label(fieldInviteEmail, "Email"))
+	b.WriteString("\n")
+	b.WriteString(m.emailInput.View())
+	b.WriteString("\n\n")
+
+	b.WriteString(label(fieldInviteRole, "Role"))
+	b.WriteString("\n")
+	b.WriteString(renderOptions(projectRoles, m.role, labelStyle, activeLabel))
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.MutedStyle().Render("You get a link to send, it signs up or signs in\nthe invited email and adds it to the project."))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m projectMembersForm) Breadcrumbs() []string {
+	return []string{"Projects", m.project.Title, "Members", "Invite"}
+}
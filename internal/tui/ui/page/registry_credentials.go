@@ -0,0 +1,143 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+type registryCredentials struct {
+	credentials []model.RegistryCredential
+	selected    int
+	keyAdd      key.Binding
+	keyDelete   key.Binding
+	keyBack     key.Binding
+	width       int
+	height      int
+}
+
+func (m registryCredentials) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyAdd, m.keyDelete, m.keyBack}
+}
+
+func NewRegistryCredentials(credentials []model.RegistryCredential) registryCredentials {
+	return registryCredentials{
+		credentials: credentials,
+		keyAdd:      key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new")),
+		keyDelete:   key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m registryCredentials) Init() tea.Cmd {
+	return nil
+}
+
+func (m registryCredentials) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.DataLoaded:
+		m.credentials = tmsg.RegistryCredentials
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, m.keyBack):
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewDashboard(s) },
+				}
+			}
+
+		case key.Matches(tmsg, m.keyAdd):
+			return m, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewRegistryCredentialForm() },
+				}
+			}
+
+		case key.Matches(tmsg, m.keyDelete):
+			if len(m.credentials) > 0 && m.selected < len(m.credentials) {
+				credential := m.credentials[m.selected]
+				return m, func() tea.Msg {
+					return msg.ChangePage{
+						PageFactory: func(s msg.Store) tea.Model { return NewRegistryCredentialDelete(credential) },
+					}
+				}
+			}
+
+		case tmsg.Code == tea.KeyUp:
+			if m.selected > 0 {
+				m.selected--
+			}
+		case tmsg.Code == tea.KeyDown:
+			if m.selected < len(m.credentials)-1 {
+				m.selected++
+			}
+		// Ctrl+P = previous (up)
+		case tmsg.String() == "ctrl+p":
+			if m.selected > 0 {
+				m.selected--
+			}
+		// Ctrl+N = next (down)
+		case tmsg.String() == "ctrl+n":
+			if m.selected < len(m.credentials)-1 {
+				m.selected++
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m registryCredentials) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Registry Credentials"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Logins for private container registries"))
+	b.WriteString("\n\n")
+
+	if len(m.credentials) == 0 {
+		b.WriteString(styles.MutedStyle().Render("No credentials configured. Press 'n' to add one."))
+	} else {
+		for i, c := range m.credentials {
+			cursor := "  "
+			style := lipgloss.NewStyle()
+			if i == m.selected {
+				cursor = "> "
+				style = style.Foreground(styles.ColorPrimary())
+			}
+
+			registryBadge := fmt.Sprintf("[%s]", c.Registry)
+			line := fmt.Sprintf("%s%s %s", cursor, style.Render(c.Name), styles.MutedStyle().Render(registryBadge))
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m registryCredentials) Breadcrumbs() []string {
+	return []string{"Settings", "Registry Credentials"}
+}
@@ -0,0 +1,116 @@
+package page
+
+import (
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+type registryCredentialDelete struct {
+	credential model.RegistryCredential
+	input      textinput.Model
+	keyConfirm key.Binding
+	keyCancel  key.Binding
+	width      int
+	height     int
+}
+
+func (p registryCredentialDelete) HelpKeys() []key.Binding {
+	return []key.Binding{p.keyConfirm, p.keyCancel}
+}
+
+func NewRegistryCredentialDelete(credential model.RegistryCredential) registryCredentialDelete {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	ti := components.NewTextInput(card.InnerWidth())
+	ti.Placeholder = credential.Name
+	ti.Focus()
+	ti.CharLimit = 100
+
+	return registryCredentialDelete{
+		credential: credential,
+		input:      ti,
+		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (p registryCredentialDelete) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (p registryCredentialDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		switch tmsg.Code {
+		case tea.KeyEscape:
+			return p, func() tea.Msg {
+				return msg.ChangePage{
+					PageFactory: func(s msg.Store) tea.Model { return NewRegistryCredentials(s.RegistryCredentials()) },
+				}
+			}
+		case tea.KeyEnter:
+			// Only delete if input matches credential name exactly
+			if p.input.Value() != p.credential.Name {
+				return p, nil
+			}
+			return p, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Deleting credentials"} },
+				api.DeleteRegistryCredential(p.credential.ID),
+			)
+		}
+
+	case tea.WindowSizeMsg:
+		p.width = tmsg.Width
+		p.height = tmsg.Height
+		return p, nil
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(tmsg)
+	return p, cmd
+}
+
+func (p registryCredentialDelete) View() tea.View {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.ColorPrimary()).
+		Render("Delete Credentials")
+
+	credentialName := lipgloss.NewStyle().
+		Bold(true).
+		Render(p.credential.Name)
+
+	hint := styles.MutedStyle().
+		Render("Type '" + p.credential.Name + "' to confirm")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		credentialName,
+		"",
+		hint,
+		"",
+		p.input.View(),
+	)
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(content)
+
+	centered := lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (p registryCredentialDelete) Breadcrumbs() []string {
+	return []string{"Settings", "Registry Credentials", "Delete"}
+}
@@ -0,0 +1,173 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+const (
+	fieldCredentialName = iota
+	fieldCredentialRegistry
+	fieldCredentialUsername
+	fieldCredentialPassword
+)
+
+const numCredentialFields = 4
+
+type registryCredentialForm struct {
+	inputs       []textinput.Model
+	focusedField int
+	keySave      key.Binding
+	keyCancel    key.Binding
+	keyTab       key.Binding
+	keyShiftTab  key.Binding
+	width        int
+	height       int
+}
+
+func (m registryCredentialForm) HelpKeys() []key.Binding {
+	return []key.Binding{m.keySave, m.keyTab, m.keyCancel}
+}
+
+func NewRegistryCredentialForm() registryCredentialForm {
+	card := styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+	inputWidth := card.InnerWidth()
+
+	inputs := make([]textinput.Model, numCredentialFields)
+	for i := range inputs {
+		inputs[i] = components.NewTextInput(inputWidth)
+		inputs[i].CharLimit = 200
+	}
+
+	inputs[fieldCredentialName].Placeholder = "e.g. GHCR"
+	inputs[fieldCredentialName].CharLimit = 50
+	inputs[fieldCredentialRegistry].Placeholder = "ghcr.io"
+	inputs[fieldCredentialUsername].Placeholder = "username"
+	inputs[fieldCredentialPassword].Placeholder = "password or access token"
+	inputs[fieldCredentialPassword].EchoMode = textinput.EchoPassword
+	inputs[fieldCredentialName].Focus()
+
+	return registryCredentialForm{
+		inputs:      inputs,
+		keySave:     key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyCancel:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		keyTab:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+	}
+}
+
+func (m registryCredentialForm) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m registryCredentialForm) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.KeyPressMsg:
+		return m.handleKeyPress(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+	}
+
+	// Blink passthrough
+	var cmd tea.Cmd
+	m.inputs[m.focusedField], cmd = m.inputs[m.focusedField].Update(tmsg)
+	return m, cmd
+}
+
+func (m *registryCredentialForm) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(tmsg, m.keyCancel):
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model { return NewRegistryCredentials(s.RegistryCredentials()) },
+			}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		credential := model.RegistryCredentialCreate{
+			Name:     strings.TrimSpace(m.inputs[fieldCredentialName].Value()),
+			Registry: strings.TrimSpace(m.inputs[fieldCredentialRegistry].Value()),
+			Username: strings.TrimSpace(m.inputs[fieldCredentialUsername].Value()),
+			Password: strings.TrimSpace(m.inputs[fieldCredentialPassword].Value()),
+		}
+		if credential.Name == "" || credential.Registry == "" || credential.Username == "" || credential.Password == "" {
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Creating credentials"} },
+			api.CreateRegistryCredential(credential),
+		)
+
+	case key.Matches(tmsg, m.keyTab):
+		m.focusedField = (m.focusedField + 1) % numCredentialFields
+		return m, m.updateFocus()
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		m.focusedField = (m.focusedField + numCredentialFields - 1) % numCredentialFields
+		return m, m.updateFocus()
+	}
+
+	// Update focused input
+	var cmd tea.Cmd
+	m.inputs[m.focusedField], cmd = m.inputs[m.focusedField].Update(tmsg)
+	return m, cmd
+}
+
+func (m *registryCredentialForm) updateFocus() tea.Cmd {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+	return m.inputs[m.focusedField].Focus()
+}
+
+func (m registryCredentialForm) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Add Registry Credentials"))
+	b.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+	activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+
+	labels := []string{"Name", "Registry", "Username", "Password"}
+	for i, label := range labels {
+		if m.focusedField == i {
+			b.WriteString(activeLabel.Render(label))
+		} else {
+			b.WriteString(labelStyle.Render(label))
+		}
+		b.WriteString("\n")
+		b.WriteString(m.inputs[i].View())
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(styles.MutedStyle().Render("Password is encrypted at rest"))
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthMD,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(b.String())
+
+	centered := lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m registryCredentialForm) Breadcrumbs() []string {
+	return []string{"Settings", "Registry Credentials", "Add"}
+}
@@ -0,0 +1,257 @@
+package page
+
+import (
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+var serverDNSCard = styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+// serverDNS picks the DNS provider Let's Encrypt certificates are issued
+// with. Field 0 is the provider, the others its credentials.
+type serverDNS struct {
+	settings     model.DNSSettings // saved settings, provider empty = HTTP challenge
+	provider     string
+	inputs       []textinput.Model
+	focusedField int
+	loading      bool
+	keySave      key.Binding
+	keyDelete    key.Binding
+	keyToggle    key.Binding
+	keyTab       key.Binding
+	keyShiftTab  key.Binding
+	keyBack      key.Binding
+	width        int
+	height       int
+}
+
+func NewServerDNS() serverDNS {
+	m := serverDNS{
+		provider:    model.DNSProviderCloudflare,
+		loading:     true,
+		keySave:     key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		keyDelete:   key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "use http challenge")),
+		keyToggle:   key.NewBinding(key.WithKeys("space", "left", "right"), key.WithHelp("space", "change")),
+		keyTab:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+		keyShiftTab: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev")),
+		keyBack:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+	m.resetInputs()
+	return m
+}
+
+func (m serverDNS) Breadcrumbs() []string {
+	return []string{"Settings", "DNS Provider"}
+}
+
+func (m serverDNS) HelpKeys() []key.Binding {
+	keys := []key.Binding{m.keySave, m.keyTab}
+	if m.focusedField == 0 {
+		keys = append(keys, m.keyToggle)
+	}
+	if m.settings.Provider != "" {
+		keys = append(keys, m.keyDelete)
+	}
+	return append(keys, m.keyBack)
+}
+
+func (m serverDNS) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, api.GetDNSSettings())
+}
+
+// resetInputs creates the credential inputs of the selected provider,
+// filled with the saved values. Secrets are never sent back, empty keeps them.
+func (m *serverDNS) resetInputs() {
+	credentials := model.DNSProviderCredentials[m.provider]
+	m.inputs = make([]textinput.Model, len(credentials))
+	for i, c := range credentials {
+		input := components.NewTextInput(serverDNSCard.InnerWidth())
+		input.CharLimit = 500
+		switch {
+		case c.Secret && m.settings.Provider == m.provider:
+			input.Placeholder = "unchanged"
+		case c.Optional:
+			input.Placeholder = "optional"
+		}
+		if c.Secret {
+			input.EchoMode = textinput.EchoPassword
+		}
+		if m.settings.Provider == m.provider {
+			input.SetValue(m.settings.Credentials[c.Key])
+		}
+		m.inputs[i] = input
+	}
+}
+
+func (m *serverDNS) updateFocus() tea.Cmd {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+	if m.focusedField == 0 {
+		return nil
+	}
+	return m.inputs[m.focusedField-1].Focus()
+}
+
+func (m serverDNS) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+		return m, nil
+
+	case msg.DNSSettingsLoaded:
+		m.loading = false
+		m.settings = tmsg.Settings
+		if m.settings.Provider != "" {
+			m.provider = m.settings.Provider
+		}
+		m.resetInputs()
+		return m, nil
+
+	case msg.DNSSettingsSaved:
+		m.settings = tmsg.Settings
+		m.resetInputs()
+		return m, func() tea.Msg {
+			return msg.ShowStatus{Text: "DNS provider saved, Traefik restarted", Type: msg.StatusSuccess}
+		}
+
+	case msg.DNSSettingsDeleted:
+		m.settings = model.DNSSettings{}
+		m.resetInputs()
+		return m, func() tea.Msg {
+			return msg.ShowStatus{Text: "Back to the HTTP challenge", Type: msg.StatusSuccess}
+		}
+
+	case msg.Error:
+		m.loading = false
+		return m, nil
+
+	case tea.KeyPressMsg:
+		if m.loading {
+			return m, nil
+		}
+		return m.handleKeyPress(tmsg)
+	}
+
+	// Blink passthrough
+	if m.focusedField == 0 {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inputs[m.focusedField-1], cmd = m.inputs[m.focusedField-1].Update(tmsg)
+	return m, cmd
+}
+
+func (m serverDNS) handleKeyPress(tmsg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	numFields := len(m.inputs) + 1
+
+	switch {
+	case key.Matches(tmsg, m.keyBack):
+		return m, func() tea.Msg {
+			return msg.ChangePage{
+				PageFactory: func(s msg.Store) tea.Model { return NewDashboard(s) },
+			}
+		}
+
+	case key.Matches(tmsg, m.keySave):
+		settings := model.DNSSettings{Provider: m.provider, Credentials: map[string]string{}}
+		for i, c := range model.DNSProviderCredentials[m.provider] {
+			settings.Credentials[c.Key] = strings.TrimSpace(m.inputs[i].Value())
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Saving DNS provider"} },
+			api.SetDNSSettings(settings),
+		)
+
+	case key.Matches(tmsg, m.keyDelete):
+		if m.settings.Provider == "" {
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg { return msg.StartLoading{Text: "Removing DNS provider"} },
+			api.DeleteDNSSettings(),
+		)
+
+	case key.Matches(tmsg, m.keyTab):
+		m.focusedField = (m.focusedField + 1) % numFields
+		return m, m.updateFocus()
+
+	case key.Matches(tmsg, m.keyShiftTab):
+		m.focusedField = (m.focusedField + numFields - 1) % numFields
+		return m, m.updateFocus()
+
+	case m.focusedField == 0 && key.Matches(tmsg, m.keyToggle):
+		delta := 1
+		if tmsg.String() == "left" {
+			delta = len(model.DNSProviders) - 1
+		}
+		i := slices.Index(model.DNSProviders, m.provider)
+		m.provider = model.DNSProviders[(i+delta)%len(model.DNSProviders)]
+		m.resetInputs()
+		return m, nil
+	}
+
+	if m.focusedField == 0 {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inputs[m.focusedField-1], cmd = m.inputs[m.focusedField-1].Update(tmsg)
+	return m, cmd
+}
+
+func (m serverDNS) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("DNS Provider"))
+	b.WriteString("\n")
+	if m.settings.Provider != "" {
+		b.WriteString(styles.SuccessStyle().Render("Certificates via DNS challenge (" + m.settings.Provider + ")"))
+	} else {
+		b.WriteString(styles.MutedStyle().Render("Certificates via HTTP challenge, no wildcard domains"))
+	}
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else {
+		labelStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted())
+		activeLabel := lipgloss.NewStyle().Foreground(styles.ColorPrimary())
+		label := func(field int, text string) string {
+			if m.focusedField == field {
+				return activeLabel.Render(text)
+			}
+			return labelStyle.Render(text)
+		}
+
+		b.WriteString(label(0, "Provider"))
+		b.WriteString("\n")
+		b.WriteString(renderOptions(model.DNSProviders, m.provider, labelStyle, activeLabel))
+		b.WriteString("\n\n")
+
+		for i, c := range model.DNSProviderCredentials[m.provider] {
+			b.WriteString(label(i+1, c.Label))
+			b.WriteString("\n")
+			b.WriteString(m.inputs[i].View())
+			b.WriteString("\n\n")
+		}
+
+		b.WriteString(styles.MutedStyle().Render("Credentials are encrypted at rest. Saving restarts Traefik,\nredeploy pods to move their certificates."))
+	}
+
+	card := styles.Card(serverDNSCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
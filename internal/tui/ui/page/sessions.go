@@ -0,0 +1,128 @@
+package page
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/components"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// sessionItem wraps model.Session to implement ScrollItem interface
+type sessionItem struct {
+	session model.Session
+}
+
+func (i sessionItem) Title() string {
+	if i.session.Current {
+		return i.session.Client + " (this session)"
+	}
+	return i.session.Client
+}
+
+func (i sessionItem) FilterValue() string { return i.session.Client }
+
+func (i sessionItem) Suffix() string {
+	return i.session.IP + " · seen " + i.session.LastSeenAt.Local().Format("2006-01-02 15:04")
+}
+
+var sessionsCard = styles.CardProps{Width: styles.CardWidthMD, Padding: []int{1, 2}, Accent: true}
+
+// sessions lists where the user is signed in.
+type sessions struct {
+	list      components.ScrollList
+	loading   bool
+	keyDelete key.Binding
+	keyBack   key.Binding
+	width     int
+	height    int
+}
+
+func (m sessions) HelpKeys() []key.Binding {
+	return []key.Binding{m.keyDelete, m.keyBack}
+}
+
+func NewSessions() sessions {
+	return sessions{
+		list:      components.NewScrollList(nil, components.ScrollListConfig{Width: sessionsCard.InnerWidth(), Height: 10}),
+		loading:   true,
+		keyDelete: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "revoke")),
+		keyBack:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	}
+}
+
+func (m sessions) Init() tea.Cmd {
+	return api.FetchSessions()
+}
+
+func (m sessions) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch tmsg := tmsg.(type) {
+	case msg.SessionsLoaded:
+		m.loading = false
+		items := make([]components.ScrollItem, len(tmsg.Sessions))
+		for i, s := range tmsg.Sessions {
+			items[i] = sessionItem{session: s}
+		}
+		m.list.SetItems(items)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, m.keyBack):
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewDashboard(s) }}
+			}
+
+		case key.Matches(tmsg, m.keyDelete):
+			item, ok := m.list.SelectedItem().(sessionItem)
+			if !ok {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewSessionDelete(item.session) }}
+			}
+		}
+
+		// Let ScrollList handle navigation (up/down/j/k/mouse)
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.MouseWheelMsg:
+		m.list, _ = m.list.Update(tmsg)
+
+	case tea.WindowSizeMsg:
+		m.width = tmsg.Width
+		m.height = tmsg.Height
+	}
+
+	return m, nil
+}
+
+func (m sessions) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary())
+	b.WriteString(titleStyle.Render("Sessions"))
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle().Render("Everywhere you are signed in"))
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.MutedStyle().Render("Loading..."))
+	} else {
+		b.WriteString(m.list.View())
+	}
+
+	card := styles.Card(sessionsCard).Render(b.String())
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (m sessions) Breadcrumbs() []string {
+	return []string{"Settings", "Sessions"}
+}
@@ -0,0 +1,101 @@
+package page
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/deeploy-sh/deeploy/internal/shared/model"
+	"github.com/deeploy-sh/deeploy/internal/tui/api"
+	"github.com/deeploy-sh/deeploy/internal/tui/msg"
+	"github.com/deeploy-sh/deeploy/internal/tui/ui/styles"
+)
+
+// sessionDelete confirms revoking a session.
+type sessionDelete struct {
+	session    model.Session
+	keyConfirm key.Binding
+	keyCancel  key.Binding
+	width      int
+	height     int
+}
+
+func (p sessionDelete) HelpKeys() []key.Binding {
+	return []key.Binding{p.keyConfirm, p.keyCancel}
+}
+
+func NewSessionDelete(session model.Session) sessionDelete {
+	return sessionDelete{
+		session:    session,
+		keyConfirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		keyCancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (p sessionDelete) Init() tea.Cmd {
+	return nil
+}
+
+func (p sessionDelete) Update(tmsg tea.Msg) (tea.Model, tea.Cmd) {
+	back := func() tea.Msg {
+		return msg.ChangePage{PageFactory: func(s msg.Store) tea.Model { return NewSessions() }}
+	}
+
+	switch tmsg := tmsg.(type) {
+	case msg.SessionDeleted:
+		// Without its session the TUI is signed out, the sessions page
+		// can't load anymore and sends it to the auth page
+		return p, tea.Batch(
+			func() tea.Msg { return msg.ShowStatus{Text: "Session revoked", Type: msg.StatusSuccess} },
+			back,
+		)
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(tmsg, p.keyCancel):
+			return p, back
+
+		case key.Matches(tmsg, p.keyConfirm):
+			return p, tea.Batch(
+				func() tea.Msg { return msg.StartLoading{Text: "Revoking session"} },
+				api.DeleteSession(p.session.ID),
+			)
+		}
+
+	case tea.WindowSizeMsg:
+		p.width = tmsg.Width
+		p.height = tmsg.Height
+	}
+
+	return p, nil
+}
+
+func (p sessionDelete) View() tea.View {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Render("Revoke " + p.session.Client + " session")
+
+	text := "The session from " + p.session.IP + " is signed out immediately."
+	if p.session.Current {
+		text = "This is the session of this TUI, you will be signed out."
+	}
+	body := styles.MutedStyle().
+		PaddingTop(1).
+		Render(text)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, body)
+
+	card := styles.Card(styles.CardProps{
+		Width:   styles.CardWidthSM,
+		Padding: []int{1, 2},
+		Accent:  true,
+	}).Render(content)
+
+	centered := lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center, card)
+
+	return tea.NewView(centered)
+}
+
+func (p sessionDelete) Breadcrumbs() []string {
+	return []string{"Settings", "Sessions", "Revoke"}
+}